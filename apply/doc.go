@@ -0,0 +1,55 @@
+// Package apply reconciles a ContextForge instance with a manifest of
+// desired gateways, tools, resources, prompts, and servers.
+//
+// A manifest is written in YAML or JSON:
+//
+//	gateways:
+//	  - name: weather
+//	    url: http://weather.internal/mcp
+//	tools:
+//	  - name: search
+//	    description: Search the docs
+//	servers:
+//	  - name: docs
+//	    tools: [search, weather-forecast]
+//
+// Entities are identified by name, or by URI for resources. Optional fields
+// left unset are not managed: they are used when an entity is created but
+// are never compared or changed afterwards.
+//
+// # Planning
+//
+// NewPlan fetches the live state through the existing List methods,
+// including inactive entities, and diffs the manifest against it. The
+// resulting Plan lists the creates, updates, toggles, and deletes needed,
+// and can be printed as a dry run:
+//
+//	m, err := apply.LoadFile("catalog.yaml")
+//	if err != nil {
+//		return err
+//	}
+//	plan, err := apply.NewPlan(ctx, client, m, nil)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Print(plan)
+//
+// Entities are only deleted when Options.Prune is set, and then only those
+// carrying Options.ManagedTag, which the plan adds to every entity it
+// creates or updates.
+//
+// # Executing
+//
+// Plan.Execute applies the changes in dependency order: gateways, tools,
+// resources, prompts, and then the servers that reference them, followed by
+// deletes in the reverse order. Server references are resolved to IDs after
+// gateways have been created, so a server may reference tools federated from
+// a gateway declared in the same manifest.
+//
+// # YAML Support
+//
+// YAML manifests are parsed with gopkg.in/yaml.v3 and then decoded through
+// the same json struct tags as JSON manifests, so field names are identical
+// in both formats. Anchors, aliases, and merge keys (<<) are supported; a
+// file must hold a single document.
+package apply
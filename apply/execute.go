@@ -0,0 +1,305 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+// Execute applies the plan's changes in order, stopping at the first
+// failure. Changes applied before a failure are not rolled back; build a new
+// plan to see what remains.
+//
+// Server tool, resource, and prompt references are resolved to IDs just
+// before the first server change, after gateways have been created and their
+// tools federated. A reference to an entity that does not exist is an error.
+func (p *Plan) Execute(ctx context.Context, client *contextforge.Client) error {
+	e := &executor{client: client, created: make(map[string]string)}
+	for _, c := range p.Changes {
+		if err := e.apply(ctx, c); err != nil {
+			return fmt.Errorf("%s %s %q; %w", c.Action, c.Kind, c.Name, err)
+		}
+	}
+	return nil
+}
+
+// executor carries state between the changes of a plan.
+type executor struct {
+	client *contextforge.Client
+
+	// created maps the kind and key of each entity created so far to its
+	// ID, for toggles that follow a create.
+	created map[string]string
+
+	// refs maps tool names, resource URIs, and prompt names to IDs, once
+	// loaded for the first server change.
+	refs map[Kind]map[string]string
+}
+
+func (e *executor) apply(ctx context.Context, c *Change) error {
+	switch c.Action {
+	case ActionCreate:
+		id, err := e.create(ctx, c)
+		if err != nil {
+			return err
+		}
+		e.created[string(c.Kind)+"\x00"+c.Name] = id
+		return nil
+	case ActionUpdate:
+		return e.update(ctx, c)
+	case ActionToggle:
+		id := c.ID
+		if id == "" {
+			id = e.created[string(c.Kind)+"\x00"+c.Name]
+		}
+		return e.toggle(ctx, c.Kind, id, c.Activate)
+	case ActionDelete:
+		return e.delete(ctx, c.Kind, c.ID)
+	}
+	return fmt.Errorf("unknown action %q", c.Action)
+}
+
+func (e *executor) create(ctx context.Context, c *Change) (string, error) {
+	switch d := c.desired.(type) {
+	case *Gateway:
+		g := &contextforge.Gateway{
+			Name:        d.Name,
+			URL:         d.URL,
+			Description: d.Description,
+			Tags:        contextforge.NewTags(c.tags),
+		}
+		if d.Transport != nil {
+			g.Transport = *d.Transport
+		}
+		created, _, err := e.client.Gateways.Create(ctx, g, &contextforge.GatewayCreateOptions{TeamID: d.TeamID, Visibility: d.Visibility})
+		if err != nil {
+			return "", err
+		}
+		return deref(created.ID), nil
+	case *Tool:
+		t := &contextforge.Tool{
+			Name:        d.Name,
+			Description: d.Description,
+			InputSchema: d.InputSchema,
+			Tags:        contextforge.NewTags(c.tags),
+		}
+		created, _, err := e.client.Tools.Create(ctx, t, &contextforge.ToolCreateOptions{TeamID: d.TeamID, Visibility: d.Visibility})
+		if err != nil {
+			return "", err
+		}
+		return created.ID, nil
+	case *Resource:
+		r := &contextforge.ResourceCreate{
+			URI:         d.URI,
+			Name:        d.Name,
+			Content:     deref(d.Content),
+			Description: d.Description,
+			MimeType:    d.MimeType,
+			Tags:        c.tags,
+		}
+		created, _, err := e.client.Resources.Create(ctx, r, &contextforge.ResourceCreateOptions{TeamID: d.TeamID, Visibility: d.Visibility})
+		if err != nil {
+			return "", err
+		}
+		if created.ID == nil {
+			return "", nil
+		}
+		return created.ID.String(), nil
+	case *Prompt:
+		pr := &contextforge.PromptCreate{
+			Name:        d.Name,
+			Description: d.Description,
+			Template:    d.Template,
+			Arguments:   d.Arguments,
+			Tags:        c.tags,
+		}
+		created, _, err := e.client.Prompts.Create(ctx, pr, &contextforge.PromptCreateOptions{TeamID: d.TeamID, Visibility: d.Visibility})
+		if err != nil {
+			return "", err
+		}
+		return created.ID, nil
+	case *Server:
+		tools, resources, prompts, err := e.resolve(ctx, d)
+		if err != nil {
+			return "", err
+		}
+		s := &contextforge.ServerCreate{
+			Name:                d.Name,
+			Description:         d.Description,
+			Icon:                d.Icon,
+			Tags:                c.tags,
+			AssociatedTools:     tools,
+			AssociatedResources: resources,
+			AssociatedPrompts:   prompts,
+		}
+		created, _, err := e.client.Servers.Create(ctx, s, &contextforge.ServerCreateOptions{TeamID: d.TeamID, Visibility: d.Visibility})
+		if err != nil {
+			return "", err
+		}
+		return created.ID, nil
+	}
+	return "", fmt.Errorf("unsupported entity %T", c.desired)
+}
+
+// update sends the declared fields of the entity. Fields the manifest does
+// not set are omitted and keep their live values.
+func (e *executor) update(ctx context.Context, c *Change) error {
+	switch d := c.desired.(type) {
+	case *Gateway:
+		g := &contextforge.Gateway{
+			Name:        d.Name,
+			URL:         d.URL,
+			Description: d.Description,
+			Visibility:  d.Visibility,
+			Tags:        contextforge.NewTags(c.tags),
+		}
+		if d.Transport != nil {
+			g.Transport = *d.Transport
+		}
+		_, _, err := e.client.Gateways.Update(ctx, c.ID, g)
+		return err
+	case *Tool:
+		t := &contextforge.Tool{
+			Name:        d.Name,
+			Description: d.Description,
+			InputSchema: d.InputSchema,
+			Tags:        contextforge.NewTags(c.tags),
+		}
+		if d.Visibility != nil {
+			t.Visibility = *d.Visibility
+		}
+		_, _, err := e.client.Tools.Update(ctx, c.ID, t)
+		return err
+	case *Resource:
+		r := &contextforge.ResourceUpdate{
+			Name:        &d.Name,
+			Description: d.Description,
+			MimeType:    d.MimeType,
+			Tags:        c.tags,
+		}
+		if d.Content != nil {
+			r.Content = *d.Content
+		}
+		_, _, err := e.client.Resources.Update(ctx, c.ID, r)
+		return err
+	case *Prompt:
+		pr := &contextforge.PromptUpdate{
+			Description: d.Description,
+			Template:    &d.Template,
+			Arguments:   d.Arguments,
+			Tags:        c.tags,
+			Visibility:  d.Visibility,
+		}
+		_, _, err := e.client.Prompts.Update(ctx, c.ID, pr)
+		return err
+	case *Server:
+		tools, resources, prompts, err := e.resolve(ctx, d)
+		if err != nil {
+			return err
+		}
+		s := &contextforge.ServerUpdate{
+			Description:         d.Description,
+			Icon:                d.Icon,
+			Tags:                c.tags,
+			AssociatedTools:     tools,
+			AssociatedResources: resources,
+			AssociatedPrompts:   prompts,
+			Visibility:          d.Visibility,
+		}
+		_, _, err = e.client.Servers.Update(ctx, c.ID, s)
+		return err
+	}
+	return fmt.Errorf("unsupported entity %T", c.desired)
+}
+
+func (e *executor) toggle(ctx context.Context, kind Kind, id string, activate bool) error {
+	var err error
+	switch kind {
+	case KindGateway:
+		_, _, err = e.client.Gateways.SetState(ctx, id, activate)
+	case KindTool:
+		_, _, err = e.client.Tools.SetState(ctx, id, activate)
+	case KindResource:
+		_, _, err = e.client.Resources.SetState(ctx, id, activate)
+	case KindPrompt:
+		_, _, err = e.client.Prompts.SetState(ctx, id, activate)
+	case KindServer:
+		_, _, err = e.client.Servers.SetState(ctx, id, activate)
+	default:
+		err = fmt.Errorf("unknown kind %q", kind)
+	}
+	return err
+}
+
+func (e *executor) delete(ctx context.Context, kind Kind, id string) error {
+	var err error
+	switch kind {
+	case KindGateway:
+		_, err = e.client.Gateways.Delete(ctx, id)
+	case KindTool:
+		_, err = e.client.Tools.Delete(ctx, id)
+	case KindResource:
+		_, err = e.client.Resources.Delete(ctx, id)
+	case KindPrompt:
+		_, err = e.client.Prompts.Delete(ctx, id)
+	case KindServer:
+		_, err = e.client.Servers.Delete(ctx, id)
+	default:
+		err = fmt.Errorf("unknown kind %q", kind)
+	}
+	return err
+}
+
+// resolve maps a server's references to IDs. Undeclared reference lists
+// resolve to nil, leaving the server's associations unchanged.
+func (e *executor) resolve(ctx context.Context, s *Server) (tools, resources, prompts []string, err error) {
+	if e.refs == nil {
+		live, err := fetchState(ctx, e.client)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		e.refs = map[Kind]map[string]string{
+			KindTool:     make(map[string]string),
+			KindResource: make(map[string]string),
+			KindPrompt:   make(map[string]string),
+		}
+		for _, t := range live.tools {
+			e.refs[KindTool][t.Name] = t.ID
+		}
+		for _, r := range live.resources {
+			if r.ID != nil {
+				e.refs[KindResource][r.URI] = r.ID.String()
+			}
+		}
+		for _, pr := range live.prompts {
+			e.refs[KindPrompt][pr.Name] = pr.ID
+		}
+	}
+
+	if tools, err = e.lookup(KindTool, s.Tools); err != nil {
+		return nil, nil, nil, err
+	}
+	if resources, err = e.lookup(KindResource, s.Resources); err != nil {
+		return nil, nil, nil, err
+	}
+	if prompts, err = e.lookup(KindPrompt, s.Prompts); err != nil {
+		return nil, nil, nil, err
+	}
+	return tools, resources, prompts, nil
+}
+
+func (e *executor) lookup(kind Kind, keys []string) ([]string, error) {
+	if keys == nil {
+		return nil, nil
+	}
+	ids := make([]string, len(keys))
+	for i, key := range keys {
+		id, ok := e.refs[kind][key]
+		if !ok {
+			return nil, fmt.Errorf("unknown %s %q", kind, key)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
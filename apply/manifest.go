@@ -0,0 +1,183 @@
+package apply
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+	"github.com/leefowlercu/go-contextforge/internal/yaml"
+	"github.com/leefowlercu/go-contextforge/jsonschema"
+)
+
+// Manifest is the desired state of a ContextForge catalog. Entities are
+// identified by name, except resources, which are identified by URI.
+//
+// Optional fields left unset in a manifest are not managed: they are used
+// when an entity is created but never compared or changed afterwards.
+type Manifest struct {
+	Gateways  []*Gateway  `json:"gateways,omitempty"`
+	Tools     []*Tool     `json:"tools,omitempty"`
+	Resources []*Resource `json:"resources,omitempty"`
+	Prompts   []*Prompt   `json:"prompts,omitempty"`
+	Servers   []*Server   `json:"servers,omitempty"`
+}
+
+// Gateway is the desired state of a federated MCP server.
+type Gateway struct {
+	Name        string   `json:"name"`
+	URL         string   `json:"url"`
+	Description *string  `json:"description,omitempty"`
+	Transport   *string  `json:"transport,omitempty"` // "SSE" or "STREAMABLEHTTP"
+	Tags        []string `json:"tags,omitempty"`
+	Visibility  *string  `json:"visibility,omitempty"`
+	TeamID      *string  `json:"teamId,omitempty"` // used on create only
+	Enabled     *bool    `json:"enabled,omitempty"`
+}
+
+// Tool is the desired state of a REST tool.
+type Tool struct {
+	Name        string         `json:"name"`
+	Description *string        `json:"description,omitempty"`
+	InputSchema map[string]any `json:"inputSchema,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+	Visibility  *string        `json:"visibility,omitempty"`
+	TeamID      *string        `json:"teamId,omitempty"` // used on create only
+	Enabled     *bool          `json:"enabled,omitempty"`
+}
+
+// Resource is the desired state of a resource.
+type Resource struct {
+	URI         string   `json:"uri"`
+	Name        string   `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	MimeType    *string  `json:"mimeType,omitempty"`
+	Content     *string  `json:"content,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Visibility  *string  `json:"visibility,omitempty"` // used on create only
+	TeamID      *string  `json:"teamId,omitempty"`     // used on create only
+	Enabled     *bool    `json:"enabled,omitempty"`
+}
+
+// Prompt is the desired state of a prompt template.
+type Prompt struct {
+	Name        string                        `json:"name"`
+	Description *string                       `json:"description,omitempty"`
+	Template    string                        `json:"template"`
+	Arguments   []contextforge.PromptArgument `json:"arguments,omitempty"`
+	Tags        []string                      `json:"tags,omitempty"`
+	Visibility  *string                       `json:"visibility,omitempty"`
+	TeamID      *string                       `json:"teamId,omitempty"` // used on create only
+	Enabled     *bool                         `json:"enabled,omitempty"`
+}
+
+// Server is the desired state of a virtual server. Its tools, resources,
+// and prompts are referenced by tool name, resource URI, and prompt name;
+// they may be declared in the same manifest or already exist, as the tools
+// federated from a gateway do.
+type Server struct {
+	Name        string   `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	Icon        *string  `json:"icon,omitempty"`
+	Tools       []string `json:"tools,omitempty"`
+	Resources   []string `json:"resources,omitempty"`
+	Prompts     []string `json:"prompts,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Visibility  *string  `json:"visibility,omitempty"`
+	TeamID      *string  `json:"teamId,omitempty"` // used on create only
+	Enabled     *bool    `json:"enabled,omitempty"`
+}
+
+// Load decodes a manifest written in JSON or YAML and validates it. Unknown
+// fields are rejected so that typos do not silently go unmanaged.
+func Load(data []byte) (*Manifest, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '{' {
+		v, err := yaml.Decode(data)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var m Manifest
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("decode manifest; %w", err)
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// LoadFile reads and decodes the manifest in the named file.
+func LoadFile(name string) (*Manifest, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	m, err := Load(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return m, nil
+}
+
+// Validate checks that every entity has its identifying and required
+// fields, that no entity is declared twice, and that tool input schemas are
+// valid JSON Schemas. All problems are reported together.
+func (m *Manifest) Validate() error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	seen := make(map[string]bool)
+	declare := func(kind Kind, i int, key string) bool {
+		if key == "" {
+			fail("%ss[%d]: missing %s", kind, i, kind.keyField())
+			return false
+		}
+		if seen[string(kind)+"\x00"+key] {
+			fail("%s %q declared more than once", kind, key)
+			return false
+		}
+		seen[string(kind)+"\x00"+key] = true
+		return true
+	}
+
+	for i, g := range m.Gateways {
+		if declare(KindGateway, i, g.Name) && g.URL == "" {
+			fail("gateway %q: missing url", g.Name)
+		}
+	}
+	for i, t := range m.Tools {
+		if !declare(KindTool, i, t.Name) || t.InputSchema == nil {
+			continue
+		}
+		if _, err := jsonschema.Compile(t.InputSchema); err != nil {
+			fail("tool %q: invalid inputSchema: %w", t.Name, err)
+		}
+	}
+	for i, r := range m.Resources {
+		if declare(KindResource, i, r.URI) && r.Name == "" {
+			fail("resource %q: missing name", r.URI)
+		}
+	}
+	for i, p := range m.Prompts {
+		if declare(KindPrompt, i, p.Name) && p.Template == "" {
+			fail("prompt %q: missing template", p.Name)
+		}
+	}
+	for i, s := range m.Servers {
+		declare(KindServer, i, s.Name)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,168 @@
+package apply
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+const testManifestYAML = `
+gateways:
+  - name: weather
+    url: http://weather.internal/mcp
+    transport: STREAMABLEHTTP
+
+tools:
+  - name: search
+    description: Search the docs
+    inputSchema:
+      type: object
+      properties:
+        query: {type: string}
+      required: [query]
+    tags: [docs]
+
+prompts:
+  - name: greet
+    template: |
+      Hello {{ name }}
+    arguments:
+      - name: name
+        required: true
+    enabled: false
+
+servers:
+  - name: docs
+    tools: [search, weather-forecast]
+`
+
+const testManifestJSON = `{
+  "gateways": [{"name": "weather", "url": "http://weather.internal/mcp", "transport": "STREAMABLEHTTP"}],
+  "tools": [{
+    "name": "search",
+    "description": "Search the docs",
+    "inputSchema": {"type": "object", "properties": {"query": {"type": "string"}}, "required": ["query"]},
+    "tags": ["docs"]
+  }],
+  "prompts": [{
+    "name": "greet",
+    "template": "Hello {{ name }}\n",
+    "arguments": [{"name": "name", "required": true}],
+    "enabled": false
+  }],
+  "servers": [{"name": "docs", "tools": ["search", "weather-forecast"]}]
+}`
+
+func TestLoad(t *testing.T) {
+	want := &Manifest{
+		Gateways: []*Gateway{{
+			Name:      "weather",
+			URL:       "http://weather.internal/mcp",
+			Transport: contextforge.String("STREAMABLEHTTP"),
+		}},
+		Tools: []*Tool{{
+			Name:        "search",
+			Description: contextforge.String("Search the docs"),
+			InputSchema: map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"query": map[string]any{"type": "string"}},
+				"required":   []any{"query"},
+			},
+			Tags: []string{"docs"},
+		}},
+		Prompts: []*Prompt{{
+			Name:      "greet",
+			Template:  "Hello {{ name }}\n",
+			Arguments: []contextforge.PromptArgument{{Name: "name", Required: true}},
+			Enabled:   contextforge.Bool(false),
+		}},
+		Servers: []*Server{{Name: "docs", Tools: []string{"search", "weather-forecast"}}},
+	}
+
+	for name, input := range map[string]string{"yaml": testManifestYAML, "json": testManifestJSON} {
+		t.Run(name, func(t *testing.T) {
+			got, err := Load([]byte(input))
+			if err != nil {
+				t.Fatalf("Load returned error: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Load() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "catalog.yaml")
+	if err := os.WriteFile(name, []byte("tools:\n  - description: no name\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadFile(name)
+	if err == nil {
+		t.Fatal("LoadFile expected error, got nil")
+	}
+	if want := name + ": tools[0]: missing name"; err.Error() != want {
+		t.Errorf("LoadFile error = %q, want %q", err, want)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr []string
+	}{
+		{
+			name:    "unknown field",
+			input:   "tools:\n  - name: search\n    descripton: typo\n",
+			wantErr: []string{`unknown field "descripton"`},
+		},
+		{
+			name:    "wrong type",
+			input:   `{"tools": {"name": "search"}}`,
+			wantErr: []string{"decode manifest"},
+		},
+		{
+			name: "validation errors are reported together",
+			input: `
+gateways:
+  - name: weather
+resources:
+  - uri: file:///readme
+  - uri: file:///readme
+    name: readme
+prompts:
+  - name: greet
+tools:
+  - name: search
+    inputSchema: {type: objekt}
+`,
+			wantErr: []string{
+				`gateway "weather": missing url`,
+				`resource "file:///readme": missing name`,
+				`resource "file:///readme" declared more than once`,
+				`prompt "greet": missing template`,
+				`tool "search": invalid inputSchema`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load([]byte(tt.input))
+			if err == nil {
+				t.Fatal("Load expected error, got nil")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Load error = %q, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
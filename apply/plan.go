@@ -0,0 +1,594 @@
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+// Kind identifies the type of entity a Change applies to.
+type Kind string
+
+// Entity kinds, in the order their creates and updates are applied.
+// Deletes are applied in the reverse order, so servers are removed before
+// the tools they reference.
+const (
+	KindGateway  Kind = "gateway"
+	KindTool     Kind = "tool"
+	KindResource Kind = "resource"
+	KindPrompt   Kind = "prompt"
+	KindServer   Kind = "server"
+)
+
+var kindOrder = []Kind{KindGateway, KindTool, KindResource, KindPrompt, KindServer}
+
+// keyField returns the name of the field that identifies entities of kind k.
+func (k Kind) keyField() string {
+	if k == KindResource {
+		return "uri"
+	}
+	return "name"
+}
+
+// Action is the operation a Change performs.
+type Action string
+
+// Actions a plan can contain.
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionToggle Action = "toggle" // enable or disable, per Change.Activate
+	ActionDelete Action = "delete"
+)
+
+// Change is a single operation in a Plan.
+type Change struct {
+	Kind   Kind
+	Action Action
+
+	// Name identifies the entity: its name, or its URI for resources.
+	Name string
+
+	// ID is the entity's ID in ContextForge. It is empty for creates, and
+	// for toggles of entities the plan creates.
+	ID string
+
+	// Fields lists the manifest fields that differ, for updates.
+	Fields []string
+
+	// Activate is the desired state, for toggles.
+	Activate bool
+
+	desired any      // the manifest entity, for creates and updates
+	tags    []string // tags to set on create or update, if managed
+}
+
+// String describes the change, for example `update tool "search": tags`.
+func (c *Change) String() string {
+	verb := string(c.Action)
+	if c.Action == ActionToggle {
+		verb = "disable"
+		if c.Activate {
+			verb = "enable"
+		}
+	}
+	s := fmt.Sprintf("%s %s %q", verb, c.Kind, c.Name)
+	if len(c.Fields) > 0 {
+		s += ": " + strings.Join(c.Fields, ", ")
+	}
+	return s
+}
+
+// Options configures how NewPlan reconciles a manifest.
+type Options struct {
+	// ManagedTag, if set, is added to the tags of every entity the plan
+	// creates or updates, marking it as managed by the manifest.
+	ManagedTag string
+
+	// Prune deletes entities that carry ManagedTag but are no longer
+	// declared in the manifest. Entities created by other means, such as
+	// the tools federated from a gateway, are never pruned. Prune requires
+	// ManagedTag.
+	Prune bool
+}
+
+// Plan is the ordered list of changes that brings a ContextForge instance
+// in line with a manifest. Print it for a dry run, or apply it with Execute.
+type Plan struct {
+	Changes []*Change
+}
+
+// Empty reports whether the plan has no changes.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String returns a summary line followed by one line per change, in the
+// order Execute applies them.
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "No changes.\n"
+	}
+
+	counts := make(map[Action]int)
+	for _, c := range p.Changes {
+		counts[c.Action]++
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Plan: %d to create, %d to update, %d to toggle, %d to delete.\n",
+		counts[ActionCreate], counts[ActionUpdate], counts[ActionToggle], counts[ActionDelete])
+	for _, c := range p.Changes {
+		fmt.Fprintf(&b, "  %s\n", c)
+	}
+	return b.String()
+}
+
+// NewPlan fetches the live state of the ContextForge instance with the
+// existing List methods, including inactive entities, and diffs the
+// manifest against it. It makes no changes. The opts parameter is optional;
+// pass nil to use the defaults.
+func NewPlan(ctx context.Context, client *contextforge.Client, m *Manifest, opts *Options) (*Plan, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	if opts.Prune && opts.ManagedTag == "" {
+		return nil, fmt.Errorf("prune requires a managed tag")
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	live, err := fetchState(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &planner{ctx: ctx, client: client, opts: opts}
+	if err := p.plan(m, live); err != nil {
+		return nil, err
+	}
+	p.sort()
+	return &Plan{Changes: p.changes}, nil
+}
+
+// state is the live state of a ContextForge instance.
+type state struct {
+	gateways  []*contextforge.Gateway
+	tools     []*contextforge.Tool
+	resources []*contextforge.Resource
+	prompts   []*contextforge.Prompt
+	servers   []*contextforge.Server
+}
+
+func fetchState(ctx context.Context, client *contextforge.Client) (*state, error) {
+	var s state
+	var err error
+	if s.gateways, err = collect(client.Gateways.All(ctx, &contextforge.GatewayListOptions{IncludeInactive: true})); err != nil {
+		return nil, fmt.Errorf("list gateways; %w", err)
+	}
+	if s.tools, err = collect(client.Tools.All(ctx, &contextforge.ToolListOptions{IncludeInactive: true})); err != nil {
+		return nil, fmt.Errorf("list tools; %w", err)
+	}
+	if s.resources, err = collect(client.Resources.All(ctx, &contextforge.ResourceListOptions{IncludeInactive: true})); err != nil {
+		return nil, fmt.Errorf("list resources; %w", err)
+	}
+	if s.prompts, err = collect(client.Prompts.All(ctx, &contextforge.PromptListOptions{IncludeInactive: true})); err != nil {
+		return nil, fmt.Errorf("list prompts; %w", err)
+	}
+	if s.servers, err = collect(client.Servers.All(ctx, &contextforge.ServerListOptions{IncludeInactive: true})); err != nil {
+		return nil, fmt.Errorf("list servers; %w", err)
+	}
+	return &s, nil
+}
+
+func collect[T any](seq iter.Seq2[*T, error]) ([]*T, error) {
+	var items []*T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// observed is the part of a live entity common to every kind.
+type observed struct {
+	id     string
+	active bool
+	tags   []string
+}
+
+// planner accumulates the changes of a plan.
+type planner struct {
+	ctx     context.Context
+	client  *contextforge.Client
+	opts    *Options
+	changes []*Change
+}
+
+func (p *planner) plan(m *Manifest, live *state) error {
+	// Server associations are stored as IDs; compare them by key.
+	toolNames := make(map[string]string)
+	for _, t := range live.tools {
+		toolNames[t.ID] = t.Name
+	}
+	resourceURIs := make(map[string]string)
+	for _, r := range live.resources {
+		if r.ID != nil {
+			resourceURIs[r.ID.String()] = r.URI
+		}
+	}
+	promptNames := make(map[string]string)
+	for _, pr := range live.prompts {
+		promptNames[pr.ID] = pr.Name
+	}
+
+	gateways, err := index(KindGateway, live.gateways, func(g *contextforge.Gateway) (string, observed) {
+		return g.Name, observed{deref(g.ID), g.Enabled, contextforge.TagNames(g.Tags)}
+	})
+	if err != nil {
+		return err
+	}
+	for _, d := range m.Gateways {
+		l := gateways.take(d.Name)
+		var fields []string
+		if l != nil {
+			fields = diffGateway(d, l.entity)
+		}
+		p.reconcile(KindGateway, d.Name, d, d.Tags, d.Enabled, l.observed(), fields)
+	}
+	prune(p, KindGateway, gateways)
+
+	tools, err := index(KindTool, live.tools, func(t *contextforge.Tool) (string, observed) {
+		return t.Name, observed{t.ID, t.Enabled, contextforge.TagNames(t.Tags)}
+	})
+	if err != nil {
+		return err
+	}
+	for _, d := range m.Tools {
+		l := tools.take(d.Name)
+		var fields []string
+		if l != nil {
+			fields = diffTool(d, l.entity)
+		}
+		p.reconcile(KindTool, d.Name, d, d.Tags, d.Enabled, l.observed(), fields)
+	}
+	prune(p, KindTool, tools)
+
+	resources, err := index(KindResource, live.resources, func(r *contextforge.Resource) (string, observed) {
+		id := ""
+		if r.ID != nil {
+			id = r.ID.String()
+		}
+		return r.URI, observed{id, r.IsActive || r.Enabled, contextforge.TagNames(r.Tags)}
+	})
+	if err != nil {
+		return err
+	}
+	for _, d := range m.Resources {
+		l := resources.take(d.URI)
+		var fields []string
+		if l != nil {
+			fields = diffResource(d, l.entity)
+			if d.Content != nil {
+				content, _, err := p.client.Resources.Get(p.ctx, l.obs.id)
+				if err != nil {
+					return fmt.Errorf("get resource %q; %w", d.URI, err)
+				}
+				if content == nil || deref(content.Text) != *d.Content {
+					fields = append(fields, "content")
+				}
+			}
+		}
+		p.reconcile(KindResource, d.URI, d, d.Tags, d.Enabled, l.observed(), fields)
+	}
+	prune(p, KindResource, resources)
+
+	prompts, err := index(KindPrompt, live.prompts, func(pr *contextforge.Prompt) (string, observed) {
+		return pr.Name, observed{pr.ID, pr.IsActive || pr.Enabled, contextforge.TagNames(pr.Tags)}
+	})
+	if err != nil {
+		return err
+	}
+	for _, d := range m.Prompts {
+		l := prompts.take(d.Name)
+		var fields []string
+		if l != nil {
+			fields = diffPrompt(d, l.entity)
+		}
+		p.reconcile(KindPrompt, d.Name, d, d.Tags, d.Enabled, l.observed(), fields)
+	}
+	prune(p, KindPrompt, prompts)
+
+	servers, err := index(KindServer, live.servers, func(s *contextforge.Server) (string, observed) {
+		return s.Name, observed{s.ID, s.IsActive || s.Enabled, contextforge.TagNames(s.Tags)}
+	})
+	if err != nil {
+		return err
+	}
+	for _, d := range m.Servers {
+		l := servers.take(d.Name)
+		var fields []string
+		if l != nil {
+			fields = diffServer(d, l.entity, toolNames, resourceURIs, promptNames)
+		}
+		p.reconcile(KindServer, d.Name, d, d.Tags, d.Enabled, l.observed(), fields)
+	}
+	prune(p, KindServer, servers)
+
+	return nil
+}
+
+// reconcile adds the changes that bring the live entity, or its absence,
+// in line with its declaration. fields lists the differences found by the
+// kind's diff function, to which tags are added if they differ.
+func (p *planner) reconcile(kind Kind, key string, desired any, tags []string, enabled *bool, live *observed, fields []string) {
+	if live == nil {
+		want, _ := p.tagsFor(tags, nil)
+		p.changes = append(p.changes, &Change{Kind: kind, Action: ActionCreate, Name: key, desired: desired, tags: want})
+		// Entities are created enabled.
+		if enabled != nil && !*enabled {
+			p.changes = append(p.changes, &Change{Kind: kind, Action: ActionToggle, Name: key})
+		}
+		return
+	}
+
+	want, changed := p.tagsFor(tags, live.tags)
+	if changed {
+		fields = append(fields, "tags")
+	}
+	if len(fields) > 0 {
+		p.changes = append(p.changes, &Change{Kind: kind, Action: ActionUpdate, Name: key, ID: live.id, Fields: fields, desired: desired, tags: want})
+	}
+	if enabled != nil && *enabled != live.active {
+		p.changes = append(p.changes, &Change{Kind: kind, Action: ActionToggle, Name: key, ID: live.id, Activate: *enabled})
+	}
+}
+
+// prune adds deletes for the remaining, undeclared live entities that
+// carry the managed tag.
+func prune[T any](p *planner, kind Kind, remaining *liveIndex[T]) {
+	if !p.opts.Prune {
+		return
+	}
+	for _, key := range remaining.keys() {
+		l := remaining.items[key].obs
+		if slices.Contains(l.tags, p.opts.ManagedTag) {
+			p.changes = append(p.changes, &Change{Kind: kind, Action: ActionDelete, Name: key, ID: l.id})
+		}
+	}
+}
+
+// tagsFor returns the tags an entity should have and whether they differ
+// from live. Declared tags replace the live ones; the managed tag is always
+// added. It returns nil if tags are not managed at all.
+func (p *planner) tagsFor(declared, live []string) ([]string, bool) {
+	if declared == nil && p.opts.ManagedTag == "" {
+		return nil, false
+	}
+	want := declared
+	if want == nil {
+		want = live
+	}
+	want = slices.Clone(want)
+	if p.opts.ManagedTag != "" && !slices.Contains(want, p.opts.ManagedTag) {
+		want = append(want, p.opts.ManagedTag)
+	}
+	if want == nil {
+		want = []string{}
+	}
+	return want, !sameSet(want, live)
+}
+
+// sort orders creates, updates, and toggles by kind dependency, followed by
+// deletes in reverse dependency order.
+func (p *planner) sort() {
+	actionOrder := []Action{ActionCreate, ActionUpdate, ActionToggle}
+	rank := func(c *Change) (int, int) {
+		k := slices.Index(kindOrder, c.Kind)
+		if c.Action == ActionDelete {
+			return len(kindOrder) + (len(kindOrder) - k), 0
+		}
+		return k, slices.Index(actionOrder, c.Action)
+	}
+	slices.SortStableFunc(p.changes, func(a, b *Change) int {
+		ka, aa := rank(a)
+		kb, ab := rank(b)
+		if ka != kb {
+			return ka - kb
+		}
+		if aa != ab {
+			return aa - ab
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+// liveIndex indexes the live entities of one kind by key. Entities are
+// removed with take as they are matched to declarations, leaving the
+// undeclared ones.
+type liveIndex[T any] struct {
+	items map[string]*liveEntry[T]
+}
+
+type liveEntry[T any] struct {
+	entity T
+	obs    observed
+}
+
+// observed returns the entry's observed state, or nil if there is no entry.
+func (l *liveEntry[T]) observed() *observed {
+	if l == nil {
+		return nil
+	}
+	return &l.obs
+}
+
+func index[T any](kind Kind, items []T, describe func(T) (string, observed)) (*liveIndex[T], error) {
+	idx := &liveIndex[T]{items: make(map[string]*liveEntry[T], len(items))}
+	for _, item := range items {
+		key, obs := describe(item)
+		if _, dup := idx.items[key]; dup {
+			return nil, fmt.Errorf("multiple live %ss have %s %q", kind, kind.keyField(), key)
+		}
+		idx.items[key] = &liveEntry[T]{entity: item, obs: obs}
+	}
+	return idx, nil
+}
+
+func (l *liveIndex[T]) take(key string) *liveEntry[T] {
+	e := l.items[key]
+	delete(l.items, key)
+	return e
+}
+
+func (l *liveIndex[T]) keys() []string {
+	keys := make([]string, 0, len(l.items))
+	for k := range l.items {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func diffGateway(d *Gateway, l *contextforge.Gateway) []string {
+	var fields []string
+	if d.URL != l.URL {
+		fields = append(fields, "url")
+	}
+	if differs(d.Description, l.Description) {
+		fields = append(fields, "description")
+	}
+	if d.Transport != nil && !strings.EqualFold(*d.Transport, l.Transport) {
+		fields = append(fields, "transport")
+	}
+	if differs(d.Visibility, l.Visibility) {
+		fields = append(fields, "visibility")
+	}
+	return fields
+}
+
+func diffTool(d *Tool, l *contextforge.Tool) []string {
+	var fields []string
+	if differs(d.Description, l.Description) {
+		fields = append(fields, "description")
+	}
+	if d.InputSchema != nil && !jsonEqual(d.InputSchema, l.InputSchema) {
+		fields = append(fields, "inputSchema")
+	}
+	if d.Visibility != nil && *d.Visibility != l.Visibility {
+		fields = append(fields, "visibility")
+	}
+	return fields
+}
+
+func diffResource(d *Resource, l *contextforge.Resource) []string {
+	var fields []string
+	if d.Name != l.Name {
+		fields = append(fields, "name")
+	}
+	if differs(d.Description, l.Description) {
+		fields = append(fields, "description")
+	}
+	if differs(d.MimeType, l.MimeType) {
+		fields = append(fields, "mimeType")
+	}
+	return fields
+}
+
+func diffPrompt(d *Prompt, l *contextforge.Prompt) []string {
+	var fields []string
+	if differs(d.Description, l.Description) {
+		fields = append(fields, "description")
+	}
+	if d.Template != l.Template {
+		fields = append(fields, "template")
+	}
+	if d.Arguments != nil && !jsonEqual(d.Arguments, l.Arguments) {
+		fields = append(fields, "arguments")
+	}
+	if differs(d.Visibility, l.Visibility) {
+		fields = append(fields, "visibility")
+	}
+	return fields
+}
+
+func diffServer(d *Server, l *contextforge.Server, toolNames, resourceURIs, promptNames map[string]string) []string {
+	var fields []string
+	if differs(d.Description, l.Description) {
+		fields = append(fields, "description")
+	}
+	if differs(d.Icon, l.Icon) {
+		fields = append(fields, "icon")
+	}
+	if d.Tools != nil && !sameSet(d.Tools, keysOf(l.AssociatedTools, toolNames)) {
+		fields = append(fields, "tools")
+	}
+	if d.Resources != nil && !sameSet(d.Resources, keysOf(l.AssociatedResources, resourceURIs)) {
+		fields = append(fields, "resources")
+	}
+	if d.Prompts != nil && !sameSet(d.Prompts, keysOf(l.AssociatedPrompts, promptNames)) {
+		fields = append(fields, "prompts")
+	}
+	if differs(d.Visibility, l.Visibility) {
+		fields = append(fields, "visibility")
+	}
+	return fields
+}
+
+// keysOf maps associated IDs to entity keys. Associations the server
+// reports by key rather than ID are kept as they are.
+func keysOf(ids []string, keys map[string]string) []string {
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		if key, ok := keys[id]; ok {
+			out[i] = key
+		} else {
+			out[i] = id
+		}
+	}
+	return out
+}
+
+// differs reports whether a declared optional field differs from the live
+// value. Undeclared fields never differ.
+func differs(declared, live *string) bool {
+	return declared != nil && *declared != deref(live)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// sameSet reports whether a and b hold the same strings, ignoring order.
+func sameSet(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+// jsonEqual reports whether a and b encode to the same JSON value.
+func jsonEqual(a, b any) bool {
+	var va, vb any
+	da, errA := json.Marshal(a)
+	db, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	if json.Unmarshal(da, &va) != nil || json.Unmarshal(db, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
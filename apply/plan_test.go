@@ -0,0 +1,295 @@
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+// testLiveState is the live state used by the plan tests, keyed by the
+// pattern that serves it.
+var testLiveState = map[string]string{
+	"GET /gateways": `[{"id":"g1","name":"weather","url":"http://weather.internal/mcp","transport":"STREAMABLEHTTP","enabled":true}]`,
+	"GET /tools": `[
+		{"id":"t1","name":"search","description":"Old","enabled":true,"tags":["docs"]},
+		{"id":"t2","name":"weather-forecast","enabled":true},
+		{"id":"t3","name":"legacy","enabled":true,"tags":["docs","managed"]}
+	]`,
+	"GET /resources":   `[{"id":7,"uri":"file:///readme","name":"readme","isActive":true,"tags":["managed"]}]`,
+	"GET /resources/7": `{"type":"resource","uri":"file:///readme","text":"old text"}`,
+	"GET /prompts":     `[{"id":"p1","name":"greet","template":"Hello {{ name }}\n","arguments":[{"name":"name","required":true}],"isActive":true,"tags":["managed"]}]`,
+	"GET /servers":     `[{"id":"s1","name":"ops","associatedTools":["t2"],"isActive":true,"tags":["other"]}]`,
+}
+
+// setup starts a test server serving testLiveState, with any entries in
+// overrides replaced, and returns a client for it, the mux to add handlers
+// to, and a function returning the mutating requests received so far.
+func setup(t *testing.T, overrides map[string]string) (*contextforge.Client, *http.ServeMux, func() []string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var calls []string
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			calls = append(calls, strings.TrimSpace(fmt.Sprintf("%s %s %s", r.Method, r.URL.RequestURI(), body)))
+			mu.Unlock()
+			r.Body = io.NopCloser(strings.NewReader(string(body)))
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := contextforge.NewClient(nil, server.URL, "test-token")
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	for pattern, body := range testLiveState {
+		if override, ok := overrides[pattern]; ok {
+			body = override
+		}
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, body)
+		})
+	}
+
+	return client, mux, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), calls...)
+	}
+}
+
+const testPlanManifest = `
+gateways:
+  - name: weather
+    url: http://weather.internal/mcp
+    transport: STREAMABLEHTTP
+tools:
+  - name: search
+    description: Search the docs
+    tags: [docs]
+  - name: fetch
+    description: Fetch a page
+    enabled: false
+resources:
+  - uri: file:///readme
+    name: readme
+    content: new text
+prompts:
+  - name: greet
+    template: |
+      Hello {{ name }}
+    enabled: false
+servers:
+  - name: docs
+    tools: [search, weather-forecast]
+    resources: [file:///readme]
+`
+
+func TestNewPlan(t *testing.T) {
+	client, _, _ := setup(t, nil)
+	m, err := Load([]byte(testPlanManifest))
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	plan, err := NewPlan(context.Background(), client, m, &Options{ManagedTag: "managed", Prune: true})
+	if err != nil {
+		t.Fatalf("NewPlan returned error: %v", err)
+	}
+
+	want := `Plan: 2 to create, 3 to update, 2 to toggle, 1 to delete.
+  update gateway "weather": tags
+  create tool "fetch"
+  update tool "search": description, tags
+  disable tool "fetch"
+  update resource "file:///readme": content
+  disable prompt "greet"
+  create server "docs"
+  delete tool "legacy"
+`
+	if got := plan.String(); got != want {
+		t.Errorf("Plan.String() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestNewPlan_NoChanges(t *testing.T) {
+	client, _, _ := setup(t, nil)
+	m, err := Load([]byte(`
+tools:
+  - name: search
+    description: Old
+    tags: [docs]
+servers:
+  - name: ops
+    tools: [weather-forecast]
+    enabled: true
+`))
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	plan, err := NewPlan(context.Background(), client, m, nil)
+	if err != nil {
+		t.Fatalf("NewPlan returned error: %v", err)
+	}
+	if !plan.Empty() {
+		t.Errorf("NewPlan() = %s, want no changes", plan)
+	}
+	if got, want := plan.String(), "No changes.\n"; got != want {
+		t.Errorf("Plan.String() = %q, want %q", got, want)
+	}
+}
+
+func TestNewPlan_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		manifest  *Manifest
+		opts      *Options
+		overrides map[string]string
+		wantErr   string
+	}{
+		{
+			name:     "prune without managed tag",
+			manifest: &Manifest{},
+			opts:     &Options{Prune: true},
+			wantErr:  "prune requires a managed tag",
+		},
+		{
+			name:     "invalid manifest",
+			manifest: &Manifest{Tools: []*Tool{{}}},
+			wantErr:  "tools[0]: missing name",
+		},
+		{
+			name:      "ambiguous live state",
+			manifest:  &Manifest{},
+			overrides: map[string]string{"GET /tools": `[{"id":"t1","name":"search"},{"id":"t9","name":"search"}]`},
+			wantErr:   `multiple live tools have name "search"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _, _ := setup(t, tt.overrides)
+
+			_, err := NewPlan(context.Background(), client, tt.manifest, tt.opts)
+			if err == nil {
+				t.Fatal("NewPlan expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewPlan error = %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPlan_Execute(t *testing.T) {
+	client, mux, calls := setup(t, nil)
+	mux.HandleFunc("POST /tools", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"t4","name":"fetch"}`)
+	})
+	mux.HandleFunc("POST /servers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"s2","name":"docs"}`)
+	})
+	for _, pattern := range []string{
+		"PUT /gateways/g1", "PUT /tools/t1", "POST /tools/t4/state", "PUT /resources/7",
+		"POST /prompts/p1/state", "DELETE /tools/t3",
+	} {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{}`)
+		})
+	}
+
+	m, err := Load([]byte(testPlanManifest))
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	plan, err := NewPlan(context.Background(), client, m, &Options{ManagedTag: "managed", Prune: true})
+	if err != nil {
+		t.Fatalf("NewPlan returned error: %v", err)
+	}
+
+	if err := plan.Execute(context.Background(), client); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	want := []string{
+		`PUT /gateways/g1 {"name":"weather","url":"http://weather.internal/mcp","transport":"STREAMABLEHTTP","tags":["managed"]}`,
+		`POST /tools {"tool":{"name":"fetch","description":"Fetch a page","tags":["managed"]}}`,
+		`PUT /tools/t1 {"name":"search","description":"Search the docs","tags":["docs","managed"]}`,
+		`POST /tools/t4/state?activate=false`,
+		`PUT /resources/7 {"name":"readme","content":"new text","tags":["managed"]}`,
+		`POST /prompts/p1/state?activate=false`,
+		`POST /servers {"server":{"name":"docs","tags":["managed"],"associated_tools":["t1","t2"],"associated_resources":["7"]}}`,
+		`DELETE /tools/t3`,
+	}
+	if got := calls(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Execute requests =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestPlan_Execute_UnknownReference(t *testing.T) {
+	client, _, calls := setup(t, nil)
+	m := &Manifest{Servers: []*Server{{Name: "docs", Tools: []string{"search", "missing"}}}}
+
+	plan, err := NewPlan(context.Background(), client, m, nil)
+	if err != nil {
+		t.Fatalf("NewPlan returned error: %v", err)
+	}
+
+	err = plan.Execute(context.Background(), client)
+	if want := `create server "docs"; unknown tool "missing"`; err == nil || err.Error() != want {
+		t.Errorf("Execute error = %v, want %q", err, want)
+	}
+	if got := calls(); len(got) != 0 {
+		t.Errorf("Execute sent requests %v, want none", got)
+	}
+}
+
+func TestChange_String(t *testing.T) {
+	tests := []struct {
+		change *Change
+		want   string
+	}{
+		{&Change{Kind: KindGateway, Action: ActionCreate, Name: "weather"}, `create gateway "weather"`},
+		{&Change{Kind: KindTool, Action: ActionUpdate, Name: "search", Fields: []string{"description", "tags"}}, `update tool "search": description, tags`},
+		{&Change{Kind: KindServer, Action: ActionToggle, Name: "docs", Activate: true}, `enable server "docs"`},
+		{&Change{Kind: KindServer, Action: ActionToggle, Name: "docs"}, `disable server "docs"`},
+		{&Change{Kind: KindResource, Action: ActionDelete, Name: "file:///readme"}, `delete resource "file:///readme"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.change.String(); got != tt.want {
+				t.Errorf("Change.String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONEqual(t *testing.T) {
+	a := map[string]any{"type": "object", "required": []string{"q"}}
+	b := map[string]any{"required": []any{"q"}, "type": "object"}
+	if !jsonEqual(a, b) {
+		t.Error("jsonEqual() = false for equivalent values, want true")
+	}
+	var c map[string]any
+	_ = json.Unmarshal([]byte(`{"type":"array"}`), &c)
+	if jsonEqual(a, c) {
+		t.Error("jsonEqual() = true for different values, want false")
+	}
+}
@@ -0,0 +1,47 @@
+// Package cassette records HTTP interactions with a ContextForge server to
+// files, called cassettes, and replays them, so tests written against a
+// live server can run offline and deterministically.
+//
+// A Recorder is an http.RoundTripper. In ModeRecord it sends requests to
+// the server and keeps each request and response; Stop writes them to the
+// cassette file. In ModeReplay it answers requests from the cassette
+// without touching the network:
+//
+//	rec, err := cassette.New("testdata/cassettes/TestTools.json", cassette.ModeAuto)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	t.Cleanup(func() {
+//		if err := rec.Stop(); err != nil {
+//			t.Error(err)
+//		}
+//	})
+//
+//	client, err := contextforge.NewClient(rec.Client(), address, token)
+//
+// # Matching
+//
+// While replaying, each request is answered with the first interaction not
+// yet replayed whose request matches it. DefaultMatcher compares the
+// method, path, and query parameters, so requests must be made in the same
+// order and to the same URLs as when recording, but bodies may differ, for
+// example in generated names. Use WithMatcher for stricter or looser
+// matching.
+//
+// # Redaction
+//
+// Secrets are removed before interactions are kept: the values of the
+// headers in DefaultRedactedHeaders, such as Authorization, and of the JSON
+// body fields in DefaultRedactedFields, such as password, access_token, and
+// the authentication values of gateways and agents, are replaced with
+// Redacted, and request URLs are recorded as sanitized by
+// contextforge.SanitizeURL, which redacts invitation tokens. Replayed responses carry the redacted values, so a login
+// replays a token of "REDACTED"; requests sent with it still match, since
+// headers are not compared.
+//
+// Event streams (text/event-stream responses), such as those of agent
+// invocations and MCP sessions, are passed to the caller as they arrive
+// while recording. The part of the stream the caller read is recorded when
+// it closes the response body, with the fields redacted in the JSON data of
+// each event, and replayed as a stream that ends after it.
+package cassette
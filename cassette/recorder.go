@@ -0,0 +1,308 @@
+package cassette
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+// Mode selects whether a Recorder records or replays interactions.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette and fails requests
+	// that match no recorded interaction. No request reaches the network.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends requests to the server and records the
+	// interactions, replacing the cassette when the Recorder stops.
+	ModeRecord
+
+	// ModeAuto replays the cassette if its file exists, and records it
+	// otherwise.
+	ModeAuto
+)
+
+// String returns the name ParseMode accepts for m.
+func (m Mode) String() string {
+	switch m {
+	case ModeReplay:
+		return "replay"
+	case ModeRecord:
+		return "record"
+	case ModeAuto:
+		return "auto"
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// ParseMode returns the mode named "replay", "record", or "auto".
+func ParseMode(s string) (Mode, error) {
+	for _, m := range []Mode{ModeReplay, ModeRecord, ModeAuto} {
+		if strings.EqualFold(s, m.String()) {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown cassette mode %q (want replay, record, or auto)", s)
+}
+
+// Matcher reports whether a request matches a recorded one. body is the
+// body of r, which has already been read.
+type Matcher func(r *http.Request, body []byte, recorded *Request) bool
+
+// DefaultMatcher matches requests with the same method, path, and query
+// parameters, ignoring the scheme and host, headers, and body. Paths are
+// compared as recorded, sanitized with contextforge.SanitizeURL, so a
+// request accepting an invitation matches whatever its token. Since
+// interactions are matched in recording order, requests that differ only
+// in their body, such as successive creates, replay in sequence.
+func DefaultMatcher(r *http.Request, body []byte, recorded *Request) bool {
+	if r.Method != recorded.Method {
+		return false
+	}
+	u, err := url.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+	ru, u := contextforge.SanitizeURL(r.URL), contextforge.SanitizeURL(u)
+	return ru.EscapedPath() == u.EscapedPath() && ru.Query().Encode() == u.Query().Encode()
+}
+
+// Option configures a Recorder created by New.
+type Option func(*Recorder)
+
+// WithTransport sets the transport that sends requests while recording.
+// The default is http.DefaultTransport.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithMatcher sets the function that matches requests to recorded
+// interactions while replaying. The default is DefaultMatcher.
+func WithMatcher(m Matcher) Option {
+	return func(r *Recorder) {
+		r.matcher = m
+	}
+}
+
+// WithRedactedHeaders sets the headers whose values are redacted from
+// recordings, replacing DefaultRedactedHeaders.
+func WithRedactedHeaders(names ...string) Option {
+	return func(r *Recorder) {
+		r.redactHeaders = names
+	}
+}
+
+// WithRedactedFields sets the JSON body fields whose values are redacted
+// from recordings, replacing DefaultRedactedFields. Fields are redacted at
+// any depth.
+func WithRedactedFields(names ...string) Option {
+	return func(r *Recorder) {
+		r.redactFields = names
+	}
+}
+
+// Recorder is an http.RoundTripper that records interactions with a server
+// to a cassette file, or replays them from it. Create one with New and call
+// Stop when done. A Recorder is safe for concurrent use.
+type Recorder struct {
+	filename      string
+	mode          Mode
+	transport     http.RoundTripper
+	matcher       Matcher
+	redactHeaders []string
+	redactFields  []string
+	redactor      *redactor
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool // replayed interactions, by index
+}
+
+// New returns a Recorder for the cassette stored in the named file. In
+// ModeReplay the file must exist; in ModeAuto the mode becomes ModeReplay
+// or ModeRecord depending on whether it does.
+func New(filename string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		filename:      filename,
+		mode:          mode,
+		transport:     http.DefaultTransport,
+		matcher:       DefaultMatcher,
+		redactHeaders: DefaultRedactedHeaders,
+		redactFields:  DefaultRedactedFields,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.redactor = newRedactor(r.redactHeaders, r.redactFields)
+
+	if r.mode == ModeAuto {
+		r.mode = ModeReplay
+		if _, err := os.Stat(filename); errors.Is(err, fs.ErrNotExist) {
+			r.mode = ModeRecord
+		}
+	}
+
+	switch r.mode {
+	case ModeReplay:
+		c, err := Load(filename)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = c
+		r.used = make([]bool, len(c.Interactions))
+	case ModeRecord:
+		r.cassette = &Cassette{}
+	default:
+		return nil, fmt.Errorf("unknown cassette mode %v", mode)
+	}
+	return r, nil
+}
+
+// Mode returns the mode the Recorder operates in, ModeReplay or ModeRecord.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns an HTTP client that sends its requests through r.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Stop writes the recorded interactions to the cassette file, when
+// recording. It does nothing when replaying.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Save(r.filename)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read request body; %w", err)
+		}
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || !r.matcher(req, body, &in.Request) {
+			continue
+		}
+		r.used[i] = true
+		header := in.Response.Header.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("cassette %s has no unused interaction matching %s %s", r.filename, req.Method, contextforge.SanitizeURL(req.URL).RequestURI())
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	if req.Body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := r.transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	resp.Request = req
+	in := &Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    contextforge.SanitizeURL(req.URL).String(),
+			Header: r.redactor.header(req.Header),
+			Body:   r.redactor.body(body),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     r.redactor.header(resp.Header),
+		},
+	}
+
+	// Event streams stay open for as long as the server has events to
+	// send, so their body is recorded as the caller reads it, and saved
+	// when the caller closes it.
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType == "text/event-stream" {
+		resp.Body = &teeBody{body: resp.Body, done: func(data []byte) {
+			r.mu.Lock()
+			in.Response.Body = r.redactor.stream(data)
+			r.mu.Unlock()
+		}}
+	} else {
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read response body; %w", err)
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+		in.Response.Body = r.redactor.body(respBody)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, in)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// teeBody is a response body that keeps a copy of what is read from it and
+// passes the copy to done when it is closed.
+type teeBody struct {
+	body io.ReadCloser
+	buf  bytes.Buffer
+	done func([]byte)
+	once sync.Once
+}
+
+func (b *teeBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	b.buf.Write(p[:n])
+	return n, err
+}
+
+func (b *teeBody) Close() error {
+	err := b.body.Close()
+	b.once.Do(func() { b.done(b.buf.Bytes()) })
+	return err
+}
@@ -0,0 +1,254 @@
+package cassette
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /auth/login", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"access_token":"jwt-secret","token_type":"bearer","expires_in":3600}`)
+	})
+	mux.HandleFunc("GET /tools", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{"id":"t1","name":%q,"enabled":true}]`, "search-"+r.URL.Query().Get("tags"))
+	})
+	mux.HandleFunc("GET /teams/team-1/invitations/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":"i1","team_id":"team-1","email":"dev@example.com","role":"member","token":"invite-secret"}]`)
+	})
+	mux.HandleFunc("POST /teams/invitations/{token}/accept/", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("token") != "invite-secret" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"id":"m1","team_id":"team-1","user_email":"dev@example.com","role":"member","is_active":true}`)
+	})
+	mux.HandleFunc("POST /gateways", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Write(body)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// exercise makes the requests recorded and replayed by the tests.
+func exercise(t *testing.T, httpClient *http.Client, address string) []string {
+	t.Helper()
+	ctx := context.Background()
+
+	ts, err := contextforge.NewPasswordTokenSource(httpClient, address, "admin@example.com", "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := contextforge.NewClientWithOptions(address,
+		contextforge.WithHTTPClient(httpClient), contextforge.WithTokenSource(ts))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, tag := range []string{"a", "b"} {
+		tools, _, err := client.Tools.List(ctx, &contextforge.ToolListOptions{Tags: tag})
+		if err != nil {
+			t.Fatalf("Tools.List returned error: %v", err)
+		}
+		names = append(names, tools[0].Name)
+	}
+	invitations, _, err := client.Teams.ListInvitations(ctx, "team-1")
+	if err != nil {
+		t.Fatalf("Teams.ListInvitations returned error: %v", err)
+	}
+	member, _, err := client.Teams.AcceptInvitation(ctx, invitations[0].Token)
+	if err != nil {
+		t.Fatalf("Teams.AcceptInvitation returned error: %v", err)
+	}
+	names = append(names, member.UserEmail)
+
+	gw, _, err := client.Gateways.Create(ctx, &contextforge.Gateway{
+		Name:      "weather",
+		URL:       "http://weather.internal",
+		AuthType:  contextforge.String("bearer"),
+		AuthToken: contextforge.String("gateway-secret"),
+	}, nil)
+	if err != nil {
+		t.Fatalf("Gateways.Create returned error: %v", err)
+	}
+	return append(names, gw.Name)
+}
+
+func TestRecorder_RecordReplay(t *testing.T) {
+	server := newServer(t)
+	filename := filepath.Join(t.TempDir(), "cassettes", "test.json")
+
+	rec, err := New(filename, ModeAuto)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if rec.Mode() != ModeRecord {
+		t.Fatalf("Mode() = %v for a missing cassette, want record", rec.Mode())
+	}
+	recorded := exercise(t, rec.Client(), server.URL)
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"hunter2", "jwt-secret", "gateway-secret", "session=secret", "invite-secret"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains secret %q", secret)
+		}
+	}
+
+	// Replay with the server gone.
+	server.Close()
+	rec, err = New(filename, ModeAuto)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if rec.Mode() != ModeReplay {
+		t.Fatalf("Mode() = %v for an existing cassette, want replay", rec.Mode())
+	}
+	replayed := exercise(t, rec.Client(), "http://replay.invalid")
+	if strings.Join(replayed, ",") != strings.Join(recorded, ",") {
+		t.Errorf("replayed %v, recorded %v", replayed, recorded)
+	}
+}
+
+func TestRecorder_RecordStream(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /a2a/echo/invoke", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "id: 1\ndata: {\"kind\":\"message\",\"role\":\"agent\",\"messageId\":\"m1\",\"parts\":[],\"metadata\":{\"api_key\":\"stream-secret\"}}\n\n")
+		w.(http.Flusher).Flush()
+		// Keep the stream open, as a running agent would.
+		<-r.Context().Done()
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	filename := filepath.Join(t.TempDir(), "stream.json")
+
+	firstEvent := func(httpClient *http.Client, address string) string {
+		t.Helper()
+		client, err := contextforge.NewClientWithOptions(address, contextforge.WithHTTPClient(httpClient))
+		if err != nil {
+			t.Fatal(err)
+		}
+		stream, _, err := client.Agents.InvokeStream(context.Background(), "echo", nil)
+		if err != nil {
+			t.Fatalf("Agents.InvokeStream returned error: %v", err)
+		}
+		defer stream.Close()
+		for ev, err := range stream.Events() {
+			if err != nil {
+				t.Fatalf("Events yielded error: %v", err)
+			}
+			return ev.Message.MessageID
+		}
+		t.Fatal("Events yielded no events")
+		return ""
+	}
+
+	rec, err := New(filename, ModeRecord)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	recorded := firstEvent(rec.Client(), server.URL)
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+	if data, err := os.ReadFile(filename); err != nil {
+		t.Fatal(err)
+	} else if strings.Contains(string(data), "stream-secret") {
+		t.Error("cassette contains the secret of a streamed event")
+	}
+
+	rec, err = New(filename, ModeReplay)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if replayed := firstEvent(rec.Client(), "http://replay.invalid"); replayed != recorded || recorded != "m1" {
+		t.Errorf("replayed message %q, recorded %q; want m1", replayed, recorded)
+	}
+}
+
+func TestRecorder_ReplayUnmatched(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "test.json")
+	c := &Cassette{Interactions: []*Interaction{{
+		Request:  Request{Method: http.MethodGet, URL: "http://localhost/tools?limit=1"},
+		Response: Response{StatusCode: http.StatusOK, Body: "[]"},
+	}}}
+	if err := c.Save(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := New(filename, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := rec.Client()
+
+	resp, err := client.Get("http://example.com/tools?limit=1")
+	if err != nil {
+		t.Fatalf("first request returned error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200", resp.StatusCode)
+	}
+
+	for _, u := range []string{"http://example.com/tools?limit=1", "http://example.com/tools"} {
+		_, err := client.Get(u)
+		if err == nil || !strings.Contains(err.Error(), "no unused interaction matching GET /tools") {
+			t.Errorf("Get(%s) error = %v, want an unmatched interaction error", u, err)
+		}
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	if _, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay); err == nil {
+		t.Error("New in replay mode with a missing cassette returned no error")
+	}
+	if _, err := New("test.json", Mode(42)); err == nil {
+		t.Error("New with an unknown mode returned no error")
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Mode
+		wantErr bool
+	}{
+		{"replay", ModeReplay, false},
+		{"Record", ModeRecord, false},
+		{"auto", ModeAuto, false},
+		{"live", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseMode(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMode(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMode(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package cassette
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Redacted replaces the values of redacted headers and body fields.
+const Redacted = "REDACTED"
+
+// DefaultRedactedHeaders are the headers whose values are redacted from
+// recordings unless WithRedactedHeaders replaces them.
+var DefaultRedactedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-API-Key",
+}
+
+// DefaultRedactedFields are the JSON body fields whose values are redacted
+// from recordings unless WithRedactedFields replaces them. They cover the
+// credentials of logins and API tokens, team invitation tokens, and the
+// authentication values of gateways and agents. Fields match whatever their
+// case and separators, so "authValue" also matches "auth_value". Only
+// string values are redacted; objects in a redacted field, such as the
+// "token" metadata of a created API token, are searched for fields instead.
+var DefaultRedactedFields = []string{
+	"password",
+	"access_token",
+	"refresh_token",
+	"client_secret",
+	"api_key",
+	"rekey_secret",
+	"token",
+	"auth_password",
+	"auth_token",
+	"auth_header_value",
+	"auth_value",
+	"auth_query_param_value",
+}
+
+// authHeadersField holds a list of {"key", "value"} header objects, whose
+// values are redacted along with the redacted fields.
+const authHeadersField = "authheaders"
+
+// redactor removes secrets from recorded headers and bodies.
+type redactor struct {
+	headers []string
+	fields  map[string]bool // normalized field names
+}
+
+func newRedactor(headers, fields []string) *redactor {
+	r := &redactor{headers: headers, fields: make(map[string]bool)}
+	for _, f := range fields {
+		r.fields[normalize(f)] = true
+	}
+	return r
+}
+
+// normalize lowercases a field name and drops its separators.
+func normalize(name string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+}
+
+// header returns a copy of h with the redacted headers replaced.
+func (r *redactor) header(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	h = h.Clone()
+	for _, name := range r.headers {
+		if values := h.Values(name); len(values) > 0 {
+			redacted := make([]string, len(values))
+			for i := range redacted {
+				redacted[i] = Redacted
+			}
+			h[http.CanonicalHeaderKey(name)] = redacted
+		}
+	}
+	return h
+}
+
+// body returns body with the redacted fields replaced, if it is JSON. Other
+// bodies, and JSON bodies without redacted fields, are returned unchanged.
+func (r *redactor) body(body []byte) string {
+	var v any
+	if len(body) == 0 || json.Unmarshal(body, &v) != nil {
+		return string(body)
+	}
+	if !r.value(v) {
+		return string(body)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+	return string(data)
+}
+
+// stream returns an event stream with the redacted fields replaced in the
+// JSON data of each of its data lines. Other lines, and data that is not
+// JSON, are kept unchanged.
+func (r *redactor) stream(body []byte) string {
+	lines := strings.SplitAfter(string(body), "\n")
+	for i, line := range lines {
+		data, ok := strings.CutPrefix(line, "data:")
+		if !ok {
+			continue
+		}
+		payload := strings.TrimRight(data, "\r\n")
+		eol := data[len(payload):]
+		space := ""
+		if rest, ok := strings.CutPrefix(payload, " "); ok {
+			space, payload = " ", rest
+		}
+		lines[i] = "data:" + space + r.body([]byte(payload)) + eol
+	}
+	return strings.Join(lines, "")
+}
+
+func isString(v any) bool {
+	_, ok := v.(string)
+	return ok
+}
+
+// value redacts the fields of v in place and reports whether it changed
+// anything.
+func (r *redactor) value(v any) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, field := range v {
+			switch name := normalize(k); {
+			case r.fields[name] && isString(field):
+				v[k] = Redacted
+				changed = true
+			case name == authHeadersField:
+				if headers, ok := field.([]any); ok {
+					for _, h := range headers {
+						if h, ok := h.(map[string]any); ok && h["value"] != nil {
+							h["value"] = Redacted
+							changed = true
+						}
+					}
+				}
+			default:
+				changed = r.value(field) || changed
+			}
+		}
+	case []any:
+		for _, item := range v {
+			changed = r.value(item) || changed
+		}
+	}
+	return changed
+}
@@ -0,0 +1,75 @@
+package cassette
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestRedactor_Body(t *testing.T) {
+	r := newRedactor(DefaultRedactedHeaders, DefaultRedactedFields)
+
+	tests := []struct {
+		name, body, want string
+	}{
+		{"login", `{"username":"admin","password":"hunter2"}`, `{"password":"REDACTED","username":"admin"}`},
+		{"token response", `{"access_token":"jwt","expires_in":3600}`, `{"access_token":"REDACTED","expires_in":3600}`},
+		{"gateway camelCase", `{"name":"g","authToken":"t","authHeaderValue":"v"}`, `{"authHeaderValue":"REDACTED","authToken":"REDACTED","name":"g"}`},
+		{"agent snake_case", `{"agent":{"name":"a","auth_value":"v"}}`, `{"agent":{"auth_value":"REDACTED","name":"a"}}`},
+		{"auth headers", `{"authHeaders":[{"key":"X-Key","value":"v"}]}`, `{"authHeaders":[{"key":"X-Key","value":"REDACTED"}]}`},
+		{"nested in array", `[{"oauthConfig":{"client_secret":"s"}}]`, `[{"oauthConfig":{"client_secret":"REDACTED"}}]`},
+		{"null secret kept", `{"authToken":null}`, `{"authToken":null}`},
+		{"invitation token", `{"email":"dev@example.com","token":"t"}`, `{"email":"dev@example.com","token":"REDACTED"}`},
+		{"token object searched", `{"token":{"id":"tok-1","name":"ci"},"access_token":"s"}`, `{"access_token":"REDACTED","token":{"id":"tok-1","name":"ci"}}`},
+		{"nothing to redact", `{"b": 1, "a": 2}`, `{"b": 1, "a": 2}`},
+		{"not JSON", `password=hunter2`, `password=hunter2`},
+		{"empty", ``, ``},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.body([]byte(tt.body)); got != tt.want {
+				t.Errorf("body(%s) = %s, want %s", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactor_Stream(t *testing.T) {
+	r := newRedactor(DefaultRedactedHeaders, DefaultRedactedFields)
+
+	body := "event: message\nid: 1\ndata: {\"auth_value\":\"v\",\"name\":\"a\"}\n\n" +
+		"data:{\"password\":\"p\"}\r\n\r\n" +
+		"data: not json\n\n" +
+		"data: {\"name\":\"b\"}"
+	want := "event: message\nid: 1\ndata: {\"auth_value\":\"REDACTED\",\"name\":\"a\"}\n\n" +
+		"data:{\"password\":\"REDACTED\"}\r\n\r\n" +
+		"data: not json\n\n" +
+		"data: {\"name\":\"b\"}"
+
+	if got := r.stream([]byte(body)); got != want {
+		t.Errorf("stream() = %q, want %q", got, want)
+	}
+}
+
+func TestRedactor_Header(t *testing.T) {
+	r := newRedactor(DefaultRedactedHeaders, nil)
+	h := http.Header{
+		"Authorization": {"Bearer jwt"},
+		"Set-Cookie":    {"a=1", "b=2"},
+		"Content-Type":  {"application/json"},
+	}
+
+	got := r.header(h)
+	want := http.Header{
+		"Authorization": {Redacted},
+		"Set-Cookie":    {Redacted, Redacted},
+		"Content-Type":  {"application/json"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("header() = %v, want %v", got, want)
+	}
+	if h.Get("Authorization") != "Bearer jwt" {
+		t.Error("header() modified its argument")
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+func agentsCommand() *command {
+	return &command{
+		name:    "agents",
+		summary: "Manage A2A agents",
+		commands: []*command{
+			{name: "list", summary: "List agents", run: runAgentsList},
+			getCommand("agent", func(c *contextforge.Client) getFunc[contextforge.Agent] { return c.Agents.Get }, agentsTable),
+			deleteCommand("agent", func(c *contextforge.Client) deleteFunc { return c.Agents.Delete }),
+			stateCommand("agent", func(c *contextforge.Client) setStateFunc[contextforge.Agent] { return c.Agents.SetState }),
+			{name: "invoke", args: "<agent-name>", summary: "Invoke an agent and print its response", run: runAgentsInvoke},
+		},
+	}
+}
+
+func runAgentsList(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	var lf listFlags
+	lf.register(fs, true)
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+
+	agents, err := collect(client.Agents.All(ctx, &contextforge.AgentListOptions{
+		MaxItems:        lf.limit,
+		IncludeInactive: lf.includeInactive,
+		Tags:            lf.tags,
+		TeamID:          lf.teamID,
+		Visibility:      lf.visibility,
+	}))
+	if err != nil {
+		return err
+	}
+	return a.print(agents, agentsTable(agents))
+}
+
+func runAgentsInvoke(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	params := fs.String("params", "", "invocation parameters as a JSON `object`, or @file")
+	interaction := fs.String("interaction-type", "", "interaction `type` (default query)")
+	pos, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	req := &contextforge.AgentInvokeRequest{InteractionType: *interaction}
+	if *params != "" {
+		if err := jsonArg("params", *params, &req.Parameters); err != nil {
+			return err
+		}
+	}
+
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	result, _, err := client.Agents.Invoke(ctx, pos[0], req)
+	if err != nil {
+		return err
+	}
+
+	// Agent responses vary in shape, so print the response as received.
+	var body any = result.Raw
+	if len(result.Raw) == 0 {
+		body = json.RawMessage("null")
+	}
+	return a.print(body, nil)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/leefowlercu/go-contextforge/apply"
+)
+
+func applyCommand() *command {
+	return &command{
+		name:    "apply",
+		args:    "<manifest>",
+		summary: "Reconcile the gateway with a YAML or JSON manifest",
+		run:     runApply,
+	}
+}
+
+func runApply(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	dryRun := fs.Bool("dry-run", false, "print the plan without applying it")
+	opts := &apply.Options{}
+	fs.StringVar(&opts.ManagedTag, "managed-tag", "", "`tag` added to every entity the manifest manages")
+	fs.BoolVar(&opts.Prune, "prune", false, "delete managed entities no longer in the manifest; requires --managed-tag")
+	pos, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if opts.Prune && opts.ManagedTag == "" {
+		return usagef("--prune requires --managed-tag")
+	}
+
+	m, err := apply.LoadFile(pos[0])
+	if err != nil {
+		return err
+	}
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	plan, err := apply.NewPlan(ctx, client, m, opts)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprint(a.stdout, plan); err != nil {
+		return err
+	}
+	if *dryRun || plan.Empty() {
+		return nil
+	}
+	if err := plan.Execute(ctx, client); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(a.stdout, "Applied.")
+	return err
+}
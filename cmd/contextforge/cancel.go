@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+func cancelCommand() *command {
+	return &command{
+		name:    "cancel",
+		summary: "Cancel in-flight requests",
+		commands: []*command{
+			{name: "request", args: "<request-id>", summary: "Request cancellation of a run", run: runCancelRequest},
+			{name: "status", args: "<request-id>", summary: "Show the cancellation status of a run", run: runCancelStatus},
+		},
+	}
+}
+
+func runCancelRequest(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	reason := fs.String("reason", "", "cancellation `reason`")
+	pos, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+
+	result, _, err := client.Cancel.Cancel(ctx, &contextforge.CancellationRequest{RequestID: pos[0], Reason: optional(*reason)})
+	if err != nil {
+		return err
+	}
+
+	tbl := &table{header: []string{"REQUEST", "STATUS", "REASON"}}
+	tbl.add(result.RequestID, result.Status, str(result.Reason))
+	return a.print(result, tbl)
+}
+
+func runCancelStatus(ctx context.Context, a *app, args []string) error {
+	pos, err := a.parse(a.flags(), args, 1)
+	if err != nil {
+		return err
+	}
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+
+	status, _, err := client.Cancel.Status(ctx, pos[0])
+	if err != nil {
+		return err
+	}
+
+	tbl := &table{header: []string{"REQUEST", "NAME", "CANCELLED", "REASON"}}
+	tbl.add(pos[0], str(status.Name), strconv.FormatBool(status.Cancelled), str(status.CancelReason))
+	return a.print(status, tbl)
+}
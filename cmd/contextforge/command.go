@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+// command is a node in the command tree. Leaf commands have a run function;
+// group commands have subcommands.
+type command struct {
+	name     string
+	args     string // synopsis of the positional arguments, e.g. "<tool-id>"
+	summary  string
+	commands []*command
+	run      func(ctx context.Context, a *app, args []string) error
+}
+
+// commands returns the top-level commands.
+func commands() []*command {
+	return []*command{
+		toolsCommand(),
+		resourcesCommand(),
+		gatewaysCommand(),
+		serversCommand(),
+		promptsCommand(),
+		agentsCommand(),
+		teamsCommand(),
+		cancelCommand(),
+		applyCommand(),
+		{name: "version", summary: "Print the SDK version", run: runVersion},
+	}
+}
+
+// usageError reports a command-line mistake. It is printed with the usage
+// of the command it occurred in.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usagef(format string, args ...any) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// app holds the state shared by the commands of one invocation.
+type app struct {
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+
+	globals globals
+	cfg     *config
+	client  *contextforge.Client
+
+	// path and cmd identify the leaf command being run, for usage output.
+	path string
+	cmd  *command
+}
+
+// globals are the flags accepted by every command.
+type globals struct {
+	config  string
+	address string
+	token   string
+	output  string
+}
+
+func (g *globals) register(fs *flag.FlagSet) {
+	fs.StringVar(&g.config, "config", g.config, "path to the configuration `file`")
+	fs.StringVar(&g.address, "address", g.address, "ContextForge `URL`")
+	fs.StringVar(&g.token, "token", g.token, "bearer `token`")
+	fs.StringVar(&g.output, "output", g.output, "output `format`: table, json, or yaml")
+	fs.StringVar(&g.output, "o", g.output, "shorthand for --output `format`")
+}
+
+// run executes the command line args and returns the process exit code:
+// 0 on success, 1 if the command failed, and 2 for usage errors.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer, getenv func(string) string) int {
+	a := &app{stdout: stdout, stderr: stderr, getenv: getenv}
+
+	root := &command{name: "contextforge", commands: commands()}
+	err := a.dispatch(ctx, root, "contextforge", args)
+
+	var uerr *usageError
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.As(err, &uerr):
+		fmt.Fprintf(stderr, "contextforge: %s\n", uerr.msg)
+		fmt.Fprintf(stderr, "Run '%s --help' for usage.\n", a.path)
+		return 2
+	default:
+		fmt.Fprintf(stderr, "contextforge: %v\n", err)
+		return 1
+	}
+}
+
+// dispatch parses the global flags that precede the next command name and
+// runs the command that args select.
+func (a *app) dispatch(ctx context.Context, cmd *command, path string, args []string) error {
+	a.path = path
+	if cmd.run != nil {
+		a.cmd = cmd
+		return cmd.run(ctx, a, args)
+	}
+
+	fs := flag.NewFlagSet(path, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	a.globals.register(fs)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			a.printGroupUsage(cmd, path)
+			return err
+		}
+		return usagef("%v", err)
+	}
+	args = fs.Args()
+
+	if len(args) == 0 || args[0] == "help" {
+		a.printGroupUsage(cmd, path)
+		if len(args) == 0 {
+			return usagef("missing command")
+		}
+		return nil
+	}
+	for _, sub := range cmd.commands {
+		if sub.name == args[0] {
+			return a.dispatch(ctx, sub, path+" "+sub.name, args[1:])
+		}
+	}
+	return usagef("unknown command %q", args[0])
+}
+
+func (a *app) printGroupUsage(cmd *command, path string) {
+	w := a.stderr
+	fmt.Fprintf(w, "Usage: %s [flags] <command>\n\nCommands:\n", path)
+	for _, sub := range cmd.commands {
+		fmt.Fprintf(w, "  %-12s %s\n", sub.name, sub.summary)
+	}
+	fmt.Fprintf(w, "\nGlobal flags:\n")
+	fs := flag.NewFlagSet(path, flag.ContinueOnError)
+	fs.SetOutput(w)
+	new(globals).register(fs)
+	fs.PrintDefaults()
+}
+
+// flags returns a flag set for the running leaf command, with the global
+// flags registered.
+func (a *app) flags() *flag.FlagSet {
+	fs := flag.NewFlagSet(a.path, flag.ContinueOnError)
+	fs.Usage = func() {
+		fs.SetOutput(a.stderr)
+		fmt.Fprintf(a.stderr, "Usage: %s [flags] %s\n\n%s.\n\nFlags:\n", a.path, a.cmd.args, a.cmd.summary)
+		fs.PrintDefaults()
+	}
+	a.globals.register(fs)
+	return fs
+}
+
+// parse parses args, which may interleave flags and positional arguments,
+// and returns the positional arguments. It fails unless there are exactly n.
+func (a *app) parse(fs *flag.FlagSet, args []string, n int) ([]string, error) {
+	var positional []string
+	for {
+		// Parse errors are reported by run, with a pointer to --help, so
+		// usage is only printed when asked for.
+		usage := fs.Usage
+		fs.Usage = func() {}
+		fs.SetOutput(io.Discard)
+		err := fs.Parse(args)
+		fs.Usage = usage
+		if errors.Is(err, flag.ErrHelp) {
+			usage()
+			return nil, err
+		}
+		if err != nil {
+			return nil, usagef("%v", err)
+		}
+
+		rest := fs.Args()
+		if len(rest) == 0 {
+			break
+		}
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			positional = append(positional, rest...)
+			break
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+
+	if len(positional) != n {
+		if n == 0 {
+			return nil, usagef("unexpected argument %q", positional[0])
+		}
+		return nil, usagef("usage: %s %s", a.path, a.cmd.args)
+	}
+	return positional, nil
+}
+
+func runVersion(ctx context.Context, a *app, args []string) error {
+	if _, err := a.parse(a.flags(), args, 0); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(a.stdout, "contextforge %s\n", contextforge.Version)
+	return err
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"iter"
+	"os"
+	"strings"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+	"github.com/leefowlercu/go-contextforge/internal/yaml"
+)
+
+// The commands shared by several services take the service method to call
+// as a function of the client, so they can be built before the client is.
+type (
+	getFunc[T any]      func(ctx context.Context, id string) (*T, *contextforge.Response, error)
+	deleteFunc          func(ctx context.Context, id string) (*contextforge.Response, error)
+	setStateFunc[T any] func(ctx context.Context, id string, activate bool) (*T, *contextforge.Response, error)
+)
+
+// getCommand returns a "get <id>" command that prints one entity.
+func getCommand[T any](kind string, method func(*contextforge.Client) getFunc[T], tbl func([]*T) *table) *command {
+	return &command{
+		name:    "get",
+		args:    "<" + kind + "-id>",
+		summary: "Show a " + kind,
+		run: func(ctx context.Context, a *app, args []string) error {
+			pos, err := a.parse(a.flags(), args, 1)
+			if err != nil {
+				return err
+			}
+			client, err := a.api()
+			if err != nil {
+				return err
+			}
+			v, _, err := method(client)(ctx, pos[0])
+			if err != nil {
+				return err
+			}
+			return a.print(v, tbl([]*T{v}))
+		},
+	}
+}
+
+// deleteCommand returns a "delete <id>" command.
+func deleteCommand(kind string, method func(*contextforge.Client) deleteFunc) *command {
+	return &command{
+		name:    "delete",
+		args:    "<" + kind + "-id>",
+		summary: "Delete a " + kind,
+		run: func(ctx context.Context, a *app, args []string) error {
+			pos, err := a.parse(a.flags(), args, 1)
+			if err != nil {
+				return err
+			}
+			client, err := a.api()
+			if err != nil {
+				return err
+			}
+			if _, err := method(client)(ctx, pos[0]); err != nil {
+				return err
+			}
+			return a.printf("Deleted %s %s", kind, pos[0])
+		},
+	}
+}
+
+// stateCommand returns a "state <id> enable|disable" command.
+func stateCommand[T any](kind string, method func(*contextforge.Client) setStateFunc[T]) *command {
+	return &command{
+		name:    "state",
+		args:    "<" + kind + "-id> enable|disable",
+		summary: "Enable or disable a " + kind,
+		run: func(ctx context.Context, a *app, args []string) error {
+			pos, err := a.parse(a.flags(), args, 2)
+			if err != nil {
+				return err
+			}
+			var activate bool
+			switch pos[1] {
+			case "enable":
+				activate = true
+			case "disable":
+			default:
+				return usagef("invalid state %q; want enable or disable", pos[1])
+			}
+			client, err := a.api()
+			if err != nil {
+				return err
+			}
+			if _, _, err := method(client)(ctx, pos[0], activate); err != nil {
+				return err
+			}
+			return a.printf("%s %s %sd", strings.ToUpper(kind[:1])+kind[1:], pos[0], pos[1])
+		},
+	}
+}
+
+// listFlags are the filters shared by the cursor-paginated list commands.
+type listFlags struct {
+	includeInactive bool
+	tags            string
+	teamID          string
+	visibility      string
+	limit           int
+}
+
+func (l *listFlags) register(fs *flag.FlagSet, filters bool) {
+	fs.BoolVar(&l.includeInactive, "include-inactive", false, "include inactive entities")
+	fs.IntVar(&l.limit, "limit", 0, "maximum number of entities to list (0 for all)")
+	if filters {
+		fs.StringVar(&l.tags, "tags", "", "comma-separated `tags` to filter by")
+		fs.StringVar(&l.teamID, "team-id", "", "team `ID` to filter by")
+		fs.StringVar(&l.visibility, "visibility", "", "`visibility` to filter by")
+	}
+}
+
+// collect gathers the entities yielded by an All iterator.
+func collect[T any](seq iter.Seq2[*T, error]) ([]*T, error) {
+	items := []*T{}
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// jsonArg decodes a JSON flag value into v. A value starting with '@'
+// names a file to read the JSON from.
+func jsonArg(name, value string, v any) error {
+	data := []byte(value)
+	if rest, ok := strings.CutPrefix(value, "@"); ok {
+		var err error
+		if data, err = os.ReadFile(rest); err != nil {
+			return err
+		}
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return usagef("invalid --%s: %v", name, err)
+	}
+	return nil
+}
+
+// decodeFile decodes the JSON or YAML document in the named file into v.
+func decodeFile(name string, v any) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	if err := decode(data, v); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// decode decodes a JSON or YAML document into v, rejecting unknown fields.
+func decode(data []byte, v any) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '{' {
+		doc, err := yaml.Decode(data)
+		if err != nil {
+			return err
+		}
+		if data, err = json.Marshal(doc); err != nil {
+			return err
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// splitList splits a comma-separated flag value, returning nil if it is
+// empty.
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
+// keyValues is a repeatable key=value flag.
+type keyValues map[string]string
+
+func (kv keyValues) String() string {
+	return fmt.Sprint(map[string]string(kv))
+}
+
+func (kv keyValues) Set(s string) error {
+	k, v, ok := strings.Cut(s, "=")
+	if !ok {
+		return fmt.Errorf("%q is not in key=value form", s)
+	}
+	kv[k] = v
+	return nil
+}
+
+// optional returns a pointer to s, or nil if s is empty.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+// config is the resolved configuration, and the contents of the
+// configuration file.
+type config struct {
+	Address  string `json:"address,omitempty"`
+	Token    string `json:"token,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Password string `json:"password,omitempty"`
+	Output   string `json:"output,omitempty"`
+}
+
+// config resolves the configuration from flags, the environment, and the
+// configuration file, in that order of precedence.
+func (a *app) config() (*config, error) {
+	if a.cfg != nil {
+		return a.cfg, nil
+	}
+
+	name, explicit := a.globals.config, true
+	if name == "" {
+		name = a.getenv("CONTEXTFORGE_CONFIG")
+	}
+	if name == "" {
+		explicit = false
+		if dir, err := os.UserConfigDir(); err == nil {
+			name = filepath.Join(dir, "contextforge", "config.yaml")
+		}
+	}
+
+	cfg := &config{}
+	if name != "" {
+		data, err := os.ReadFile(name)
+		switch {
+		case errors.Is(err, fs.ErrNotExist) && !explicit:
+			// The default configuration file is optional.
+		case err != nil:
+			return nil, fmt.Errorf("read config; %w", err)
+		default:
+			if err := decode(data, cfg); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	}
+
+	override := func(dst *string, env, flag string) {
+		if v := a.getenv(env); v != "" {
+			*dst = v
+		}
+		if flag != "" {
+			*dst = flag
+		}
+	}
+	override(&cfg.Address, "CONTEXTFORGE_ADDR", a.globals.address)
+	override(&cfg.Token, "CONTEXTFORGE_TOKEN", a.globals.token)
+	override(&cfg.Email, "CONTEXTFORGE_EMAIL", "")
+	override(&cfg.Password, "CONTEXTFORGE_PASSWORD", "")
+	override(&cfg.Output, "CONTEXTFORGE_OUTPUT", a.globals.output)
+
+	switch cfg.Output {
+	case "":
+		cfg.Output = "table"
+	case "table", "json", "yaml":
+	default:
+		return nil, usagef("unknown output format %q; want table, json, or yaml", cfg.Output)
+	}
+
+	a.cfg = cfg
+	return cfg, nil
+}
+
+// api returns the API client, creating it on first use.
+func (a *app) api() (*contextforge.Client, error) {
+	if a.client != nil {
+		return a.client, nil
+	}
+	cfg, err := a.config()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Address == "" {
+		return nil, fmt.Errorf("no ContextForge address; set --address, CONTEXTFORGE_ADDR, or address in the config file")
+	}
+
+	opts := []contextforge.Option{contextforge.WithUserAgentSuffix("contextforge-cli")}
+	switch {
+	case cfg.Token != "":
+		opts = append(opts, contextforge.WithBearerToken(cfg.Token))
+	case cfg.Email != "" && cfg.Password != "":
+		ts, err := contextforge.NewPasswordTokenSource(nil, cfg.Address, cfg.Email, cfg.Password)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, contextforge.WithTokenSource(ts))
+	}
+
+	client, err := contextforge.NewClientWithOptions(cfg.Address, opts...)
+	if err != nil {
+		return nil, err
+	}
+	a.client = client
+	return client, nil
+}
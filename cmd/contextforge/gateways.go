@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+func gatewaysCommand() *command {
+	return &command{
+		name:    "gateways",
+		summary: "Manage federated MCP servers",
+		commands: []*command{
+			{name: "list", summary: "List gateways", run: runGatewaysList},
+			getCommand("gateway", func(c *contextforge.Client) getFunc[contextforge.Gateway] { return c.Gateways.Get }, gatewaysTable),
+			deleteCommand("gateway", func(c *contextforge.Client) deleteFunc { return c.Gateways.Delete }),
+			stateCommand("gateway", func(c *contextforge.Client) setStateFunc[contextforge.Gateway] { return c.Gateways.SetState }),
+			{name: "refresh", args: "<gateway-id>", summary: "Re-discover a gateway's tools, resources, and prompts", run: runGatewaysRefresh},
+		},
+	}
+}
+
+func runGatewaysList(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	var lf listFlags
+	lf.register(fs, false)
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+
+	gateways, err := collect(client.Gateways.All(ctx, &contextforge.GatewayListOptions{
+		ListOptions:     contextforge.ListOptions{MaxItems: lf.limit},
+		IncludeInactive: lf.includeInactive,
+	}))
+	if err != nil {
+		return err
+	}
+	return a.print(gateways, gatewaysTable(gateways))
+}
+
+func runGatewaysRefresh(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	var opts contextforge.GatewayRefreshOptions
+	fs.BoolVar(&opts.IncludeResources, "include-resources", false, "also refresh resources")
+	fs.BoolVar(&opts.IncludePrompts, "include-prompts", false, "also refresh prompts")
+	pos, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+
+	result, _, err := client.Gateways.RefreshTools(ctx, pos[0], &opts)
+	if err != nil {
+		return err
+	}
+
+	counts := func(added, updated, removed int) string {
+		return "+" + strconv.Itoa(added) + " ~" + strconv.Itoa(updated) + " -" + strconv.Itoa(removed)
+	}
+	errs := result.ValidationErrors
+	if result.Error != nil {
+		errs = append([]string{*result.Error}, errs...)
+	}
+	tbl := &table{header: []string{"GATEWAY", "SUCCESS", "TOOLS", "RESOURCES", "PROMPTS", "ERROR"}}
+	tbl.add(result.GatewayID, strconv.FormatBool(result.Success),
+		counts(result.ToolsAdded, result.ToolsUpdated, result.ToolsRemoved),
+		counts(result.ResourcesAdded, result.ResourcesUpdated, result.ResourcesRemoved),
+		counts(result.PromptsAdded, result.PromptsUpdated, result.PromptsRemoved),
+		strings.Join(errs, "; "))
+	return a.print(result, tbl)
+}
@@ -0,0 +1,45 @@
+// Command contextforge manages a ContextForge MCP Gateway from the command
+// line, using the go-contextforge SDK.
+//
+// Usage:
+//
+//	contextforge [global flags] <command> <subcommand> [flags] [args]
+//
+// Run "contextforge help" for the list of commands.
+//
+// # Authentication
+//
+// The gateway address and credentials are read from, in order of
+// precedence, command-line flags, environment variables, and a YAML or JSON
+// configuration file:
+//
+//	Flag          Environment             Config file
+//	--address     CONTEXTFORGE_ADDR       address
+//	--token       CONTEXTFORGE_TOKEN      token
+//	              CONTEXTFORGE_EMAIL      email
+//	              CONTEXTFORGE_PASSWORD   password
+//	--output      CONTEXTFORGE_OUTPUT     output
+//
+// A bearer token takes precedence over an email and password, which are
+// exchanged for a token on first use. The configuration file is named by
+// --config or CONTEXTFORGE_CONFIG, and defaults to contextforge/config.yaml
+// in the user's configuration directory.
+//
+// # Output
+//
+// Results are printed as a table by default. Use --output json or
+// --output yaml for machine-readable output.
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr, os.Getenv)
+	stop()
+	os.Exit(code)
+}
@@ -0,0 +1,395 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// fixtures are the canned responses of the test server, keyed by pattern.
+var fixtures = map[string]string{
+	"GET /tools": `[
+		{"id":"t1","name":"search","description":"Search the docs","enabled":true,"visibility":"public","tags":["docs","web"],
+		 "inputSchema":{"type":"object","properties":{"query":{"type":"string"}},"required":["query"]}},
+		{"id":"t2","name":"fetch","enabled":false,"visibility":"private"}
+	]`,
+	"GET /tools/t1":        `{"id":"t1","name":"search","description":"Search the docs","enabled":true,"visibility":"public","tags":["docs","web"]}`,
+	"GET /tools/missing":   `!404 {"message":"Tool not found"}`,
+	"POST /tools":          `{"id":"t3","name":"weather","description":"Get the forecast","enabled":true,"visibility":"team","tags":["weather"]}`,
+	"DELETE /tools/t1":     `!204`,
+	"POST /tools/t1/state": `{"status":"success","tool":{"id":"t1","name":"search","enabled":false}}`,
+	"POST /rpc":            `{"jsonrpc":"2.0","id":"1","result":{"content":[{"type":"text","text":"Sunny, 21°C"}]}}`,
+	"GET /resources":       `[{"id":7,"uri":"file:///readme.md","name":"readme","mimeType":"text/markdown","isActive":true,"tags":["docs"]}]`,
+	"GET /resources/7":     `{"type":"resource","uri":"file:///readme.md","text":"# Readme\n"}`,
+	"GET /gateways":        `[{"id":"g1","name":"weather","url":"http://weather.internal/mcp","transport":"STREAMABLEHTTP","enabled":true,"reachable":true}]`,
+	"POST /gateways/g1/tools/refresh": `{"gateway_id":"g1","success":true,"tools_added":2,"tools_updated":1,"tools_removed":0,` +
+		`"validation_errors":["tool \"x\": missing inputSchema"]}`,
+	"GET /servers":          `[]`,
+	"GET /prompts":          `[]`,
+	"GET /servers/s1/tools": `[{"id":"t1","name":"search","enabled":true,"visibility":"public"}]`,
+	"POST /prompts/p1": `{"description":"Greeting","messages":[` +
+		`{"role":"user","content":{"type":"text","text":"Hello Ada"}},` +
+		`{"role":"assistant","content":{"type":"text","text":"Hi! How can I help?"}}]}`,
+	"GET /a2a":                `[{"id":"a1","name":"helper","endpointUrl":"http://agents.internal/helper","agentType":"generic","enabled":true,"reachable":false}]`,
+	"POST /a2a/helper/invoke": `{"kind":"message","role":"agent","parts":[{"kind":"text","text":"done"}]}`,
+	"GET /teams": `{"teams":[{"id":"tm1","name":"Platform","slug":"platform","is_personal":false,"visibility":"private","member_count":2,"is_active":true}],` +
+		`"total":1}`,
+	"GET /teams/tm1/members/": `[{"id":"m1","team_id":"tm1","user_email":"ada@example.com","role":"owner","joined_at":"2025-01-02T03:04:05Z","is_active":true},` +
+		`{"id":"m2","team_id":"tm1","user_email":"bob@example.com","role":"member","is_active":true}]`,
+	"POST /teams/tm1/invitations/": `{"id":"inv1","team_id":"tm1","team_name":"Platform","email":"cy@example.com","role":"member",` +
+		`"expires_at":"2025-02-01T00:00:00Z","token":"secret","is_active":true}`,
+	"GET /cancellation/status/r1": `{"name":"tools/call","cancelled":true,"cancel_reason":"user abort"}`,
+	"POST /cancellation/cancel":   `{"status":"cancelled","requestId":"r1","reason":"user abort"}`,
+	"POST /auth/login":            `{"access_token":"login-token","token_type":"bearer","expires_in":3600}`,
+}
+
+// request is a request received by the test server.
+type request struct {
+	method, path, auth, body string
+}
+
+// setup starts a test server serving fixtures and returns its URL and a
+// function returning the requests it has received.
+func setup(t *testing.T) (string, func() []request) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var requests []request
+
+	mux := http.NewServeMux()
+	for pattern, fixture := range fixtures {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			status, payload := http.StatusOK, fixture
+			if strings.HasPrefix(fixture, "!") {
+				code, rest, _ := strings.Cut(fixture[1:], " ")
+				fmt.Sscan(code, &status)
+				payload = rest
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			fmt.Fprint(w, payload)
+		})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		mu.Lock()
+		requests = append(requests, request{r.Method, r.URL.RequestURI(), r.Header.Get("Authorization"), string(body)})
+		mu.Unlock()
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL, func() []request {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]request(nil), requests...)
+	}
+}
+
+// runCLI runs the command line with the given environment and returns its
+// output and exit code.
+func runCLI(env map[string]string, args ...string) (stdout, stderr string, code int) {
+	var out, errOut bytes.Buffer
+	getenv := func(key string) string { return env[key] }
+	code = run(context.Background(), args, &out, &errOut, getenv)
+	return out.String(), errOut.String(), code
+}
+
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file; %v (run go test -update to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("output does not match %s\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+func TestCommands(t *testing.T) {
+	tests := []struct {
+		golden string
+		args   []string
+	}{
+		{"tools_list", []string{"tools", "list"}},
+		{"tools_list_json", []string{"tools", "list", "-o", "json"}},
+		{"tools_list_yaml", []string{"--output", "yaml", "tools", "list"}},
+		{"tools_get", []string{"tools", "get", "t1"}},
+		{"tools_create", []string{"tools", "create", "--name", "weather", "--description", "Get the forecast",
+			"--schema", `{"type":"object","properties":{"city":{"type":"string"}}}`, "--tags", "weather", "--visibility", "team"}},
+		{"tools_delete", []string{"tools", "delete", "t1"}},
+		{"tools_state", []string{"tools", "state", "t1", "disable"}},
+		{"tools_invoke", []string{"tools", "invoke", "weather", "--args", `{"city":"Paris"}`}},
+		{"resources_list", []string{"resources", "list"}},
+		{"resources_read", []string{"resources", "read", "7"}},
+		{"gateways_list", []string{"gateways", "list"}},
+		{"gateways_refresh", []string{"gateways", "refresh", "g1"}},
+		{"servers_tools", []string{"servers", "tools", "s1"}},
+		{"prompts_render", []string{"prompts", "render", "p1", "--arg", "name=Ada"}},
+		{"prompts_render_yaml", []string{"prompts", "render", "p1", "--arg", "name=Ada", "-o", "yaml"}},
+		{"agents_list", []string{"agents", "list"}},
+		{"agents_invoke", []string{"agents", "invoke", "helper", "--params", `{"task":"x"}`}},
+		{"teams_list", []string{"teams", "list"}},
+		{"teams_members", []string{"teams", "members", "tm1"}},
+		{"teams_invite", []string{"teams", "invite", "tm1", "cy@example.com", "--role", "member"}},
+		{"cancel_status", []string{"cancel", "status", "r1"}},
+		{"cancel_request", []string{"cancel", "request", "r1", "--reason", "user abort"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			address, _ := setup(t)
+			env := map[string]string{"CONTEXTFORGE_ADDR": address, "CONTEXTFORGE_TOKEN": "test-token"}
+
+			stdout, stderr, code := runCLI(env, tt.args...)
+			if code != 0 {
+				t.Fatalf("exit code = %d, want 0; stderr:\n%s", code, stderr)
+			}
+			checkGolden(t, tt.golden, stdout)
+		})
+	}
+}
+
+func TestCommands_Requests(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want request
+	}{
+		{
+			name: "tools create",
+			args: []string{"tools", "create", "--name", "weather", "--tags", "a,b", "--team-id", "tm1"},
+			want: request{method: "POST", path: "/tools", body: `{"team_id":"tm1","tool":{"name":"weather","tags":["a","b"]}}`},
+		},
+		{
+			name: "tools state",
+			args: []string{"tools", "state", "t1", "enable"},
+			want: request{method: "POST", path: "/tools/t1/state?activate=true"},
+		},
+		{
+			name: "prompts render",
+			args: []string{"prompts", "render", "p1", "--arg", "name=Ada", "--arg", "tone=warm"},
+			want: request{method: "POST", path: "/prompts/p1", body: `{"name":"Ada","tone":"warm"}`},
+		},
+		{
+			name: "teams invite",
+			args: []string{"teams", "invite", "tm1", "cy@example.com"},
+			want: request{method: "POST", path: "/teams/tm1/invitations/", body: `{"email":"cy@example.com"}`},
+		},
+		{
+			name: "tools list filters",
+			args: []string{"tools", "list", "--include-inactive", "--tags", "docs"},
+			want: request{method: "GET", path: "/tools?include_inactive=true&include_pagination=true&tags=docs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, requests := setup(t)
+			env := map[string]string{"CONTEXTFORGE_ADDR": address, "CONTEXTFORGE_TOKEN": "test-token"}
+
+			if _, stderr, code := runCLI(env, tt.args...); code != 0 {
+				t.Fatalf("exit code = %d, want 0; stderr:\n%s", code, stderr)
+			}
+			got := requests()
+			if len(got) != 1 {
+				t.Fatalf("got %d requests, want 1: %+v", len(got), got)
+			}
+			got[0].auth = ""
+			got[0].body = strings.TrimSpace(got[0].body)
+			if got[0] != tt.want {
+				t.Errorf("request = %+v, want %+v", got[0], tt.want)
+			}
+		})
+	}
+}
+
+func TestAuth(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name     string
+		config   string // written to a file named by CONTEXTFORGE_CONFIG; ADDRESS is replaced
+		env      map[string]string
+		args     []string
+		wantAuth string
+	}{
+		{
+			name:     "config file",
+			config:   "address: ADDRESS\ntoken: file-token\n",
+			wantAuth: "Bearer file-token",
+		},
+		{
+			name:     "json config file",
+			config:   `{"address": "ADDRESS", "token": "json-token"}`,
+			wantAuth: "Bearer json-token",
+		},
+		{
+			name:     "environment overrides config file",
+			config:   "address: ADDRESS\ntoken: file-token\n",
+			env:      map[string]string{"CONTEXTFORGE_TOKEN": "env-token"},
+			wantAuth: "Bearer env-token",
+		},
+		{
+			name:     "flag overrides environment",
+			config:   "address: ADDRESS\n",
+			env:      map[string]string{"CONTEXTFORGE_TOKEN": "env-token"},
+			args:     []string{"--token", "flag-token"},
+			wantAuth: "Bearer flag-token",
+		},
+		{
+			name:     "email and password",
+			config:   "address: ADDRESS\nemail: admin@example.com\npassword: changeme\n",
+			wantAuth: "Bearer login-token",
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, requests := setup(t)
+			env := map[string]string{
+				"CONTEXTFORGE_CONFIG": writeConfig(fmt.Sprintf("config%d", i), strings.ReplaceAll(tt.config, "ADDRESS", address)),
+			}
+			for k, v := range tt.env {
+				env[k] = v
+			}
+
+			args := append([]string{"tools", "get", "t1"}, tt.args...)
+			if _, stderr, code := runCLI(env, args...); code != 0 {
+				t.Fatalf("exit code = %d, want 0; stderr:\n%s", code, stderr)
+			}
+
+			got := requests()
+			last := got[len(got)-1]
+			if last.path != "/tools/t1" || last.auth != tt.wantAuth {
+				t.Errorf("last request = %s with Authorization %q, want /tools/t1 with %q", last.path, last.auth, tt.wantAuth)
+			}
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        map[string]string
+		args       []string
+		wantCode   int
+		wantStderr string
+	}{
+		{"no command", nil, nil, 2, "missing command"},
+		{"unknown command", nil, []string{"widgets"}, 2, `unknown command "widgets"`},
+		{"unknown subcommand", nil, []string{"tools", "frobnicate"}, 2, `Run 'contextforge tools --help' for usage.`},
+		{"missing argument", nil, []string{"tools", "get"}, 2, "usage: contextforge tools get <tool-id>"},
+		{"extra argument", nil, []string{"tools", "list", "extra"}, 2, `unexpected argument "extra"`},
+		{"unknown flag", nil, []string{"tools", "list", "--colour"}, 2, "flag provided but not defined: -colour"},
+		{"invalid state", nil, []string{"tools", "state", "t1", "off"}, 2, `invalid state "off"`},
+		{"invalid output", nil, []string{"tools", "list", "-o", "xml"}, 2, `unknown output format "xml"`},
+		{"invalid json flag", nil, []string{"tools", "invoke", "x", "--args", "{"}, 2, "invalid --args"},
+		{"no address", map[string]string{"CONTEXTFORGE_CONFIG": os.DevNull}, []string{"tools", "list"}, 1, "no ContextForge address"},
+		{"missing config file", map[string]string{"CONTEXTFORGE_CONFIG": "testdata/missing.yaml"}, []string{"tools", "list"}, 1, "read config"},
+		{"api error", nil, []string{"tools", "get", "missing"}, 1, "404 Tool not found"},
+		{"prune without managed tag", nil, []string{"apply", "catalog.yaml", "--prune"}, 2, "--prune requires --managed-tag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, _ := setup(t)
+			env := map[string]string{"CONTEXTFORGE_ADDR": address, "CONTEXTFORGE_TOKEN": "test-token"}
+			if tt.env != nil {
+				env = tt.env
+			}
+
+			stdout, stderr, code := runCLI(env, tt.args...)
+			if code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d", code, tt.wantCode)
+			}
+			if !strings.Contains(stderr, tt.wantStderr) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr, tt.wantStderr)
+			}
+			if stdout != "" {
+				t.Errorf("stdout = %q, want empty", stdout)
+			}
+		})
+	}
+}
+
+func TestErrors_Repeated(t *testing.T) {
+	address, _ := setup(t)
+	env := map[string]string{"CONTEXTFORGE_ADDR": address, "CONTEXTFORGE_TOKEN": "test-token"}
+
+	for i := range 2 {
+		_, stderr, code := runCLI(env, "tools", "get", "missing")
+		if code != 1 || !strings.Contains(stderr, "404 Tool not found") {
+			t.Errorf("run %d: exit code = %d, stderr = %q; want 1 and a 404 error", i+1, code, stderr)
+		}
+	}
+}
+
+func TestHelp(t *testing.T) {
+	for _, args := range [][]string{{"help"}, {"--help"}, {"tools", "--help"}, {"tools", "list", "-h"}} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			stdout, stderr, code := runCLI(nil, args...)
+			if code != 0 {
+				t.Errorf("exit code = %d, want 0", code)
+			}
+			if stdout != "" || !strings.HasPrefix(stderr, "Usage: contextforge") {
+				t.Errorf("output = %q, %q; want usage on stderr", stdout, stderr)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	address, requests := setup(t)
+	manifest := filepath.Join(t.TempDir(), "catalog.yaml")
+	if err := os.WriteFile(manifest, []byte("tools:\n  - name: weather\n    description: Get the forecast\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	env := map[string]string{"CONTEXTFORGE_ADDR": address, "CONTEXTFORGE_TOKEN": "test-token"}
+
+	stdout, stderr, code := runCLI(env, "apply", manifest, "--dry-run")
+	if code != 0 {
+		t.Fatalf("exit code = %d, want 0; stderr:\n%s", code, stderr)
+	}
+	checkGolden(t, "apply_dry_run", stdout)
+	for _, r := range requests() {
+		if r.method != http.MethodGet {
+			t.Errorf("dry run sent %s %s", r.method, r.path)
+		}
+	}
+
+	stdout, stderr, code = runCLI(env, "apply", manifest)
+	if code != 0 {
+		t.Fatalf("exit code = %d, want 0; stderr:\n%s", code, stderr)
+	}
+	if !strings.HasSuffix(stdout, "Applied.\n") {
+		t.Errorf("stdout = %q, want it to end with %q", stdout, "Applied.\n")
+	}
+}
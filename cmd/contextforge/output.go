@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+	"github.com/leefowlercu/go-contextforge/internal/yaml"
+)
+
+// table is the tabular rendering of a command's result.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func (t *table) add(cells ...string) {
+	for i, c := range cells {
+		if c == "" {
+			cells[i] = "-"
+		}
+	}
+	t.rows = append(t.rows, cells)
+}
+
+// print writes v in the configured output format. In table format it
+// writes tbl, or v as indented JSON if tbl is nil.
+func (a *app) print(v any, tbl *table) error {
+	cfg, err := a.config()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case cfg.Output == "yaml":
+		data, err := yaml.Encode(v)
+		if err != nil {
+			return err
+		}
+		_, err = a.stdout.Write(data)
+		return err
+	case cfg.Output == "json" || tbl == nil:
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(tbl.header, "\t"))
+	for _, row := range tbl.rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// printf writes a confirmation message in table format. Machine-readable
+// formats print nothing for commands without a result.
+func (a *app) printf(format string, args ...any) error {
+	cfg, err := a.config()
+	if err != nil {
+		return err
+	}
+	if cfg.Output != "table" {
+		return nil
+	}
+	_, err = fmt.Fprintf(a.stdout, format+"\n", args...)
+	return err
+}
+
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func tags(t []contextforge.Tag) string {
+	return strings.Join(contextforge.TagNames(t), ",")
+}
+
+func ts(t *contextforge.Timestamp) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
+
+func toolsTable(tools []*contextforge.Tool) *table {
+	t := &table{header: []string{"ID", "NAME", "ENABLED", "VISIBILITY", "TAGS", "DESCRIPTION"}}
+	for _, tool := range tools {
+		t.add(tool.ID, tool.Name, strconv.FormatBool(tool.Enabled), tool.Visibility, tags(tool.Tags), str(tool.Description))
+	}
+	return t
+}
+
+func resourcesTable(resources []*contextforge.Resource) *table {
+	t := &table{header: []string{"ID", "URI", "NAME", "ACTIVE", "MIME TYPE", "TAGS"}}
+	for _, r := range resources {
+		id := ""
+		if r.ID != nil {
+			id = r.ID.String()
+		}
+		t.add(id, r.URI, r.Name, strconv.FormatBool(r.IsActive || r.Enabled), str(r.MimeType), tags(r.Tags))
+	}
+	return t
+}
+
+func gatewaysTable(gateways []*contextforge.Gateway) *table {
+	t := &table{header: []string{"ID", "NAME", "URL", "TRANSPORT", "ENABLED", "REACHABLE"}}
+	for _, g := range gateways {
+		t.add(str(g.ID), g.Name, g.URL, g.Transport, strconv.FormatBool(g.Enabled), strconv.FormatBool(g.Reachable))
+	}
+	return t
+}
+
+func serversTable(servers []*contextforge.Server) *table {
+	t := &table{header: []string{"ID", "NAME", "ACTIVE", "TOOLS", "RESOURCES", "PROMPTS", "TAGS"}}
+	for _, s := range servers {
+		t.add(s.ID, s.Name, strconv.FormatBool(s.IsActive || s.Enabled),
+			strconv.Itoa(len(s.AssociatedTools)), strconv.Itoa(len(s.AssociatedResources)), strconv.Itoa(len(s.AssociatedPrompts)),
+			tags(s.Tags))
+	}
+	return t
+}
+
+func promptsTable(prompts []*contextforge.Prompt) *table {
+	t := &table{header: []string{"ID", "NAME", "ACTIVE", "ARGUMENTS", "TAGS", "DESCRIPTION"}}
+	for _, p := range prompts {
+		args := make([]string, len(p.Arguments))
+		for i, arg := range p.Arguments {
+			args[i] = arg.Name
+		}
+		t.add(p.ID, p.Name, strconv.FormatBool(p.IsActive || p.Enabled), strings.Join(args, ","), tags(p.Tags), str(p.Description))
+	}
+	return t
+}
+
+func agentsTable(agents []*contextforge.Agent) *table {
+	t := &table{header: []string{"ID", "NAME", "ENDPOINT", "TYPE", "ENABLED", "REACHABLE"}}
+	for _, ag := range agents {
+		t.add(ag.ID, ag.Name, ag.EndpointURL, ag.AgentType, strconv.FormatBool(ag.Enabled), strconv.FormatBool(ag.Reachable))
+	}
+	return t
+}
+
+func teamsTable(teams []*contextforge.Team) *table {
+	t := &table{header: []string{"ID", "NAME", "SLUG", "MEMBERS", "PERSONAL", "VISIBILITY"}}
+	for _, team := range teams {
+		t.add(team.ID, team.Name, team.Slug, strconv.Itoa(team.MemberCount), strconv.FormatBool(team.IsPersonal), str(team.Visibility))
+	}
+	return t
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+func promptsCommand() *command {
+	return &command{
+		name:    "prompts",
+		summary: "Manage prompt templates",
+		commands: []*command{
+			{name: "list", summary: "List prompts", run: runPromptsList},
+			{name: "render", args: "<prompt-id>", summary: "Render a prompt with arguments", run: runPromptsRender},
+			deleteCommand("prompt", func(c *contextforge.Client) deleteFunc { return c.Prompts.Delete }),
+			stateCommand("prompt", func(c *contextforge.Client) setStateFunc[contextforge.Prompt] { return c.Prompts.SetState }),
+		},
+	}
+}
+
+func runPromptsList(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	var lf listFlags
+	lf.register(fs, true)
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+
+	prompts, err := collect(client.Prompts.All(ctx, &contextforge.PromptListOptions{
+		ListOptions:     contextforge.ListOptions{MaxItems: lf.limit},
+		IncludeInactive: lf.includeInactive,
+		Tags:            lf.tags,
+		TeamID:          lf.teamID,
+		Visibility:      lf.visibility,
+	}))
+	if err != nil {
+		return err
+	}
+	return a.print(prompts, promptsTable(prompts))
+}
+
+func runPromptsRender(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	promptArgs := keyValues{}
+	fs.Var(promptArgs, "arg", "template argument as `name=value`; repeatable")
+	pos, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+
+	result, _, err := client.Prompts.Get(ctx, pos[0], promptArgs)
+	if err != nil {
+		return err
+	}
+
+	tbl := &table{header: []string{"ROLE", "CONTENT"}}
+	for _, m := range result.Messages {
+		tbl.add(m.Role, messageText(m.Content))
+	}
+	return a.print(result, tbl)
+}
+
+// messageText returns the printable content of a prompt message.
+func messageText(c *contextforge.PromptMessageContent) string {
+	switch {
+	case c == nil:
+		return ""
+	case c.Text != nil:
+		return *c.Text
+	case c.URI != nil:
+		return *c.URI
+	case c.Data != nil:
+		data, _ := json.Marshal(c.Data)
+		return string(data)
+	}
+	return fmt.Sprintf("(%s)", c.Type)
+}
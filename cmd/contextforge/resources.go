@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+func resourcesCommand() *command {
+	return &command{
+		name:    "resources",
+		summary: "Manage resources",
+		commands: []*command{
+			{name: "list", summary: "List resources", run: runResourcesList},
+			getCommand("resource", func(c *contextforge.Client) getFunc[contextforge.Resource] {
+				return func(ctx context.Context, id string) (*contextforge.Resource, *contextforge.Response, error) {
+					return c.Resources.GetInfo(ctx, id, &contextforge.ResourceInfoOptions{IncludeInactive: true})
+				}
+			}, resourcesTable),
+			{name: "read", args: "<resource-id>", summary: "Print a resource's content", run: runResourcesRead},
+			deleteCommand("resource", func(c *contextforge.Client) deleteFunc { return c.Resources.Delete }),
+			stateCommand("resource", func(c *contextforge.Client) setStateFunc[contextforge.Resource] { return c.Resources.SetState }),
+		},
+	}
+}
+
+func runResourcesList(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	var lf listFlags
+	lf.register(fs, true)
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+
+	resources, err := collect(client.Resources.All(ctx, &contextforge.ResourceListOptions{
+		ListOptions:     contextforge.ListOptions{MaxItems: lf.limit},
+		IncludeInactive: lf.includeInactive,
+		Tags:            lf.tags,
+		TeamID:          lf.teamID,
+		Visibility:      lf.visibility,
+	}))
+	if err != nil {
+		return err
+	}
+	return a.print(resources, resourcesTable(resources))
+}
+
+func runResourcesRead(ctx context.Context, a *app, args []string) error {
+	pos, err := a.parse(a.flags(), args, 1)
+	if err != nil {
+		return err
+	}
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+
+	content, _, err := client.Resources.Get(ctx, pos[0])
+	if err != nil {
+		return err
+	}
+
+	// In table format, print the content itself so it can be piped.
+	if cfg, _ := a.config(); cfg.Output == "table" {
+		text := content.Text
+		if text == nil {
+			text = content.Blob
+		}
+		_, err := a.stdout.Write([]byte(str(text)))
+		return err
+	}
+	return a.print(content, nil)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+func serversCommand() *command {
+	return &command{
+		name:    "servers",
+		summary: "Manage virtual servers",
+		commands: []*command{
+			{name: "list", summary: "List virtual servers", run: runServersList},
+			getCommand("server", func(c *contextforge.Client) getFunc[contextforge.Server] { return c.Servers.Get }, serversTable),
+			deleteCommand("server", func(c *contextforge.Client) deleteFunc { return c.Servers.Delete }),
+			stateCommand("server", func(c *contextforge.Client) setStateFunc[contextforge.Server] { return c.Servers.SetState }),
+			{name: "tools", args: "<server-id>", summary: "List the tools a server exposes", run: runServersTools},
+			{name: "resources", args: "<server-id>", summary: "List the resources a server exposes", run: runServersResources},
+			{name: "prompts", args: "<server-id>", summary: "List the prompts a server exposes", run: runServersPrompts},
+		},
+	}
+}
+
+func runServersList(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	var lf listFlags
+	lf.register(fs, true)
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+
+	servers, err := collect(client.Servers.All(ctx, &contextforge.ServerListOptions{
+		ListOptions:     contextforge.ListOptions{MaxItems: lf.limit},
+		IncludeInactive: lf.includeInactive,
+		Tags:            lf.tags,
+		TeamID:          lf.teamID,
+		Visibility:      lf.visibility,
+	}))
+	if err != nil {
+		return err
+	}
+	return a.print(servers, serversTable(servers))
+}
+
+// serverAssociation parses the arguments of the commands that list a
+// server's associations and returns the server ID and list options.
+func serverAssociation(a *app, args []string) (*contextforge.Client, string, *contextforge.ServerAssociationOptions, error) {
+	fs := a.flags()
+	opts := &contextforge.ServerAssociationOptions{}
+	fs.BoolVar(&opts.IncludeInactive, "include-inactive", false, "include inactive entities")
+	pos, err := a.parse(fs, args, 1)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	client, err := a.api()
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return client, pos[0], opts, nil
+}
+
+func runServersTools(ctx context.Context, a *app, args []string) error {
+	client, id, opts, err := serverAssociation(a, args)
+	if err != nil {
+		return err
+	}
+	tools, _, err := client.Servers.ListTools(ctx, id, opts)
+	if err != nil {
+		return err
+	}
+	return a.print(tools, toolsTable(tools))
+}
+
+func runServersResources(ctx context.Context, a *app, args []string) error {
+	client, id, opts, err := serverAssociation(a, args)
+	if err != nil {
+		return err
+	}
+	resources, _, err := client.Servers.ListResources(ctx, id, opts)
+	if err != nil {
+		return err
+	}
+	return a.print(resources, resourcesTable(resources))
+}
+
+func runServersPrompts(ctx context.Context, a *app, args []string) error {
+	client, id, opts, err := serverAssociation(a, args)
+	if err != nil {
+		return err
+	}
+	prompts, _, err := client.Servers.ListPrompts(ctx, id, opts)
+	if err != nil {
+		return err
+	}
+	return a.print(prompts, promptsTable(prompts))
+}
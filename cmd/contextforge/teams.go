@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+func teamsCommand() *command {
+	return &command{
+		name:    "teams",
+		summary: "Manage teams and their members",
+		commands: []*command{
+			{name: "list", summary: "List teams", run: runTeamsList},
+			getCommand("team", func(c *contextforge.Client) getFunc[contextforge.Team] { return c.Teams.Get }, teamsTable),
+			{name: "members", args: "<team-id>", summary: "List a team's members", run: runTeamsMembers},
+			{name: "invite", args: "<team-id> <email>", summary: "Invite a user to a team", run: runTeamsInvite},
+		},
+	}
+}
+
+func runTeamsList(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	limit := fs.Int("limit", 0, "maximum number of teams to list (0 for all)")
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+
+	teams, err := collect(client.Teams.All(ctx, &contextforge.TeamListOptions{MaxItems: *limit}))
+	if err != nil {
+		return err
+	}
+	return a.print(teams, teamsTable(teams))
+}
+
+func runTeamsMembers(ctx context.Context, a *app, args []string) error {
+	pos, err := a.parse(a.flags(), args, 1)
+	if err != nil {
+		return err
+	}
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+
+	members, _, err := client.Teams.ListMembers(ctx, pos[0])
+	if err != nil {
+		return err
+	}
+
+	tbl := &table{header: []string{"EMAIL", "ROLE", "ACTIVE", "JOINED"}}
+	for _, m := range members {
+		tbl.add(m.UserEmail, m.Role, strconv.FormatBool(m.IsActive), ts(m.JoinedAt))
+	}
+	return a.print(members, tbl)
+}
+
+func runTeamsInvite(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	role := fs.String("role", "", "member `role`: owner or member (default member)")
+	pos, err := a.parse(fs, args, 2)
+	if err != nil {
+		return err
+	}
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+
+	invitation, _, err := client.Teams.InviteMember(ctx, pos[0], &contextforge.TeamInvite{Email: pos[1], Role: optional(*role)})
+	if err != nil {
+		return err
+	}
+
+	tbl := &table{header: []string{"ID", "TEAM", "EMAIL", "ROLE", "EXPIRES"}}
+	tbl.add(invitation.ID, invitation.TeamName, invitation.Email, invitation.Role, ts(invitation.ExpiresAt))
+	return a.print(invitation, tbl)
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+	"github.com/leefowlercu/go-contextforge/jsonschema"
+)
+
+func toolsCommand() *command {
+	return &command{
+		name:    "tools",
+		summary: "Manage tools",
+		commands: []*command{
+			{name: "list", summary: "List tools", run: runToolsList},
+			getCommand("tool", func(c *contextforge.Client) getFunc[contextforge.Tool] { return c.Tools.Get }, toolsTable),
+			{name: "create", summary: "Create a REST tool from flags or a JSON or YAML file", run: runToolsCreate},
+			deleteCommand("tool", func(c *contextforge.Client) deleteFunc { return c.Tools.Delete }),
+			stateCommand("tool", func(c *contextforge.Client) setStateFunc[contextforge.Tool] { return c.Tools.SetState }),
+			{name: "invoke", args: "<tool-name>", summary: "Call a tool and print its result", run: runToolsInvoke},
+		},
+	}
+}
+
+func runToolsList(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	var lf listFlags
+	lf.register(fs, true)
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+
+	tools, err := collect(client.Tools.All(ctx, &contextforge.ToolListOptions{
+		ListOptions:     contextforge.ListOptions{MaxItems: lf.limit},
+		IncludeInactive: lf.includeInactive,
+		Tags:            lf.tags,
+		TeamID:          lf.teamID,
+		Visibility:      lf.visibility,
+	}))
+	if err != nil {
+		return err
+	}
+	return a.print(tools, toolsTable(tools))
+}
+
+func runToolsCreate(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	file := fs.String("file", "", "JSON or YAML `file` describing the tool; flags override its fields")
+	name := fs.String("name", "", "tool `name`")
+	description := fs.String("description", "", "tool `description`")
+	schema := fs.String("schema", "", "input schema as `JSON`, or @file")
+	tagList := fs.String("tags", "", "comma-separated `tags`")
+	visibility := fs.String("visibility", "", "`visibility`: private, team, or public")
+	teamID := fs.String("team-id", "", "owning team `ID`")
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	tool := &contextforge.Tool{}
+	if *file != "" {
+		if err := decodeFile(*file, tool); err != nil {
+			return err
+		}
+	}
+	if *name != "" {
+		tool.Name = *name
+	}
+	if *description != "" {
+		tool.Description = description
+	}
+	if *schema != "" {
+		if err := jsonArg("schema", *schema, &tool.InputSchema); err != nil {
+			return err
+		}
+	}
+	if *tagList != "" {
+		tool.Tags = contextforge.NewTags(splitList(*tagList))
+	}
+	if tool.Name == "" {
+		return usagef("a tool name is required; set --name or name in --file")
+	}
+	if tool.InputSchema != nil {
+		if _, err := jsonschema.Compile(tool.InputSchema); err != nil {
+			return fmt.Errorf("invalid input schema; %w", err)
+		}
+	}
+
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	created, _, err := client.Tools.Create(ctx, tool, &contextforge.ToolCreateOptions{
+		TeamID:     optional(*teamID),
+		Visibility: optional(*visibility),
+	})
+	if err != nil {
+		return err
+	}
+	return a.print(created, toolsTable([]*contextforge.Tool{created}))
+}
+
+func runToolsInvoke(ctx context.Context, a *app, args []string) error {
+	fs := a.flags()
+	argsJSON := fs.String("args", "", "tool arguments as a JSON `object`, or @file")
+	pos, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	name := pos[0]
+
+	var toolArgs map[string]any
+	if *argsJSON != "" {
+		if err := jsonArg("args", *argsJSON, &toolArgs); err != nil {
+			return err
+		}
+	}
+
+	client, err := a.api()
+	if err != nil {
+		return err
+	}
+	result, _, err := client.Tools.Invoke(ctx, name, toolArgs, nil)
+	if err != nil {
+		return err
+	}
+
+	tbl := &table{header: []string{"TYPE", "CONTENT"}}
+	for _, c := range result.Content {
+		text := c.Text
+		if c.Type != "text" {
+			text = c.Data
+		}
+		tbl.add(c.Type, text)
+	}
+	if err := a.print(result, tbl); err != nil {
+		return err
+	}
+	if result.IsError {
+		return fmt.Errorf("tool %q reported an error", name)
+	}
+	return nil
+}
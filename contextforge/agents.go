@@ -2,12 +2,26 @@ package contextforge
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
+	"iter"
 	"net/http"
 	"net/url"
+	"sync"
+	"time"
 )
 
+// requestIDHeader carries the client-generated ID of a streaming invocation,
+// under which ContextForge registers the run for cancellation.
+const requestIDHeader = "X-Request-ID"
+
+// remoteCancelTimeout bounds the cancellation request sent when the context
+// of a streaming invocation is canceled.
+const remoteCancelTimeout = 10 * time.Second
+
 // AgentsService handles communication with the A2A agent-related
 // methods of the ContextForge API.
 //
@@ -34,7 +48,7 @@ func (s *AgentsService) List(ctx context.Context, opts *AgentListOptions) ([]*Ag
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Agents", Method: "List"}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -56,11 +70,45 @@ func (s *AgentsService) List(ctx context.Context, opts *AgentListOptions) ([]*Ag
 	return agents, resp, nil
 }
 
+// All returns an iterator over every agent matching opts, following
+// pagination cursors until the result set is exhausted or opts.MaxItems
+// agents have been yielded. Iteration stops with ctx.Err() if ctx is done.
+func (s *AgentsService) All(ctx context.Context, opts *AgentListOptions) iter.Seq2[*Agent, error] {
+	reqOpts := &AgentListOptions{}
+	if opts != nil {
+		*reqOpts = *opts
+	}
+
+	return allByCursor(ctx, reqOpts.Cursor, reqOpts.MaxItems, func(ctx context.Context, cursor string) ([]*Agent, *Response, error) {
+		pageOpts := *reqOpts
+		pageOpts.Cursor = cursor
+		return s.List(ctx, &pageOpts)
+	})
+}
+
+// AllBySkip returns an iterator over every agent using legacy skip/limit
+// pagination, advancing Skip by the size of each page. Iteration ends at the
+// first empty or short page, or once opts.MaxItems agents have been yielded.
+// Prefer All against servers that support cursor pagination.
+func (s *AgentsService) AllBySkip(ctx context.Context, opts *AgentListOptions) iter.Seq2[*Agent, error] {
+	reqOpts := &AgentListOptions{}
+	if opts != nil {
+		*reqOpts = *opts
+	}
+
+	return allByOffset(ctx, reqOpts.Skip, reqOpts.Limit, reqOpts.MaxItems, func(ctx context.Context, skip int) ([]*Agent, *Response, error) {
+		pageOpts := *reqOpts
+		pageOpts.Skip = skip
+		pageOpts.Cursor = ""
+		return s.List(ctx, &pageOpts)
+	})
+}
+
 // Get retrieves a specific agent by its ID.
 func (s *AgentsService) Get(ctx context.Context, agentID string) (*Agent, *Response, error) {
 	u := fmt.Sprintf("a2a/%s", url.PathEscape(agentID))
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Agents", Method: "Get", EntityID: agentID}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -94,7 +142,7 @@ func (s *AgentsService) Create(ctx context.Context, agent *AgentCreate, opts *Ag
 		}
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, body)
+	req, err := s.client.newRequest(Operation{Service: "Agents", Method: "Create"}, http.MethodPost, u, body)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -113,7 +161,7 @@ func (s *AgentsService) Create(ctx context.Context, agent *AgentCreate, opts *Ag
 func (s *AgentsService) Update(ctx context.Context, agentID string, agent *AgentUpdate) (*Agent, *Response, error) {
 	u := fmt.Sprintf("a2a/%s", url.PathEscape(agentID))
 
-	req, err := s.client.NewRequest(http.MethodPut, u, agent)
+	req, err := s.client.newRequest(Operation{Service: "Agents", Method: "Update", EntityID: agentID}, http.MethodPut, u, agent)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -131,7 +179,7 @@ func (s *AgentsService) Update(ctx context.Context, agentID string, agent *Agent
 func (s *AgentsService) Delete(ctx context.Context, agentID string) (*Response, error) {
 	u := fmt.Sprintf("a2a/%s", url.PathEscape(agentID))
 
-	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Agents", Method: "Delete", EntityID: agentID}, http.MethodDelete, u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -142,18 +190,18 @@ func (s *AgentsService) Delete(ctx context.Context, agentID string) (*Response,
 
 // SetState sets an agent's enabled status using the preferred /state endpoint.
 func (s *AgentsService) SetState(ctx context.Context, agentID string, activate bool) (*Agent, *Response, error) {
-	return s.setState(ctx, agentID, activate, "state")
+	return s.setState(ctx, "SetState", agentID, activate, "state")
 }
 
 // Toggle toggles an agent's enabled status using the legacy /toggle endpoint.
 func (s *AgentsService) Toggle(ctx context.Context, agentID string, activate bool) (*Agent, *Response, error) {
-	return s.setState(ctx, agentID, activate, "toggle")
+	return s.setState(ctx, "Toggle", agentID, activate, "toggle")
 }
 
-func (s *AgentsService) setState(ctx context.Context, agentID string, activate bool, endpoint string) (*Agent, *Response, error) {
+func (s *AgentsService) setState(ctx context.Context, method, agentID string, activate bool, endpoint string) (*Agent, *Response, error) {
 	u := fmt.Sprintf("a2a/%s/%s?activate=%t", url.PathEscape(agentID), endpoint, activate)
 
-	req, err := s.client.NewRequest(http.MethodPost, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Agents", Method: method, EntityID: agentID}, http.MethodPost, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -167,22 +215,221 @@ func (s *AgentsService) setState(ctx context.Context, agentID string, activate b
 	return agent, resp, nil
 }
 
-// Invoke invokes an A2A agent by name with specified parameters.
+// Invoke invokes an A2A agent by name with specified parameters and returns
+// the decoded task, message, or error payload along with the raw response.
 // Note: Uses agent name (not ID) as identifier.
 // The req parameter is optional; pass nil to use default parameters.
-func (s *AgentsService) Invoke(ctx context.Context, agentName string, req *AgentInvokeRequest) (map[string]any, *Response, error) {
+func (s *AgentsService) Invoke(ctx context.Context, agentName string, req *AgentInvokeRequest) (*AgentInvokeResult, *Response, error) {
+	var result *AgentInvokeResult
+	resp, err := s.invoke(ctx, "Invoke", agentName, req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result, resp, nil
+}
+
+// InvokeInto invokes an A2A agent by name like AgentsService.Invoke, but
+// decodes the response body into a value of type T. Use it for agents whose
+// responses do not follow the A2A task and message shapes.
+func InvokeInto[T any](ctx context.Context, s *AgentsService, agentName string, req *AgentInvokeRequest) (*T, *Response, error) {
+	result := new(T)
+	resp, err := s.invoke(ctx, "InvokeInto", agentName, req, result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result, resp, nil
+}
+
+// invoke posts req to the agent's invoke endpoint and decodes the response into v.
+func (s *AgentsService) invoke(ctx context.Context, method, agentName string, req *AgentInvokeRequest, v any) (*Response, error) {
 	u := fmt.Sprintf("a2a/%s/invoke", url.PathEscape(agentName))
 
-	httpReq, err := s.client.NewRequest(http.MethodPost, u, req)
+	httpReq, err := s.client.newRequest(Operation{Service: "Agents", Method: method, EntityID: agentName}, http.MethodPost, u, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, httpReq, v)
+}
+
+// InvokeStream invokes an A2A agent by name and streams its progress as
+// server-sent events. Read the events with AgentStream.Events, and close the
+// stream if iteration is abandoned before it ends.
+//
+// Each call is assigned a fresh request ID, available as AgentStream.RequestID.
+// If ctx is canceled before the stream ends, the in-flight run is also
+// cancelled server-side with CancellationService.Cancel. The Timeout of the
+// client's http.Client does not apply to streams, which may run for as long
+// as the agent does; use ctx to bound them.
+// Note: Uses agent name (not ID) as identifier.
+func (s *AgentsService) InvokeStream(ctx context.Context, agentName string, req *AgentInvokeRequest) (*AgentStream, *Response, error) {
+	u := fmt.Sprintf("a2a/%s/invoke", url.PathEscape(agentName))
+
+	httpReq, err := s.client.newRequest(Operation{Service: "Agents", Method: "InvokeStream", EntityID: agentName}, http.MethodPost, u, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requestID, err := newRequestID()
 	if err != nil {
 		return nil, nil, err
 	}
+	httpReq.Header.Set(requestIDHeader, requestID)
+
+	// Cancel the run server-side if ctx ends before the stream does. The
+	// callback is registered before sending so that a cancellation racing
+	// with the initial response is not lost.
+	stop := context.AfterFunc(ctx, func() {
+		s.cancelRemote(ctx, requestID)
+	})
 
-	var result map[string]any
-	resp, err := s.client.Do(ctx, httpReq, &result)
+	streamCtx, cancel := context.WithCancel(ctx)
+	resp, err := s.client.doStream(streamCtx, httpReq)
 	if err != nil {
+		if ctx.Err() == nil {
+			stop()
+		}
+		cancel()
 		return nil, resp, err
 	}
 
-	return result, resp, nil
+	body := resp.Body
+	stream := &AgentStream{
+		RequestID: requestID,
+		events:    agentEvents(ctx, newSSEReader(body)),
+		close: func() error {
+			stop()
+			cancel()
+			return body.Close()
+		},
+	}
+
+	return stream, resp, nil
+}
+
+// cancelRemote asks ContextForge to cancel the run registered as requestID.
+// It is called after ctx is done, so the request uses a detached context.
+func (s *AgentsService) cancelRemote(ctx context.Context, requestID string) {
+	cancelCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), remoteCancelTimeout)
+	defer cancel()
+
+	_, _, err := s.client.Cancel.Cancel(cancelCtx, &CancellationRequest{
+		RequestID: requestID,
+		Reason:    String("client context canceled"),
+	})
+	if err != nil && s.client.Logger != nil {
+		s.client.Logger.WarnContext(cancelCtx, "canceling agent invocation",
+			"request_id", requestID,
+			"error", err,
+		)
+	}
+}
+
+// AgentStream is a streaming A2A agent invocation returned by
+// AgentsService.InvokeStream.
+type AgentStream struct {
+	// RequestID identifies the invocation for CancellationService.
+	RequestID string
+
+	events iter.Seq2[*AgentStreamEvent, error]
+	close  func() error
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// NewAgentStream returns an AgentStream with the given request ID that
+// yields events, for tests of code that uses AgentsAPI. Close stops the
+// iteration of events but has no other effect. A zero AgentStream yields no
+// events.
+func NewAgentStream(requestID string, events iter.Seq2[*AgentStreamEvent, error]) *AgentStream {
+	return &AgentStream{RequestID: requestID, events: events}
+}
+
+// Events returns an iterator over the events of the stream, which ends when
+// the agent closes the stream. The stream is closed when iteration stops.
+// If the stream fails, or the context passed to InvokeStream is done, the
+// error is yielded once and iteration stops.
+//
+// Events may only be iterated once.
+func (s *AgentStream) Events() iter.Seq2[*AgentStreamEvent, error] {
+	return func(yield func(*AgentStreamEvent, error) bool) {
+		defer s.Close()
+
+		if s.events == nil {
+			return
+		}
+		for event, err := range s.events {
+			if !yield(event, err) {
+				return
+			}
+		}
+	}
+}
+
+// Close releases the stream's connection. Closing a stream does not cancel
+// the run server-side; cancel the context passed to InvokeStream for that.
+func (s *AgentStream) Close() error {
+	s.closeOnce.Do(func() {
+		if s.close != nil {
+			s.closeErr = s.close()
+		}
+	})
+	return s.closeErr
+}
+
+// agentEvents returns an iterator over the A2A events read from events,
+// which stops after yielding the first error. ctx is the context of the
+// invocation.
+func agentEvents(ctx context.Context, events *sseReader) iter.Seq2[*AgentStreamEvent, error] {
+	return func(yield func(*AgentStreamEvent, error) bool) {
+		for {
+			ev, err := events.Next()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				// A canceled context surfaces as a read error on the body;
+				// the context's error is more useful.
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					err = ctxErr
+				}
+				yield(nil, err)
+				return
+			}
+			if ev.Data == "" {
+				continue
+			}
+
+			p, err := decodeA2APayload([]byte(ev.Data))
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			event := &AgentStreamEvent{
+				ID:             ev.ID,
+				StatusUpdate:   p.statusUpdate,
+				ArtifactUpdate: p.artifactUpdate,
+				Task:           p.task,
+				Message:        p.message,
+				Error:          p.err,
+				Raw:            json.RawMessage(ev.Data),
+			}
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+}
+
+// newRequestID returns a random identifier for a cancellable request.
+func newRequestID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate request id; %w", err)
+	}
+	return hex.EncodeToString(b), nil
 }
@@ -3,9 +3,11 @@ package contextforge
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestAgentsService_List(t *testing.T) {
@@ -410,8 +412,12 @@ func TestAgentsService_Invoke(t *testing.T) {
 		t.Errorf("Agents.Invoke returned error: %v", err)
 	}
 
-	if result["result"] != "success" {
-		t.Errorf("Agents.Invoke returned result %q, want %q", result["result"], "success")
+	want := `{"result":"success","data":"response data"}`
+	if string(result.Raw) != want {
+		t.Errorf("Agents.Invoke returned raw %s, want %s", result.Raw, want)
+	}
+	if result.Task != nil || result.Message != nil || result.Error != nil {
+		t.Errorf("Agents.Invoke decoded A2A payload from a non-A2A response: %+v", result)
 	}
 }
 
@@ -433,8 +439,152 @@ func TestAgentsService_Invoke_Nil(t *testing.T) {
 		t.Errorf("Agents.Invoke returned error: %v", err)
 	}
 
-	if result["result"] != "success" {
-		t.Errorf("Agents.Invoke returned result %q, want %q", result["result"], "success")
+	if string(result.Raw) != `{"result":"success"}` {
+		t.Errorf("Agents.Invoke returned raw %s, want %s", result.Raw, `{"result":"success"}`)
+	}
+}
+
+func TestAgentsService_Invoke_Task(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/a2a/test-agent/invoke", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{
+			"jsonrpc": "2.0",
+			"id": 1,
+			"result": {
+				"kind": "task",
+				"id": "task-1",
+				"contextId": "ctx-1",
+				"status": {
+					"state": "completed",
+					"timestamp": "2026-02-09T10:00:00Z",
+					"message": {"role": "agent", "parts": [{"kind": "text", "text": "done"}]}
+				},
+				"artifacts": [{
+					"artifactId": "art-1",
+					"name": "report",
+					"parts": [
+						{"kind": "text", "text": "summary"},
+						{"kind": "file", "file": {"name": "report.pdf", "mimeType": "application/pdf", "uri": "https://example.com/report.pdf"}},
+						{"kind": "data", "data": {"score": 0.9}}
+					]
+				}]
+			}
+		}`)
+	})
+
+	result, _, err := client.Agents.Invoke(context.Background(), "test-agent", nil)
+	if err != nil {
+		t.Fatalf("Agents.Invoke returned error: %v", err)
+	}
+
+	task := result.Task
+	if task == nil {
+		t.Fatalf("Agents.Invoke Task = nil, want task (raw %s)", result.Raw)
+	}
+	if task.ID != "task-1" || task.ContextID != "ctx-1" {
+		t.Errorf("task = %+v, want id task-1 in ctx-1", task)
+	}
+	if task.Status.State != A2ATaskStateCompleted || !task.Status.State.Terminal() {
+		t.Errorf("task state = %q, want terminal %q", task.Status.State, A2ATaskStateCompleted)
+	}
+	if got := task.Status.Message.Text(); got != "done" {
+		t.Errorf("status message text = %q, want %q", got, "done")
+	}
+	if len(task.Artifacts) != 1 || len(task.Artifacts[0].Parts) != 3 {
+		t.Fatalf("artifacts = %+v, want one artifact with three parts", task.Artifacts)
+	}
+	parts := task.Artifacts[0].Parts
+	if parts[0].Kind != "text" || parts[0].Text != "summary" {
+		t.Errorf("parts[0] = %+v, want text part", parts[0])
+	}
+	if parts[1].Kind != "file" || StringValue(parts[1].File.URI) != "https://example.com/report.pdf" {
+		t.Errorf("parts[1] = %+v, want file part with uri", parts[1])
+	}
+	if parts[2].Kind != "data" || parts[2].Data["score"] != 0.9 {
+		t.Errorf("parts[2] = %+v, want data part", parts[2])
+	}
+	if result.Message != nil || result.Error != nil {
+		t.Errorf("Agents.Invoke set Message or Error for a task response: %+v", result)
+	}
+}
+
+func TestAgentsService_Invoke_Message(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	// An unwrapped message using the earlier "type" part discriminator.
+	mux.HandleFunc("/a2a/test-agent/invoke", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"role":"agent","messageId":"m-1","parts":[{"type":"text","text":"hello "},{"type":"text","text":"world"}]}`)
+	})
+
+	result, _, err := client.Agents.Invoke(context.Background(), "test-agent", nil)
+	if err != nil {
+		t.Fatalf("Agents.Invoke returned error: %v", err)
+	}
+
+	if result.Message == nil {
+		t.Fatalf("Agents.Invoke Message = nil, want message (raw %s)", result.Raw)
+	}
+	if got := result.Message.Text(); got != "hello world" {
+		t.Errorf("message text = %q, want %q", got, "hello world")
+	}
+	if result.Task != nil {
+		t.Errorf("Agents.Invoke Task = %+v, want nil", result.Task)
+	}
+}
+
+func TestAgentsService_Invoke_Error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/a2a/test-agent/invoke", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-32001,"message":"Task not found","data":{"taskId":"t-9"}}}`)
+	})
+
+	result, _, err := client.Agents.Invoke(context.Background(), "test-agent", nil)
+	if err != nil {
+		t.Fatalf("Agents.Invoke returned error: %v", err)
+	}
+
+	if result.Error == nil {
+		t.Fatalf("Agents.Invoke Error = nil, want error payload (raw %s)", result.Raw)
+	}
+	if result.Error.Code != -32001 || result.Error.Message != "Task not found" {
+		t.Errorf("Error = %+v, want code -32001 Task not found", result.Error)
+	}
+	if got := result.Error.Error(); got != "a2a error -32001: Task not found" {
+		t.Errorf("Error.Error() = %q", got)
+	}
+	if result.Task != nil || result.Message != nil {
+		t.Errorf("Agents.Invoke decoded a result alongside an error: %+v", result)
+	}
+}
+
+func TestInvokeInto(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/a2a/test-agent/invoke", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"status":"success","execution_time":123}`)
+	})
+
+	type customResult struct {
+		Status        string `json:"status"`
+		ExecutionTime int    `json:"execution_time"`
+	}
+
+	got, _, err := InvokeInto[customResult](context.Background(), client.Agents, "test-agent", nil)
+	if err != nil {
+		t.Fatalf("InvokeInto returned error: %v", err)
+	}
+
+	want := &customResult{Status: "success", ExecutionTime: 123}
+	if *got != *want {
+		t.Errorf("InvokeInto = %+v, want %+v", got, want)
 	}
 }
 
@@ -456,3 +606,255 @@ func TestAgentsService_URLEscaping(t *testing.T) {
 		t.Errorf("Agents.Invoke with URL escaping returned error: %v", err)
 	}
 }
+
+// writeSSE writes one server-sent event carrying data and flushes it.
+func writeSSE(t *testing.T, w http.ResponseWriter, id, data string) {
+	t.Helper()
+	if id != "" {
+		fmt.Fprintf(w, "id: %s\n", id)
+	}
+	fmt.Fprintf(w, "data: %s\n\n", data)
+	w.(http.Flusher).Flush()
+}
+
+func TestAgentsService_InvokeStream(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var requestID string
+	mux.HandleFunc("/a2a/test-agent/invoke", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if got := r.Header.Get("Accept"); got != "text/event-stream" {
+			t.Errorf("Accept = %q, want %q", got, "text/event-stream")
+		}
+		requestID = r.Header.Get("X-Request-ID")
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeSSE(t, w, "1", `{"jsonrpc":"2.0","id":1,"result":{"kind":"task","id":"task-1","status":{"state":"submitted"}}}`)
+		writeSSE(t, w, "2", `{"jsonrpc":"2.0","id":1,"result":{"kind":"status-update","taskId":"task-1","status":{"state":"working","message":{"role":"agent","parts":[{"kind":"text","text":"thinking"}]}}}}`)
+		writeSSE(t, w, "3", `{"jsonrpc":"2.0","id":1,"result":{"kind":"artifact-update","taskId":"task-1","artifact":{"artifactId":"a-1","parts":[{"kind":"text","text":"partial"}]},"lastChunk":true}}`)
+		writeSSE(t, w, "4", `{"jsonrpc":"2.0","id":1,"result":{"kind":"status-update","taskId":"task-1","status":{"state":"completed"},"final":true}}`)
+	})
+	mux.HandleFunc("/cancellation/cancel", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("InvokeStream cancelled a stream that completed normally")
+	})
+
+	stream, _, err := client.Agents.InvokeStream(context.Background(), "test-agent", &AgentInvokeRequest{
+		Parameters: map[string]any{"query": "q"},
+	})
+	if err != nil {
+		t.Fatalf("Agents.InvokeStream returned error: %v", err)
+	}
+	if stream.RequestID == "" || stream.RequestID != requestID {
+		t.Errorf("RequestID = %q, server saw %q", stream.RequestID, requestID)
+	}
+
+	var events []*AgentStreamEvent
+	for ev, err := range stream.Events() {
+		if err != nil {
+			t.Fatalf("Events() yielded error: %v", err)
+		}
+		events = append(events, ev)
+	}
+
+	if len(events) != 4 {
+		t.Fatalf("received %d events, want 4", len(events))
+	}
+	if events[0].Task == nil || events[0].Task.Status.State != A2ATaskStateSubmitted {
+		t.Errorf("events[0] = %+v, want submitted task", events[0])
+	}
+	if su := events[1].StatusUpdate; su == nil || su.Status.State != A2ATaskStateWorking || su.Status.Message.Text() != "thinking" {
+		t.Errorf("events[1].StatusUpdate = %+v, want working update", su)
+	}
+	if au := events[2].ArtifactUpdate; au == nil || au.Artifact.ArtifactID != "a-1" || !au.LastChunk {
+		t.Errorf("events[2].ArtifactUpdate = %+v, want last chunk of a-1", au)
+	}
+	if su := events[3].StatusUpdate; su == nil || !su.Final || !su.Status.State.Terminal() {
+		t.Errorf("events[3].StatusUpdate = %+v, want final completed update", su)
+	}
+	if events[3].ID != "4" || len(events[3].Raw) == 0 {
+		t.Errorf("events[3] ID = %q, Raw = %s; want ID 4 with raw data", events[3].ID, events[3].Raw)
+	}
+}
+
+func TestAgentsService_InvokeStream_CancelContext(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/a2a/test-agent/invoke", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeSSE(t, w, "", `{"kind":"status-update","taskId":"task-1","status":{"state":"working"}}`)
+		<-r.Context().Done()
+	})
+
+	cancelled := make(chan CancellationRequest, 1)
+	mux.HandleFunc("/cancellation/cancel", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var body CancellationRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		cancelled <- body
+		fmt.Fprintf(w, `{"status":"cancelled","requestId":%q}`, body.RequestID)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, _, err := client.Agents.InvokeStream(ctx, "test-agent", nil)
+	if err != nil {
+		t.Fatalf("Agents.InvokeStream returned error: %v", err)
+	}
+
+	var gotErr error
+	n := 0
+	for _, err := range stream.Events() {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		n++
+		cancel()
+	}
+
+	if n != 1 {
+		t.Errorf("received %d events before cancellation, want 1", n)
+	}
+	if !errors.Is(gotErr, context.Canceled) {
+		t.Errorf("Events() error = %v, want %v", gotErr, context.Canceled)
+	}
+
+	select {
+	case req := <-cancelled:
+		if req.RequestID != stream.RequestID {
+			t.Errorf("cancelled request %q, want %q", req.RequestID, stream.RequestID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("InvokeStream did not call the cancellation endpoint")
+	}
+}
+
+func TestAgentsService_InvokeStream_CloseDoesNotCancel(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/a2a/test-agent/invoke", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeSSE(t, w, "", `{"kind":"status-update","taskId":"task-1","status":{"state":"working"}}`)
+		<-r.Context().Done()
+	})
+	mux.HandleFunc("/cancellation/cancel", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("InvokeStream cancelled the run after the stream was closed")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, _, err := client.Agents.InvokeStream(ctx, "test-agent", nil)
+	if err != nil {
+		t.Fatalf("Agents.InvokeStream returned error: %v", err)
+	}
+
+	for range stream.Events() {
+		break
+	}
+	cancel()
+
+	// Give a wrongly registered cancellation a chance to reach the server.
+	time.Sleep(50 * time.Millisecond)
+}
+
+func TestAgentsService_InvokeStream_OutlivesClientTimeout(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.client.Timeout = 50 * time.Millisecond
+
+	mux.HandleFunc("/a2a/test-agent/invoke", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeSSE(t, w, "1", `{"kind":"status-update","taskId":"task-1","status":{"state":"working"}}`)
+		time.Sleep(200 * time.Millisecond)
+		writeSSE(t, w, "2", `{"kind":"status-update","taskId":"task-1","status":{"state":"completed"},"final":true}`)
+	})
+
+	stream, _, err := client.Agents.InvokeStream(context.Background(), "test-agent", nil)
+	if err != nil {
+		t.Fatalf("Agents.InvokeStream returned error: %v", err)
+	}
+
+	n := 0
+	for _, err := range stream.Events() {
+		if err != nil {
+			t.Fatalf("Events() yielded error after %d events: %v", n, err)
+		}
+		n++
+	}
+	if n != 2 {
+		t.Errorf("received %d events, want 2", n)
+	}
+	if client.client.Timeout != 50*time.Millisecond {
+		t.Errorf("client Timeout = %v, want it left at 50ms", client.client.Timeout)
+	}
+}
+
+func TestAgentsService_InvokeStream_Error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/a2a/missing/invoke", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Agent not found"}`)
+	})
+
+	_, resp, err := client.Agents.InvokeStream(context.Background(), "missing", nil)
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("Agents.InvokeStream error = %v, want *ErrorResponse", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestNewAgentStream(t *testing.T) {
+	want := []*AgentStreamEvent{
+		{ID: "1", Message: &A2AMessage{MessageID: "m1"}},
+		{ID: "2", Message: &A2AMessage{MessageID: "m2"}},
+	}
+	failure := errors.New("stream failed")
+	stream := NewAgentStream("req-1", func(yield func(*AgentStreamEvent, error) bool) {
+		for _, ev := range want {
+			if !yield(ev, nil) {
+				return
+			}
+		}
+		yield(nil, failure)
+	})
+	if stream.RequestID != "req-1" {
+		t.Errorf("RequestID = %q, want %q", stream.RequestID, "req-1")
+	}
+
+	var got []*AgentStreamEvent
+	var gotErr error
+	for ev, err := range stream.Events() {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		got = append(got, ev)
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Events yielded %v, want %v", got, want)
+	}
+	if gotErr != failure {
+		t.Errorf("Events error = %v, want %v", gotErr, failure)
+	}
+	if err := stream.Close(); err != nil {
+		t.Errorf("Close returned error: %v", err)
+	}
+}
+
+func TestAgentStream_Zero(t *testing.T) {
+	stream := &AgentStream{}
+	for ev, err := range stream.Events() {
+		t.Errorf("Events yielded %v, %v; want nothing", ev, err)
+	}
+	if err := stream.Close(); err != nil {
+		t.Errorf("Close returned error: %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package contextforge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// AuthService handles communication with the authentication and API token
+// methods of the ContextForge API.
+//
+// Note: Login and Me use the email authentication endpoints (/auth/email/*).
+// API token management uses the /tokens endpoints.
+
+// Login authenticates with an email and password and returns a JWT access token.
+// The returned token is not installed on the client; pass it to NewClient or
+// wrap it in a TokenSource.
+func (s *AuthService) Login(ctx context.Context, login *LoginRequest) (*LoginResponse, *Response, error) {
+	if login == nil {
+		return nil, nil, fmt.Errorf("login request is nil")
+	}
+
+	u := "auth/email/login"
+	req, err := s.client.newRequest(Operation{Service: "Auth", Method: "Login"}, http.MethodPost, u, login)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result *LoginResponse
+	resp, err := s.client.Do(ctx, req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result, resp, nil
+}
+
+// Me retrieves the user associated with the client's current credentials.
+func (s *AuthService) Me(ctx context.Context) (*AuthUser, *Response, error) {
+	u := "auth/email/me"
+	req, err := s.client.newRequest(Operation{Service: "Auth", Method: "Me"}, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var user *AuthUser
+	resp, err := s.client.Do(ctx, req, &user)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return user, resp, nil
+}
+
+// ListTokens retrieves the API tokens owned by the current user.
+// Note: Tokens use limit/offset pagination; the total count is reported in Response.Total.
+func (s *AuthService) ListTokens(ctx context.Context, opts *APITokenListOptions) ([]*APIToken, *Response, error) {
+	u := "tokens"
+	u, err := addOptions(u, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(Operation{Service: "Auth", Method: "ListTokens"}, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result *APITokenListResponse
+	resp, err := s.client.Do(ctx, req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+	if result == nil {
+		return nil, resp, nil
+	}
+	resp.Total = result.Total
+
+	return result.Tokens, resp, nil
+}
+
+// GetToken retrieves details of a specific API token by its ID.
+// The token secret itself is only returned once, by CreateToken.
+func (s *AuthService) GetToken(ctx context.Context, tokenID string) (*APIToken, *Response, error) {
+	u := fmt.Sprintf("tokens/%s", url.PathEscape(tokenID))
+
+	req, err := s.client.newRequest(Operation{Service: "Auth", Method: "GetToken", EntityID: tokenID}, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var token *APIToken
+	resp, err := s.client.Do(ctx, req, &token)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return token, resp, nil
+}
+
+// CreateToken creates a new scoped API token. The secret is returned only in
+// this response, in APITokenCreateResponse.AccessToken.
+// Note: Setting TeamID creates a team-scoped token via /tokens/teams/{team_id}.
+func (s *AuthService) CreateToken(ctx context.Context, token *APITokenCreate) (*APITokenCreateResponse, *Response, error) {
+	if token == nil {
+		return nil, nil, fmt.Errorf("token create request is nil")
+	}
+
+	u := "tokens"
+	if token.TeamID != nil && *token.TeamID != "" {
+		u = fmt.Sprintf("tokens/teams/%s", url.PathEscape(*token.TeamID))
+	}
+
+	req, err := s.client.newRequest(Operation{Service: "Auth", Method: "CreateToken"}, http.MethodPost, u, token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var created *APITokenCreateResponse
+	resp, err := s.client.Do(ctx, req, &created)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return created, resp, nil
+}
+
+// RevokeToken revokes an API token by its ID. The reason parameter is optional;
+// pass nil to revoke without recording a reason.
+func (s *AuthService) RevokeToken(ctx context.Context, tokenID string, reason *string) (*Response, error) {
+	u := fmt.Sprintf("tokens/%s", url.PathEscape(tokenID))
+
+	var body any
+	if reason != nil {
+		body = &APITokenRevokeRequest{Reason: reason}
+	}
+
+	req, err := s.client.newRequest(Operation{Service: "Auth", Method: "RevokeToken", EntityID: tokenID}, http.MethodDelete, u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+	return resp, err
+}
@@ -0,0 +1,257 @@
+package contextforge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestAuthService_Login(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/auth/email/login", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body LoginRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body.Email != "admin@test.local" || body.Password != "secret" {
+			t.Errorf("login body = %+v, want admin@test.local/secret", body)
+		}
+
+		fmt.Fprint(w, `{"access_token":"jwt","token_type":"bearer","expires_in":3600,"user":{"email":"admin@test.local","is_admin":true}}`)
+	})
+
+	got, _, err := client.Auth.Login(context.Background(), &LoginRequest{Email: "admin@test.local", Password: "secret"})
+	if err != nil {
+		t.Fatalf("Auth.Login returned error: %v", err)
+	}
+
+	if got.AccessToken != "jwt" {
+		t.Errorf("AccessToken = %q, want %q", got.AccessToken, "jwt")
+	}
+	if got.ExpiresIn != 3600 {
+		t.Errorf("ExpiresIn = %d, want 3600", got.ExpiresIn)
+	}
+	if got.User == nil || got.User.Email != "admin@test.local" || !got.User.IsAdmin {
+		t.Errorf("User = %+v, want admin@test.local admin", got.User)
+	}
+}
+
+func TestAuthService_Login_NilRequest(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	_, _, err := client.Auth.Login(context.Background(), nil)
+	if err == nil {
+		t.Fatal("Auth.Login expected error for nil request, got nil")
+	}
+}
+
+func TestAuthService_Me(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/auth/email/me", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"email":"user@test.local","full_name":"Test User","is_admin":false,"auth_provider":"local","email_verified":true}`)
+	})
+
+	got, _, err := client.Auth.Me(context.Background())
+	if err != nil {
+		t.Fatalf("Auth.Me returned error: %v", err)
+	}
+
+	if got.Email != "user@test.local" {
+		t.Errorf("Email = %q, want %q", got.Email, "user@test.local")
+	}
+	if StringValue(got.FullName) != "Test User" {
+		t.Errorf("FullName = %q, want %q", StringValue(got.FullName), "Test User")
+	}
+	if got.AuthProvider != "local" || !got.EmailVerified {
+		t.Errorf("Me = %+v, want local provider with verified email", got)
+	}
+}
+
+func TestAuthService_ListTokens(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/tokens", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		q := r.URL.Query()
+		if got := q.Get("include_inactive"); got != "true" {
+			t.Errorf("include_inactive = %q, want %q", got, "true")
+		}
+		if got := q.Get("limit"); got != "10" {
+			t.Errorf("limit = %q, want %q", got, "10")
+		}
+		if got := q.Get("offset"); got != "20" {
+			t.Errorf("offset = %q, want %q", got, "20")
+		}
+		fmt.Fprint(w, `{"tokens":[{"id":"1","name":"ci","is_active":true},{"id":"2","name":"old","is_revoked":true}],"total":22,"limit":10,"offset":20}`)
+	})
+
+	opts := &APITokenListOptions{IncludeInactive: true, Limit: 10, Offset: 20}
+	tokens, resp, err := client.Auth.ListTokens(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Auth.ListTokens returned error: %v", err)
+	}
+
+	if len(tokens) != 2 {
+		t.Fatalf("Auth.ListTokens returned %d tokens, want 2", len(tokens))
+	}
+	if tokens[0].Name != "ci" || !tokens[0].IsActive {
+		t.Errorf("tokens[0] = %+v, want active token named ci", tokens[0])
+	}
+	if !tokens[1].IsRevoked {
+		t.Errorf("tokens[1].IsRevoked = false, want true")
+	}
+	if resp.Total != 22 {
+		t.Errorf("Response.Total = %d, want 22", resp.Total)
+	}
+}
+
+func TestAuthService_GetToken(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/tokens/abc", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"abc","name":"ci","team_id":"team-1","expires_at":"2030-01-01T00:00:00Z"}`)
+	})
+
+	got, _, err := client.Auth.GetToken(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("Auth.GetToken returned error: %v", err)
+	}
+
+	if got.ID != "abc" || StringValue(got.TeamID) != "team-1" {
+		t.Errorf("GetToken = %+v, want id abc in team-1", got)
+	}
+	if got.ExpiresAt == nil || got.ExpiresAt.Year() != 2030 {
+		t.Errorf("ExpiresAt = %v, want 2030-01-01", got.ExpiresAt)
+	}
+}
+
+func TestAuthService_CreateToken(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/tokens", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["name"] != "ci" || body["expires_in_days"] != float64(30) {
+			t.Errorf("request body = %v, want name ci expiring in 30 days", body)
+		}
+		scope, _ := body["scope"].(map[string]any)
+		if scope["server_id"] != "srv-1" {
+			t.Errorf("scope = %v, want server_id srv-1", scope)
+		}
+		if _, ok := body["TeamID"]; ok {
+			t.Error("request body contains TeamID")
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"token":{"id":"1","name":"ci","server_id":"srv-1"},"access_token":"secret-token"}`)
+	})
+
+	create := &APITokenCreate{
+		Name:          "ci",
+		ExpiresInDays: Int(30),
+		Scope: &APITokenScope{
+			ServerID:    String("srv-1"),
+			Permissions: []string{"tools.read"},
+		},
+	}
+	got, _, err := client.Auth.CreateToken(context.Background(), create)
+	if err != nil {
+		t.Fatalf("Auth.CreateToken returned error: %v", err)
+	}
+
+	if got.AccessToken != "secret-token" {
+		t.Errorf("AccessToken = %q, want %q", got.AccessToken, "secret-token")
+	}
+	if got.Token == nil || got.Token.ID != "1" {
+		t.Errorf("Token = %+v, want id 1", got.Token)
+	}
+}
+
+func TestAuthService_CreateToken_Team(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/tokens/teams/team-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"token":{"id":"2","name":"team","team_id":"team-1"},"access_token":"team-token"}`)
+	})
+
+	got, _, err := client.Auth.CreateToken(context.Background(), &APITokenCreate{
+		Name:   "team",
+		TeamID: String("team-1"),
+	})
+	if err != nil {
+		t.Fatalf("Auth.CreateToken returned error: %v", err)
+	}
+
+	if StringValue(got.Token.TeamID) != "team-1" {
+		t.Errorf("Token.TeamID = %q, want %q", StringValue(got.Token.TeamID), "team-1")
+	}
+}
+
+func TestAuthService_CreateToken_NilRequest(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	_, _, err := client.Auth.CreateToken(context.Background(), nil)
+	if err == nil {
+		t.Fatal("Auth.CreateToken expected error for nil request, got nil")
+	}
+}
+
+func TestAuthService_RevokeToken(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/tokens/abc", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		body, _ := io.ReadAll(r.Body)
+		if want := `{"reason":"leaked"}` + "\n"; string(body) != want {
+			t.Errorf("request body = %q, want %q", body, want)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Auth.RevokeToken(context.Background(), "abc", String("leaked"))
+	if err != nil {
+		t.Fatalf("Auth.RevokeToken returned error: %v", err)
+	}
+}
+
+func TestAuthService_RevokeToken_NoReason(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/tokens/abc", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		if r.ContentLength > 0 {
+			t.Errorf("request has a body of %d bytes, want none", r.ContentLength)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Auth.RevokeToken(context.Background(), "abc", nil)
+	if err != nil {
+		t.Fatalf("Auth.RevokeToken returned error: %v", err)
+	}
+}
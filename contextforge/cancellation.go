@@ -14,7 +14,7 @@ func (s *CancellationService) Cancel(ctx context.Context, req *CancellationReque
 	}
 
 	u := "cancellation/cancel"
-	httpReq, err := s.client.NewRequest(http.MethodPost, u, req)
+	httpReq, err := s.client.newRequest(Operation{Service: "Cancellation", Method: "Cancel"}, http.MethodPost, u, req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -31,7 +31,7 @@ func (s *CancellationService) Cancel(ctx context.Context, req *CancellationReque
 // Status retrieves cancellation status for a request ID.
 func (s *CancellationService) Status(ctx context.Context, requestID string) (*CancellationStatus, *Response, error) {
 	u := fmt.Sprintf("cancellation/status/%s", url.PathEscape(requestID))
-	httpReq, err := s.client.NewRequest(http.MethodGet, u, nil)
+	httpReq, err := s.client.newRequest(Operation{Service: "Cancellation", Method: "Status", EntityID: requestID}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -23,7 +23,17 @@ const (
 // If a nil httpClient is provided, a new http.Client will be used. The bearerToken
 // parameter is required for API authentication and should be a valid JWT token.
 // The address parameter must be a valid URL; a trailing slash will be added automatically if missing.
+//
+// NewClient is equivalent to calling NewClientWithOptions with WithHTTPClient
+// and WithBearerToken.
 func NewClient(httpClient *http.Client, address string, bearerToken string) (*Client, error) {
+	return NewClientWithOptions(address, WithHTTPClient(httpClient), WithBearerToken(bearerToken))
+}
+
+// NewClientWithOptions returns a new ContextForge API client with the specified
+// address, configured by opts. Options are applied in order.
+// The address parameter must be a valid URL; a trailing slash will be added automatically if missing.
+func NewClientWithOptions(address string, opts ...Option) (*Client, error) {
 	if !strings.HasSuffix(address, "/") {
 		address = address + "/"
 	}
@@ -33,7 +43,14 @@ func NewClient(httpClient *http.Client, address string, bearerToken string) (*Cl
 		return nil, fmt.Errorf("invalid address; %w", err)
 	}
 
-	return newClient(httpClient, parsedURL, bearerToken), nil
+	c := newClient(nil, parsedURL, "")
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
 }
 
 // newClient initializes a new client with the given parameters.
@@ -49,6 +66,7 @@ func newClient(httpClient *http.Client, address *url.URL, bearerToken string) *C
 		Address:     address,
 		UserAgent:   userAgent,
 		BearerToken: bearerToken,
+		headers:     make(http.Header),
 		rateLimits:  make(map[string]Rate),
 	}
 
@@ -61,6 +79,8 @@ func newClient(httpClient *http.Client, address *url.URL, bearerToken string) *C
 	c.Agents = (*AgentsService)(&c.common)
 	c.Teams = (*TeamsService)(&c.common)
 	c.Cancel = (*CancellationService)(&c.common)
+	c.Auth = (*AuthService)(&c.common)
+	c.Export = (*ExportService)(&c.common)
 
 	return c
 }
@@ -95,6 +115,11 @@ func (c *Client) NewRequest(method, urlStr string, body any) (*http.Request, err
 		return nil, err
 	}
 
+	for key, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
 	if body != nil {
 		req.Header.Set("Content-Type", mediaTypeJSON)
 	}
@@ -106,9 +131,24 @@ func (c *Client) NewRequest(method, urlStr string, body any) (*http.Request, err
 		req.Header.Set("Authorization", "Bearer "+c.BearerToken)
 	}
 
+	// Record the body for the middleware of Do.
+	if body != nil {
+		req = req.WithContext(context.WithValue(req.Context(), bodyKey{}, body))
+	}
+
 	return req, nil
 }
 
+// newRequest creates an API request with NewRequest for the service method
+// identified by op.
+func (c *Client) newRequest(op Operation, method, urlStr string, body any) (*http.Request, error) {
+	req, err := c.NewRequest(method, urlStr, body)
+	if err != nil {
+		return nil, err
+	}
+	return req.WithContext(context.WithValue(req.Context(), operationKey{}, op)), nil
+}
+
 // newResponse creates a new Response for the provided http.Response.
 // The Response is returned along with any error encountered while
 // parsing rate limit headers and pagination cursor.
@@ -147,6 +187,16 @@ func parseCursor(r *http.Response) string {
 // the raw response body will be written to v, without attempting to first
 // decode it.
 //
+// If c.RetryPolicy is set, failed attempts are retried with exponential
+// backoff as described by the policy, and Response.Attempts reports how many
+// attempts were made. If c.RespectRateLimits is set, Do first waits for any
+// exhausted rate limit window on the request path to reset.
+//
+// Requests pass through c.Middleware, if set, before they are sent.
+//
+// Do is safe for concurrent use; requests made from different goroutines
+// are sent in parallel.
+//
 // The provided ctx must be non-nil. If it is canceled or times out,
 // ctx.Err() will be returned.
 func (c *Client) Do(ctx context.Context, req *http.Request, v any) (*Response, error) {
@@ -154,32 +204,48 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v any) (*Response, e
 		return nil, fmt.Errorf("context must be non-nil")
 	}
 
-	req = req.WithContext(ctx)
+	if len(c.Middleware) == 0 {
+		return c.do(ctx, req, v)
+	}
+	call := newCall(req)
+	if call.Operation != (Operation{}) {
+		ctx = context.WithValue(ctx, operationKey{}, call.Operation)
+	}
+	return c.chain(func(ctx context.Context, call *Call, v any) (*Response, error) {
+		return c.do(ctx, call.Request, v)
+	})(ctx, call, v)
+}
+
+// do sends req at the end of the middleware chain of Do.
+func (c *Client) do(ctx context.Context, req *http.Request, v any) (*Response, error) {
+	ctx, done := c.instrument(ctx, req)
+	var response *Response
+	l := &requestLog{start: time.Now()}
+	if c.logBodies() {
+		l.reqBody = requestBody(req)
+	}
+	defer func() {
+		c.logRequest(ctx, req, l)
+		done(response, l.err)
+	}()
 
-	c.clientMu.Lock()
-	resp, err := c.client.Do(req)
-	c.clientMu.Unlock()
+	resp, attempts, err := c.send(ctx, c.client, req)
+	l.attempts, l.err = attempts, err
 	if err != nil {
-		// If we got an error, and the context has been canceled,
-		// the context's error is probably more useful.
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
 		return nil, err
 	}
 	defer resp.Body.Close()
+	l.resp = resp
+	if c.logBodies() {
+		l.respBody = bufferBody(resp)
+	}
 
-	response := newResponse(resp)
-
-	// Store rate limit information
-	c.rateMu.Lock()
-	c.rateLimits[req.URL.Path] = response.Rate
-	c.rateMu.Unlock()
+	response = newResponse(resp)
+	response.Attempts = attempts
 
 	err = CheckResponse(resp)
 	if err != nil {
+		l.err = err
 		return response, err
 	}
 
@@ -193,6 +259,7 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v any) (*Response, e
 			}
 			if decErr != nil {
 				err = decErr
+				l.err = err
 			}
 		}
 	}
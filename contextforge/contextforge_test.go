@@ -2,10 +2,12 @@ package contextforge
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -412,6 +414,105 @@ func TestDo_NilContext(t *testing.T) {
 	}
 }
 
+func TestDo_Concurrent(t *testing.T) {
+	const workers = 8
+
+	// Every request blocks until all workers have reached the server, so the
+	// test only completes if the client sends requests in parallel.
+	var arrived sync.WaitGroup
+	arrived.Add(workers)
+	allArrived := make(chan struct{})
+	go func() {
+		arrived.Wait()
+		close(allArrived)
+	}()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arrived.Done()
+		select {
+		case <-allArrived:
+		case <-time.After(5 * time.Second):
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "50")
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(nil, server.URL+"/", "test-token")
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := c.Tools.Get(context.Background(), fmt.Sprint(i))
+			errs <- err
+			c.RateLimits()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Tools.Get returned error: %v", err)
+		}
+	}
+	if got := len(c.RateLimits()); got != workers {
+		t.Errorf("RateLimits() has %d paths, want %d", got, workers)
+	}
+}
+
+// BenchmarkDo_Concurrent measures request throughput against a server with a
+// fixed per-request latency. Because requests are sent in parallel, ns/op
+// should fall roughly in proportion to the number of concurrent callers.
+func BenchmarkDo_Concurrent(b *testing.B) {
+	const latency = 2 * time.Millisecond
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(latency)
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	for _, callers := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("callers=%d", callers), func(b *testing.B) {
+			httpClient := &http.Client{Transport: &http.Transport{MaxIdleConnsPerHost: callers}}
+			c, err := NewClient(httpClient, server.URL+"/", "test-token")
+			if err != nil {
+				b.Fatalf("NewClient() error: %v", err)
+			}
+
+			var wg sync.WaitGroup
+			work := make(chan struct{})
+			b.ResetTimer()
+			for range callers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range work {
+						if _, _, err := c.Tools.Get(context.Background(), "1"); err != nil {
+							b.Error(err)
+						}
+					}
+				}()
+			}
+			for range b.N {
+				work <- struct{}{}
+			}
+			close(work)
+			wg.Wait()
+		})
+	}
+}
+
 func TestAddOptions(t *testing.T) {
 	opts := &ListOptions{
 		Limit:  10,
@@ -20,6 +20,7 @@
 //   - Cursor-based pagination (Tools, Resources, Gateways, Servers, Prompts, Agents)
 //   - Skip/limit pagination (Teams and legacy agent pagination)
 //   - Rate limit tracking from response headers
+//   - Optional automatic retries with exponential backoff
 //   - Context support for all API calls
 //   - Bearer token (JWT) authentication
 //   - Comprehensive error handling
@@ -34,6 +35,17 @@
 //		log.Fatal(err)
 //	}
 //
+// For long-running processes, use a TokenSource instead of a static token.
+// PasswordTokenSource logs in with an email and password, caches the JWT until
+// shortly before its exp claim, and is refreshed automatically if the API
+// rejects a request with 401 Unauthorized:
+//
+//	ts, err := contextforge.NewPasswordTokenSource(nil, "http://localhost:8000/", "admin@example.com", "password")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	client, err := contextforge.NewClientWithOptions("http://localhost:8000/", contextforge.WithTokenSource(ts))
+//
 // # Usage
 //
 // Import the package:
@@ -64,6 +76,18 @@
 //		log.Fatal(err)
 //	}
 //
+// Use NewClientWithOptions to configure the client with functional options:
+//
+//	client, err := contextforge.NewClientWithOptions("https://contextforge.example.com/",
+//		contextforge.WithBearerToken("your-jwt-token"),
+//		contextforge.WithTimeout(60*time.Second),
+//		contextforge.WithUserAgentSuffix("my-app/1.0"),
+//		contextforge.WithRetryPolicy(contextforge.DefaultRetryPolicy()),
+//	)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
 // List tools:
 //
 //	tools, resp, err := client.Tools.List(context.Background(), nil)
@@ -116,6 +140,13 @@
 //
 //	resp, err := client.Tools.Delete(context.Background(), "tool-id")
 //
+// Invoke a tool through the gateway's JSON-RPC endpoint, checking the
+// arguments against its input schema first:
+//
+//	opts := &contextforge.ToolInvokeOptions{InputSchema: tool.InputSchema}
+//	result, resp, err := client.Tools.Invoke(ctx, tool.Name, args, opts)
+//	fmt.Println(result.Text(), result.IsError)
+//
 // # Pagination
 //
 // The API supports two pagination patterns:
@@ -140,6 +171,21 @@
 //		opts.Cursor = resp.NextCursor
 //	}
 //
+// Each cursor-paginated service also provides an All method returning a
+// range-over-func iterator that follows NextCursor (from either the
+// X-Next-Cursor header or the response envelope) until the results are
+// exhausted. Set MaxItems to cap the number of items yielded:
+//
+//	opts := &contextforge.ToolListOptions{
+//		ListOptions: contextforge.ListOptions{Limit: 50, MaxItems: 500},
+//	}
+//	for tool, err := range client.Tools.All(ctx, opts) {
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		fmt.Println(tool.Name)
+//	}
+//
 // Skip/limit (offset-based) pagination (Teams and legacy agents):
 //
 //	var allAgents []*contextforge.Agent
@@ -160,6 +206,18 @@
 //		}
 //	}
 //
+// Offset-paginated endpoints have iterators too. TeamsService.List reports the
+// total team count in Response.Total, which TeamsService.All uses to know when
+// to stop; TeamsService.DiscoverAll and AgentsService.AllBySkip stop at the
+// first short page:
+//
+//	for team, err := range client.Teams.All(ctx, &contextforge.TeamListOptions{Limit: 100}) {
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		fmt.Println(team.Name)
+//	}
+//
 // # Error Handling
 //
 // The library provides structured error handling with custom error types:
@@ -188,6 +246,68 @@
 //		fmt.Printf("Reset at: %v\n", resp.Rate.Reset)
 //	}
 //
+// The client remembers the last Rate seen for each request path; use
+// Client.RateLimits to inspect it. Set Client.RespectRateLimits to have
+// requests wait (honouring ctx) until an exhausted window resets, and to retry
+// a 429 response once after its Retry-After delay:
+//
+//	client.RespectRateLimits = true
+//
+// # Retries
+//
+// By default every request is sent once. Set Client.RetryPolicy to retry
+// transport errors and selected status codes with jittered exponential
+// backoff. Only idempotent methods are retried unless RetryNonIdempotent is
+// set, and no retry is attempted if it could not start before the ctx
+// deadline:
+//
+//	client.RetryPolicy = contextforge.DefaultRetryPolicy()
+//
+//	tools, resp, err := client.Tools.List(ctx, nil)
+//	if err == nil {
+//		fmt.Printf("Succeeded after %d attempt(s)\n", resp.Attempts)
+//	}
+//
+// # Logging
+//
+// Set Client.Logger to log a record of every request with its method,
+// sanitized URL, status, duration, attempts, and rate limit, and a record of
+// every retry. Client.LogOptions sets the levels of those records and can
+// add request and response bodies, with authentication secrets redacted:
+//
+//	client.Logger = slog.Default()
+//	client.LogOptions = &contextforge.LogOptions{
+//		Level:      slog.LevelInfo,
+//		ErrorLevel: slog.LevelError,
+//		RetryLevel: slog.LevelWarn,
+//	}
+//
+// # Instrumentation
+//
+// Set Client.Instrumentation to observe every API request, for example to
+// trace it; the otelcontextforge package provides an OpenTelemetry
+// implementation. Requests made by service methods carry an Operation, such
+// as Tools.List, in their context, which OperationFromContext returns to
+// instrumentation and to the client's http.RoundTripper. The messages of MCP
+// sessions are instrumented too, with operations such as MCP.tools/call.
+//
+// # Middleware
+//
+// Client.Middleware wraps every API request, including the streamed ones of
+// AgentsService.InvokeStream and MCP sessions. Each Middleware
+// receives a Call with the Operation, the request, and the value encoded as
+// its body, and may modify them, inspect or replace the Response and error,
+// or answer the request itself:
+//
+//	client.Middleware = append(client.Middleware, func(next contextforge.Handler) contextforge.Handler {
+//		return func(ctx context.Context, call *contextforge.Call, v any) (*contextforge.Response, error) {
+//			call.Request.Header.Set("X-Tenant", tenantFor(call.Operation))
+//			resp, err := next(ctx, call, v)
+//			audit(call.Operation, call.Body, resp, err)
+//			return resp, err
+//		}
+//	})
+//
 // # Service Architecture
 //
 // The client follows a service-oriented architecture where different API
@@ -202,12 +322,15 @@
 //	client.Agents     // A2A agent-related operations
 //	client.Teams      // Team-related operations
 //	client.Cancel     // Cancellation operations
+//	client.Auth       // Login, current user, and API token operations
+//	client.Export     // Catalog export and import operations
 //
 // Each service provides methods for different operations. Most services follow
 // a common CRUD pattern:
 //
 //	// Common CRUD methods (most services)
 //	List(ctx, opts) ([]*Type, *Response, error)
+//	All(ctx, opts) iter.Seq2[*Type, error]            // cursor-paginated services
 //	Get(ctx, id) (*Type, *Response, error)
 //	Create(ctx, item, opts) (*Type, *Response, error)  // opts is optional
 //	Update(ctx, id, item) (*Type, *Response, error)
@@ -230,9 +353,39 @@
 //	client.Prompts.Get(ctx, promptID, args)      // Hybrid endpoint with arguments
 //	client.Prompts.GetNoArgs(ctx, promptID)      // Hybrid endpoint without arguments
 //
+//	// ServersService MCP sessions (JSON-RPC over /servers/{id}/mcp)
+//	session, err := client.Servers.Connect(ctx, serverID, nil)
+//	session.ListTools(ctx, cursor)
+//	session.CallTool(ctx, name, args)
+//	session.ReadResource(ctx, uri)
+//	session.GetPrompt(ctx, name, args)
+//
+//	// Legacy SSE transport (GET /servers/{id}/sse, POST /servers/{id}/message)
+//	client.Servers.Connect(ctx, serverID, &contextforge.MCPSessionOptions{
+//		Transport: contextforge.MCPTransportSSE,
+//	})
+//
 //	// AgentsService invocation
 //	client.Agents.Invoke(ctx, agentName, req)  // Uses name, not ID
 //
+// Invoke decodes A2A responses into AgentInvokeResult, whose Task, Message,
+// and Error fields hold the typed payload and whose Raw field holds the
+// response body as received. InvokeInto decodes the response into a caller
+// supplied type instead:
+//
+//	out, _, err := contextforge.InvokeInto[MyResult](ctx, client.Agents, agentName, req)
+//
+// InvokeStream streams a long-running invocation as server-sent events.
+// Canceling ctx ends the stream and cancels the run server-side through the
+// cancellation service, using the stream's RequestID:
+//
+//	stream, _, err := client.Agents.InvokeStream(ctx, agentName, req)
+//	for event, err := range stream.Events() {
+//		if event.StatusUpdate != nil && event.StatusUpdate.Final {
+//			// ...
+//		}
+//	}
+//
 // # Helper Functions
 //
 // The package provides helper functions for working with pointer types,
@@ -6,6 +6,9 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"regexp"
+
+	"github.com/leefowlercu/go-contextforge/jsonschema"
 )
 
 // ErrorResponse represents an error response from the ContextForge API.
@@ -26,18 +29,18 @@ type Error struct {
 func (r *ErrorResponse) Error() string {
 	if r.Message != "" {
 		return fmt.Sprintf("%v %v; %d %v",
-			r.Response.Request.Method, sanitizeURL(r.Response.Request.URL),
+			r.Response.Request.Method, SanitizeURL(r.Response.Request.URL),
 			r.Response.StatusCode, r.Message)
 	}
 
 	if len(r.Errors) > 0 {
 		return fmt.Sprintf("%v %v; %d %+v",
-			r.Response.Request.Method, sanitizeURL(r.Response.Request.URL),
+			r.Response.Request.Method, SanitizeURL(r.Response.Request.URL),
 			r.Response.StatusCode, r.Errors)
 	}
 
 	return fmt.Sprintf("%v %v; %d",
-		r.Response.Request.Method, sanitizeURL(r.Response.Request.URL),
+		r.Response.Request.Method, SanitizeURL(r.Response.Request.URL),
 		r.Response.StatusCode)
 }
 
@@ -50,7 +53,7 @@ type RateLimitError struct {
 
 func (r *RateLimitError) Error() string {
 	return fmt.Sprintf("%v %v; %d %v (rate limit; %d/%d, reset at %v)",
-		r.Response.Request.Method, sanitizeURL(r.Response.Request.URL),
+		r.Response.Request.Method, SanitizeURL(r.Response.Request.URL),
 		r.Response.StatusCode, r.Message,
 		r.Rate.Remaining, r.Rate.Limit, r.Rate.Reset)
 }
@@ -66,7 +69,24 @@ func (r *RateLimitError) Is(target error) bool {
 		r.Message == v.Message &&
 		r.Response.StatusCode == v.Response.StatusCode &&
 		r.Response.Request.Method == v.Response.Request.Method &&
-		sanitizeURL(r.Response.Request.URL) == sanitizeURL(v.Response.Request.URL)
+		SanitizeURL(r.Response.Request.URL) == SanitizeURL(v.Response.Request.URL)
+}
+
+// ToolArgumentsError reports tool arguments that do not satisfy the tool's
+// input schema. It is returned by ToolsService.Invoke before any request is
+// sent.
+type ToolArgumentsError struct {
+	Tool   string                      // name of the tool being invoked
+	Errors jsonschema.ValidationErrors // one entry per problem, with the argument's path
+}
+
+func (e *ToolArgumentsError) Error() string {
+	return fmt.Sprintf("invalid arguments for tool %q: %v", e.Tool, e.Errors)
+}
+
+// Unwrap returns the underlying jsonschema.ValidationErrors.
+func (e *ToolArgumentsError) Unwrap() error {
+	return e.Errors
 }
 
 // CheckResponse checks the API response for errors, and returns them if present.
@@ -99,8 +119,15 @@ func CheckResponse(r *http.Response) error {
 	return errorResponse
 }
 
-// sanitizeURL redacts any authentication tokens from the URL.
-func sanitizeURL(u *url.URL) *url.URL {
+// invitationTokenPath matches the path of TeamsService.AcceptInvitation,
+// whose third segment is the invitation token.
+var invitationTokenPath = regexp.MustCompile(`(/teams/invitations/)[^/]+(/accept/?)$`)
+
+// SanitizeURL returns a copy of u with the secrets it may hold redacted: the
+// user information, and the invitation token in the path of
+// TeamsService.AcceptInvitation. Error messages, logs, and the
+// otelcontextforge spans show request URLs sanitized with it.
+func SanitizeURL(u *url.URL) *url.URL {
 	if u == nil {
 		return nil
 	}
@@ -108,5 +135,10 @@ func sanitizeURL(u *url.URL) *url.URL {
 	if u2.User != nil {
 		u2.User = url.UserPassword("REDACTED", "REDACTED")
 	}
+	// Match the escaped path, in which a token holding "/" is one segment.
+	if p := u2.EscapedPath(); invitationTokenPath.MatchString(p) {
+		u2.RawPath = invitationTokenPath.ReplaceAllString(p, "${1}REDACTED${2}")
+		u2.Path, _ = url.PathUnescape(u2.RawPath)
+	}
 	return &u2
 }
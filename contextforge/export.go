@@ -0,0 +1,118 @@
+package contextforge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+)
+
+// Export retrieves the gateway catalog as an ExportDocument. Without options,
+// every active entity is exported.
+func (s *ExportService) Export(ctx context.Context, opts *ExportOptions) (*ExportDocument, *Response, error) {
+	u := "export"
+	u, err := addOptions(u, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(Operation{Service: "Export", Method: "Export"}, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var doc *ExportDocument
+	resp, err := s.client.Do(ctx, req, &doc)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return doc, resp, nil
+}
+
+// Import imports the entities of doc, typically read by Export from another
+// gateway. opts.ConflictStrategy selects how entities that already exist are
+// handled. Entities that fail to import are reported in the result's
+// Entities and Errors rather than as an error. With opts.DryRun, nothing is
+// modified and the result reports what would have changed.
+func (s *ExportService) Import(ctx context.Context, doc *ExportDocument, opts *ImportOptions) (*ImportResult, *Response, error) {
+	if doc == nil {
+		return nil, nil, fmt.Errorf("export document is nil")
+	}
+
+	body := &importRequest{ImportData: doc}
+	if opts != nil {
+		body.ConflictStrategy = opts.ConflictStrategy
+		body.DryRun = opts.DryRun
+		body.RekeySecret = opts.RekeySecret
+		body.SelectedEntities = opts.SelectedEntities
+	}
+
+	req, err := s.client.newRequest(Operation{Service: "Export", Method: "Import"}, http.MethodPost, "import", body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result *ImportResult
+	resp, err := s.client.Do(ctx, req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+	if result != nil && body.DryRun {
+		result.DryRun = true
+	}
+
+	return result, resp, nil
+}
+
+// ImportStatus retrieves the status of an import by its ID.
+func (s *ExportService) ImportStatus(ctx context.Context, importID string) (*ImportResult, *Response, error) {
+	u := fmt.Sprintf("import/status/%s", url.PathEscape(importID))
+	req, err := s.client.newRequest(Operation{Service: "Export", Method: "ImportStatus", EntityID: importID}, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result *ImportResult
+	resp, err := s.client.Do(ctx, req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result, resp, nil
+}
+
+// Messages in which the server names skipped and failed entities, such as
+// "Skipped gateway weather: already exists" and "Failed to import tool
+// search: invalid schema".
+var (
+	skippedMessage = regexp.MustCompile(`^Skipped (\S+) (.+?)(?:: (.+))?$`)
+	failedMessage  = regexp.MustCompile(`^Failed to import (\S+) (.+?)(?:: (.+))?$`)
+)
+
+// UnmarshalJSON implements json.Unmarshaler. Entities is decoded from the
+// per-entity results of the server; only when there are none is it parsed
+// from the skipped and failed entities named in Warnings and Errors.
+func (r *ImportResult) UnmarshalJSON(data []byte) error {
+	type plain ImportResult
+	if err := json.Unmarshal(data, (*plain)(r)); err != nil {
+		return err
+	}
+	if r.Entities != nil {
+		return nil
+	}
+
+	for _, msg := range r.Warnings {
+		if m := skippedMessage.FindStringSubmatch(msg); m != nil {
+			r.Entities = append(r.Entities, ImportEntityResult{EntityType: m[1], Name: m[2], Action: ImportSkipped, Error: m[3]})
+		}
+	}
+	for _, msg := range r.Errors {
+		if m := failedMessage.FindStringSubmatch(msg); m != nil {
+			r.Entities = append(r.Entities, ImportEntityResult{EntityType: m[1], Name: m[2], Action: ImportFailed, Error: m[3]})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,295 @@
+package contextforge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const testExportDocument = `{
+	"version": "2025-03-26",
+	"exported_at": "2025-01-02T03:04:05Z",
+	"exported_by": "admin@example.com",
+	"source_gateway": "http://gateway.internal:4444",
+	"encryption_method": "AES-256-GCM",
+	"entities": {
+		"tools": [{"name": "search", "url": "http://search.internal", "integration_type": "REST", "tags": ["docs"], "is_active": true,
+			"auth_type": "bearer", "auth_value": "gAAAA-encrypted"}],
+		"gateways": [{"name": "weather", "url": "http://weather.internal/mcp", "transport": "SSE", "is_active": false}],
+		"roots": [{"uri": "file:///data", "name": null}]
+	},
+	"metadata": {"entity_counts": {"tools": 1, "gateways": 1, "roots": 1}, "export_options": {"include_inactive": true}}
+}`
+
+func TestExportService_Export(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/export", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		want := "exclude_types=roots&include_dependencies=false&include_inactive=true&tags=docs%2Cprod&types=tools%2Cgateways"
+		if got := r.URL.RawQuery; got != want {
+			t.Errorf("query = %q, want %q", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, testExportDocument)
+	})
+
+	opts := &ExportOptions{
+		Types:               []string{ExportTypeTools, ExportTypeGateways},
+		ExcludeTypes:        []string{ExportTypeRoots},
+		Tags:                "docs,prod",
+		IncludeInactive:     true,
+		IncludeDependencies: Bool(false),
+	}
+	doc, _, err := client.Export.Export(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Export returned error: %v", err)
+	}
+
+	if doc.Version != "2025-03-26" || doc.ExportedBy != "admin@example.com" {
+		t.Errorf("Export version, exported_by = %q, %q", doc.Version, doc.ExportedBy)
+	}
+	if doc.ExportedAt == nil || doc.ExportedAt.Year() != 2025 {
+		t.Errorf("Export exported_at = %v, want 2025-01-02", doc.ExportedAt)
+	}
+	if len(doc.Entities.Tools) != 1 || len(doc.Entities.Gateways) != 1 || len(doc.Entities.Roots) != 1 {
+		t.Fatalf("Export entities = %+v, want 1 tool, 1 gateway, 1 root", doc.Entities)
+	}
+	if got := doc.Metadata.EntityCounts["tools"]; got != 1 {
+		t.Errorf("Export entity_counts[tools] = %d, want 1", got)
+	}
+	if got := doc.Entities.Tools[0]["auth_value"]; got != "gAAAA-encrypted" {
+		t.Errorf("Export tool auth_value = %v, want it kept", got)
+	}
+}
+
+func TestExportService_Import(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var doc *ExportDocument
+	if err := json.Unmarshal([]byte(testExportDocument), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	mux.HandleFunc("/import", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if body["conflict_strategy"] != "rename" || body["dry_run"] != true || body["rekey_secret"] != "new-secret" {
+			t.Errorf("Import options = %v, %v, %v", body["conflict_strategy"], body["dry_run"], body["rekey_secret"])
+		}
+		if want := map[string]any{"tools": []any{"search"}}; !reflect.DeepEqual(body["selected_entities"], want) {
+			t.Errorf("Import selected_entities = %v, want %v", body["selected_entities"], want)
+		}
+
+		// The document is sent as exported, including fields the SDK does
+		// not model.
+		var want map[string]any
+		if err := json.Unmarshal([]byte(testExportDocument), &want); err != nil {
+			t.Fatal(err)
+		}
+		if got := body["import_data"].(map[string]any)["entities"]; !reflect.DeepEqual(got, want["entities"]) {
+			t.Errorf("Import entities = %v, want %v", got, want["entities"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"import_id":"imp-1","status":"completed",
+			"progress":{"total":3,"processed":3,"created":1,"updated":0,"skipped":1,"failed":1},
+			"warnings":["Skipped gateway weather: already exists"],"errors":["Failed to import root file:///data"],
+			"started_at":"2025-01-02T03:04:05Z","completed_at":"2025-01-02T03:04:06Z"}`)
+	})
+
+	result, _, err := client.Export.Import(context.Background(), doc, &ImportOptions{
+		ConflictStrategy: ConflictRename,
+		DryRun:           true,
+		RekeySecret:      String("new-secret"),
+		SelectedEntities: map[string][]string{"tools": {"search"}},
+	})
+	if err != nil {
+		t.Fatalf("Import returned error: %v", err)
+	}
+
+	want := &ImportProgress{Total: 3, Processed: 3, Created: 1, Skipped: 1, Failed: 1}
+	if !reflect.DeepEqual(result.Progress, want) {
+		t.Errorf("Import progress = %+v, want %+v", result.Progress, want)
+	}
+	if result.ImportID != "imp-1" || len(result.Warnings) != 1 || len(result.Errors) != 1 {
+		t.Errorf("Import result = %+v", result)
+	}
+	if !result.DryRun {
+		t.Error("Import result DryRun = false, want true")
+	}
+	wantEntities := []ImportEntityResult{
+		{EntityType: "gateway", Name: "weather", Action: ImportSkipped, Error: "already exists"},
+		{EntityType: "root", Name: "file:///data", Action: ImportFailed},
+	}
+	if !reflect.DeepEqual(result.Entities, wantEntities) {
+		t.Errorf("Import entities = %+v, want %+v", result.Entities, wantEntities)
+	}
+}
+
+func TestExportService_Import_Entities(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/import", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"import_id":"imp-3","status":"completed",
+			"progress":{"total":4,"processed":4,"created":1,"updated":1,"skipped":0,"failed":1},
+			"errors":["Failed to import prompt greet: invalid template"],
+			"entities":[
+				{"entity_type":"tool","name":"search","id":"t1","action":"created"},
+				{"entity_type":"gateway","name":"weather","id":"g1","action":"updated"},
+				{"entity_type":"server","name":"ops","id":"s2","action":"renamed","new_name":"ops-1"},
+				{"entity_type":"prompt","name":"greet","action":"failed","error":"invalid template"}
+			]}`)
+	})
+
+	for _, dryRun := range []bool{false, true} {
+		result, _, err := client.Export.Import(context.Background(), &ExportDocument{}, &ImportOptions{
+			ConflictStrategy: ConflictRename,
+			DryRun:           dryRun,
+		})
+		if err != nil {
+			t.Fatalf("Import returned error: %v", err)
+		}
+		if result.DryRun != dryRun {
+			t.Errorf("Import result DryRun = %t, want %t", result.DryRun, dryRun)
+		}
+
+		want := []ImportEntityResult{
+			{EntityType: "tool", Name: "search", ID: "t1", Action: ImportCreated},
+			{EntityType: "gateway", Name: "weather", ID: "g1", Action: ImportUpdated},
+			{EntityType: "server", Name: "ops", ID: "s2", Action: ImportRenamed, NewName: "ops-1"},
+			{EntityType: "prompt", Name: "greet", Action: ImportFailed, Error: "invalid template"},
+		}
+		if !reflect.DeepEqual(result.Entities, want) {
+			t.Errorf("Import entities = %+v, want %+v", result.Entities, want)
+		}
+	}
+}
+
+func TestImportResult_EntitiesFromMessages(t *testing.T) {
+	tests := []struct {
+		name     string
+		warnings []string
+		errors   []string
+		want     []ImportEntityResult
+	}{
+		{"none", nil, nil, nil},
+		{
+			"skipped and failed",
+			[]string{"Skipped tool search: already exists", "Rekeyed 2 secrets"},
+			[]string{"Failed to import tool fetch: name too long", "Database unavailable"},
+			[]ImportEntityResult{
+				{EntityType: "tool", Name: "search", Action: ImportSkipped, Error: "already exists"},
+				{EntityType: "tool", Name: "fetch", Action: ImportFailed, Error: "name too long"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _ := json.Marshal(map[string]any{"import_id": "i", "warnings": tt.warnings, "errors": tt.errors})
+			var result ImportResult
+			if err := json.Unmarshal(data, &result); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result.Entities, tt.want) {
+				t.Errorf("Entities = %+v, want %+v", result.Entities, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportService_Import_DefaultOptions(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/import", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		for _, key := range []string{"conflict_strategy", "rekey_secret", "selected_entities"} {
+			if _, ok := body[key]; ok {
+				t.Errorf("Import sent %s without options", key)
+			}
+		}
+		if body["dry_run"] != false {
+			t.Errorf("Import dry_run = %v, want false", body["dry_run"])
+		}
+		fmt.Fprint(w, `{"import_id":"imp-2","status":"completed"}`)
+	})
+
+	if _, _, err := client.Export.Import(context.Background(), &ExportDocument{Version: "2025-03-26"}, nil); err != nil {
+		t.Fatalf("Import returned error: %v", err)
+	}
+}
+
+func TestExportService_Import_NilDocument(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	if _, _, err := client.Export.Import(context.Background(), nil, nil); err == nil {
+		t.Fatal("Import expected error for nil document, got nil")
+	}
+}
+
+func TestExportService_ImportStatus(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/import/status/imp-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"import_id":"imp-1","status":"running","progress":{"total":10,"processed":4}}`)
+	})
+
+	result, _, err := client.Export.ImportStatus(context.Background(), "imp-1")
+	if err != nil {
+		t.Fatalf("ImportStatus returned error: %v", err)
+	}
+	if result.Status != "running" || result.Progress.Processed != 4 {
+		t.Errorf("ImportStatus = %+v, want running with 4 processed", result)
+	}
+}
+
+func TestExportEntity(t *testing.T) {
+	tests := []struct {
+		name       string
+		entity     string
+		wantName   string
+		wantTags   []string
+		wantActive bool
+	}{
+		{"tool", `{"name":"search","tags":["docs","web"],"is_active":true}`, "search", []string{"docs", "web"}, true},
+		{"inactive", `{"name":"fetch","is_active":false}`, "fetch", []string{}, false},
+		{"tag objects", `{"name":"greet","tags":[{"id":"docs","label":"Docs"}]}`, "greet", []string{"docs"}, true},
+		{"root", `{"uri":"file:///data","name":null}`, "file:///data", []string{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e ExportEntity
+			if err := json.Unmarshal([]byte(tt.entity), &e); err != nil {
+				t.Fatal(err)
+			}
+			if got := e.Name(); got != tt.wantName {
+				t.Errorf("Name() = %q, want %q", got, tt.wantName)
+			}
+			if got := e.Tags(); !reflect.DeepEqual(got, tt.wantTags) {
+				t.Errorf("Tags() = %v, want %v", got, tt.wantTags)
+			}
+			if got := e.IsActive(); got != tt.wantActive {
+				t.Errorf("IsActive() = %v, want %v", got, tt.wantActive)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -28,7 +29,7 @@ func (s *GatewaysService) List(ctx context.Context, opts *GatewayListOptions) ([
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Gateways", Method: "List"}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -50,11 +51,27 @@ func (s *GatewaysService) List(ctx context.Context, opts *GatewayListOptions) ([
 	return gateways, resp, nil
 }
 
+// All returns an iterator over every gateway matching opts, following
+// pagination cursors until the result set is exhausted or opts.MaxItems
+// gateways have been yielded. Iteration stops with ctx.Err() if ctx is done.
+func (s *GatewaysService) All(ctx context.Context, opts *GatewayListOptions) iter.Seq2[*Gateway, error] {
+	reqOpts := &GatewayListOptions{}
+	if opts != nil {
+		*reqOpts = *opts
+	}
+
+	return allByCursor(ctx, reqOpts.Cursor, reqOpts.MaxItems, func(ctx context.Context, cursor string) ([]*Gateway, *Response, error) {
+		pageOpts := *reqOpts
+		pageOpts.Cursor = cursor
+		return s.List(ctx, &pageOpts)
+	})
+}
+
 // Get retrieves a specific gateway by its ID.
 func (s *GatewaysService) Get(ctx context.Context, gatewayID string) (*Gateway, *Response, error) {
 	u := fmt.Sprintf("gateways/%s", url.PathEscape(gatewayID))
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Gateways", Method: "Get", EntityID: gatewayID}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -95,7 +112,7 @@ func (s *GatewaysService) Create(ctx context.Context, gateway *Gateway, opts *Ga
 		}
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, gatewayMap)
+	req, err := s.client.newRequest(Operation{Service: "Gateways", Method: "Create"}, http.MethodPost, u, gatewayMap)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -113,7 +130,7 @@ func (s *GatewaysService) Create(ctx context.Context, gateway *Gateway, opts *Ga
 func (s *GatewaysService) Update(ctx context.Context, gatewayID string, gateway *Gateway) (*Gateway, *Response, error) {
 	u := fmt.Sprintf("gateways/%s", url.PathEscape(gatewayID))
 
-	req, err := s.client.NewRequest(http.MethodPut, u, gateway)
+	req, err := s.client.newRequest(Operation{Service: "Gateways", Method: "Update", EntityID: gatewayID}, http.MethodPut, u, gateway)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -131,7 +148,7 @@ func (s *GatewaysService) Update(ctx context.Context, gatewayID string, gateway
 func (s *GatewaysService) Delete(ctx context.Context, gatewayID string) (*Response, error) {
 	u := fmt.Sprintf("gateways/%s", url.PathEscape(gatewayID))
 
-	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Gateways", Method: "Delete", EntityID: gatewayID}, http.MethodDelete, u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -146,18 +163,18 @@ func (s *GatewaysService) Delete(ctx context.Context, gatewayID string) (*Respon
 
 // SetState sets a gateway's enabled status using the preferred /state endpoint.
 func (s *GatewaysService) SetState(ctx context.Context, gatewayID string, activate bool) (*Gateway, *Response, error) {
-	return s.setState(ctx, gatewayID, activate, "state")
+	return s.setState(ctx, "SetState", gatewayID, activate, "state")
 }
 
 // Toggle toggles a gateway's enabled status using the legacy /toggle endpoint.
 func (s *GatewaysService) Toggle(ctx context.Context, gatewayID string, activate bool) (*Gateway, *Response, error) {
-	return s.setState(ctx, gatewayID, activate, "toggle")
+	return s.setState(ctx, "Toggle", gatewayID, activate, "toggle")
 }
 
-func (s *GatewaysService) setState(ctx context.Context, gatewayID string, activate bool, endpoint string) (*Gateway, *Response, error) {
+func (s *GatewaysService) setState(ctx context.Context, method, gatewayID string, activate bool, endpoint string) (*Gateway, *Response, error) {
 	u := fmt.Sprintf("gateways/%s/%s?activate=%t", url.PathEscape(gatewayID), endpoint, activate)
 
-	req, err := s.client.NewRequest(http.MethodPost, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Gateways", Method: method, EntityID: gatewayID}, http.MethodPost, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -188,7 +205,7 @@ func (s *GatewaysService) RefreshTools(ctx context.Context, gatewayID string, op
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Gateways", Method: "RefreshTools", EntityID: gatewayID}, http.MethodPost, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
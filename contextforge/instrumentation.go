@@ -0,0 +1,68 @@
+package contextforge
+
+import (
+	"context"
+	"net/http"
+)
+
+// Operation identifies the service method, such as ToolsService.List, that
+// an API request is made for. The requests of an MCPSession have the
+// Service "MCP" and the JSON-RPC method of the message they send, such as
+// "tools/call", as their Method.
+type Operation struct {
+	// Service is the name of the service without its "Service" suffix, for
+	// example "Tools".
+	Service string
+
+	// Method is the name of the service method, for example "List".
+	Method string
+
+	// EntityID is the ID or name of the entity the request addresses, for
+	// example the tool ID passed to ToolsService.Get. It is empty for requests
+	// to collections, and for requests that address an entity by a secret,
+	// such as TeamsService.AcceptInvitation.
+	EntityID string
+}
+
+// String returns the name of the operation in the form "Tools.List".
+func (op Operation) String() string {
+	return op.Service + "." + op.Method
+}
+
+// operationKey is the context key under which the Operation of a request is
+// stored.
+type operationKey struct{}
+
+// OperationFromContext returns the Operation of the API request whose
+// context is ctx. Client.Do adds it to the context of every request made by
+// a service method, so it is available to instrumentation and to the
+// http.RoundTripper of the client.
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	op, ok := ctx.Value(operationKey{}).(Operation)
+	return op, ok
+}
+
+// Instrumentation observes the API requests of a Client, including streamed
+// ones and the messages of MCP sessions, for example to trace them. The otelcontextforge package implements it with
+// OpenTelemetry.
+type Instrumentation interface {
+	// Start is called before req is sent, with a context carrying its
+	// Operation, if any. Start may add headers to req. The request is sent
+	// with the returned context, and the returned function is called once
+	// with the outcome of the request; resp is nil if no response was
+	// received.
+	Start(ctx context.Context, req *http.Request) (context.Context, func(resp *Response, err error))
+}
+
+// instrument prepares ctx for sending req: it adds the Operation recorded by
+// NewRequest and starts c.Instrumentation, if set. The returned function
+// must be called with the outcome of the request.
+func (c *Client) instrument(ctx context.Context, req *http.Request) (context.Context, func(*Response, error)) {
+	if op, ok := OperationFromContext(req.Context()); ok {
+		ctx = context.WithValue(ctx, operationKey{}, op)
+	}
+	if c.Instrumentation == nil {
+		return ctx, func(*Response, error) {}
+	}
+	return c.Instrumentation.Start(ctx, req)
+}
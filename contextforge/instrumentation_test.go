@@ -0,0 +1,134 @@
+package contextforge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// recordingInstrumentation records the requests it observes.
+type recordingInstrumentation struct {
+	ops      []Operation
+	statuses []int
+	errs     []error
+}
+
+type instrumentationKey struct{}
+
+func (ri *recordingInstrumentation) Start(ctx context.Context, req *http.Request) (context.Context, func(*Response, error)) {
+	op, _ := OperationFromContext(ctx)
+	ri.ops = append(ri.ops, op)
+	req.Header.Set("X-Instrumented", op.String())
+	return context.WithValue(ctx, instrumentationKey{}, true), func(resp *Response, err error) {
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		ri.statuses = append(ri.statuses, status)
+		ri.errs = append(ri.errs, err)
+	}
+}
+
+func TestDo_Instrumentation(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	ri := &recordingInstrumentation{}
+	client.Instrumentation = ri
+
+	var headers []string
+	mux.HandleFunc("/tools", func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Get("X-Instrumented"))
+		fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("/tools/t%2F1/toggle", func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Get("X-Instrumented"))
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	var sawOperation, sawContext bool
+	base := client.client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	client.client.Transport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		_, sawOperation = OperationFromContext(r.Context())
+		sawContext, _ = r.Context().Value(instrumentationKey{}).(bool)
+		return base.RoundTrip(r)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Tools.List(ctx, nil); err != nil {
+		t.Fatalf("Tools.List returned error: %v", err)
+	}
+	if !sawOperation || !sawContext {
+		t.Errorf("transport saw operation %t and instrumentation context %t, want both", sawOperation, sawContext)
+	}
+	if _, _, err := client.Tools.Toggle(ctx, "t/1", true); err == nil {
+		t.Fatal("Tools.Toggle returned no error")
+	}
+
+	wantOps := []Operation{
+		{Service: "Tools", Method: "List"},
+		{Service: "Tools", Method: "Toggle", EntityID: "t/1"},
+	}
+	if fmt.Sprint(ri.ops) != fmt.Sprint(wantOps) {
+		t.Errorf("operations = %+v, want %+v", ri.ops, wantOps)
+	}
+	if fmt.Sprint(headers) != "[Tools.List Tools.Toggle]" {
+		t.Errorf("server saw headers %v, want those set by Start", headers)
+	}
+	if fmt.Sprint(ri.statuses) != "[200 404]" {
+		t.Errorf("statuses = %v, want [200 404]", ri.statuses)
+	}
+	if ri.errs[0] != nil || ri.errs[1] == nil {
+		t.Errorf("errors = %v, want nil then an error", ri.errs)
+	}
+}
+
+func TestNewRequest_Operation(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	req, err := client.NewRequest(http.MethodGet, "tools", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op, ok := OperationFromContext(req.Context()); ok {
+		t.Errorf("request built outside a service method has operation %v", op)
+	}
+}
+
+func TestServices_Operation(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	ri := &recordingInstrumentation{}
+	client.Instrumentation = ri
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `null`)
+	})
+
+	// The results do not matter, only the operations of the requests.
+	ctx := context.Background()
+	client.Tools.Get(ctx, "t/1")
+	client.Tools.SetState(ctx, "t1", true)
+	client.Agents.Invoke(ctx, "my agent", nil)
+	InvokeInto[map[string]any](ctx, client.Agents, "my agent", nil)
+	client.Teams.ApproveJoinRequest(ctx, "team-1", "req-1")
+	client.Teams.AcceptInvitation(ctx, "invitation-secret")
+	client.Resources.ListTemplates(ctx)
+
+	want := []Operation{
+		{Service: "Tools", Method: "Get", EntityID: "t/1"},
+		{Service: "Tools", Method: "SetState", EntityID: "t1"},
+		{Service: "Agents", Method: "Invoke", EntityID: "my agent"},
+		{Service: "Agents", Method: "InvokeInto", EntityID: "my agent"},
+		{Service: "Teams", Method: "ApproveJoinRequest", EntityID: "team-1"},
+		{Service: "Teams", Method: "AcceptInvitation"},
+		{Service: "Resources", Method: "ListTemplates"},
+	}
+	if fmt.Sprint(ri.ops) != fmt.Sprint(want) {
+		t.Errorf("operations = %+v, want %+v", ri.ops, want)
+	}
+}
@@ -0,0 +1,164 @@
+package contextforge
+
+import (
+	"context"
+	"iter"
+)
+
+// The interfaces below describe the methods of each service, so code that
+// uses a service can accept a substitute for it in tests. The services of a
+// Client satisfy them:
+//
+//	type Syncer struct {
+//		Tools contextforge.ToolsAPI
+//	}
+//
+//	s := &Syncer{Tools: client.Tools}
+//
+// The contextforgemock package provides mock implementations that record
+// their calls and return canned responses. NewAgentStream and NewMCPSession
+// create the values returned by AgentsAPI.InvokeStream and ServersAPI.Connect.
+
+// ToolsAPI is the interface implemented by ToolsService.
+type ToolsAPI interface {
+	List(ctx context.Context, opts *ToolListOptions) ([]*Tool, *Response, error)
+	All(ctx context.Context, opts *ToolListOptions) iter.Seq2[*Tool, error]
+	Get(ctx context.Context, toolID string) (*Tool, *Response, error)
+	Create(ctx context.Context, tool *Tool, opts *ToolCreateOptions) (*Tool, *Response, error)
+	Update(ctx context.Context, toolID string, tool *Tool) (*Tool, *Response, error)
+	Delete(ctx context.Context, toolID string) (*Response, error)
+	SetState(ctx context.Context, toolID string, activate bool) (*Tool, *Response, error)
+	Toggle(ctx context.Context, toolID string, activate bool) (*Tool, *Response, error)
+	Invoke(ctx context.Context, name string, args map[string]any, opts *ToolInvokeOptions) (*ToolCallResult, *Response, error)
+}
+
+// GatewaysAPI is the interface implemented by GatewaysService.
+type GatewaysAPI interface {
+	List(ctx context.Context, opts *GatewayListOptions) ([]*Gateway, *Response, error)
+	All(ctx context.Context, opts *GatewayListOptions) iter.Seq2[*Gateway, error]
+	Get(ctx context.Context, gatewayID string) (*Gateway, *Response, error)
+	Create(ctx context.Context, gateway *Gateway, opts *GatewayCreateOptions) (*Gateway, *Response, error)
+	Update(ctx context.Context, gatewayID string, gateway *Gateway) (*Gateway, *Response, error)
+	Delete(ctx context.Context, gatewayID string) (*Response, error)
+	SetState(ctx context.Context, gatewayID string, activate bool) (*Gateway, *Response, error)
+	Toggle(ctx context.Context, gatewayID string, activate bool) (*Gateway, *Response, error)
+	RefreshTools(ctx context.Context, gatewayID string, opts *GatewayRefreshOptions) (*GatewayRefreshResponse, *Response, error)
+}
+
+// ServersAPI is the interface implemented by ServersService.
+type ServersAPI interface {
+	List(ctx context.Context, opts *ServerListOptions) ([]*Server, *Response, error)
+	All(ctx context.Context, opts *ServerListOptions) iter.Seq2[*Server, error]
+	Get(ctx context.Context, serverID string) (*Server, *Response, error)
+	Create(ctx context.Context, server *ServerCreate, opts *ServerCreateOptions) (*Server, *Response, error)
+	Update(ctx context.Context, serverID string, server *ServerUpdate) (*Server, *Response, error)
+	Delete(ctx context.Context, serverID string) (*Response, error)
+	SetState(ctx context.Context, serverID string, activate bool) (*Server, *Response, error)
+	Toggle(ctx context.Context, serverID string, activate bool) (*Server, *Response, error)
+	ListTools(ctx context.Context, serverID string, opts *ServerAssociationOptions) ([]*Tool, *Response, error)
+	ListResources(ctx context.Context, serverID string, opts *ServerAssociationOptions) ([]*Resource, *Response, error)
+	ListPrompts(ctx context.Context, serverID string, opts *ServerAssociationOptions) ([]*Prompt, *Response, error)
+	Connect(ctx context.Context, serverID string, opts *MCPSessionOptions) (*MCPSession, error)
+}
+
+// PromptsAPI is the interface implemented by PromptsService.
+type PromptsAPI interface {
+	List(ctx context.Context, opts *PromptListOptions) ([]*Prompt, *Response, error)
+	All(ctx context.Context, opts *PromptListOptions) iter.Seq2[*Prompt, error]
+	Get(ctx context.Context, promptID string, args map[string]string) (*PromptResult, *Response, error)
+	GetNoArgs(ctx context.Context, promptID string) (*PromptResult, *Response, error)
+	Create(ctx context.Context, prompt *PromptCreate, opts *PromptCreateOptions) (*Prompt, *Response, error)
+	Update(ctx context.Context, promptID string, prompt *PromptUpdate) (*Prompt, *Response, error)
+	Delete(ctx context.Context, promptID string) (*Response, error)
+	SetState(ctx context.Context, promptID string, activate bool) (*Prompt, *Response, error)
+	Toggle(ctx context.Context, promptID string, activate bool) (*Prompt, *Response, error)
+}
+
+// ResourcesAPI is the interface implemented by ResourcesService.
+type ResourcesAPI interface {
+	List(ctx context.Context, opts *ResourceListOptions) ([]*Resource, *Response, error)
+	All(ctx context.Context, opts *ResourceListOptions) iter.Seq2[*Resource, error]
+	Get(ctx context.Context, resourceID string) (*ResourceContent, *Response, error)
+	GetInfo(ctx context.Context, resourceID string, opts *ResourceInfoOptions) (*Resource, *Response, error)
+	Create(ctx context.Context, resource *ResourceCreate, opts *ResourceCreateOptions) (*Resource, *Response, error)
+	Update(ctx context.Context, resourceID string, resource *ResourceUpdate) (*Resource, *Response, error)
+	Delete(ctx context.Context, resourceID string) (*Response, error)
+	SetState(ctx context.Context, resourceID string, activate bool) (*Resource, *Response, error)
+	Toggle(ctx context.Context, resourceID string, activate bool) (*Resource, *Response, error)
+	ListTemplates(ctx context.Context) (*ListResourceTemplatesResult, *Response, error)
+}
+
+// AgentsAPI is the interface implemented by AgentsService.
+type AgentsAPI interface {
+	List(ctx context.Context, opts *AgentListOptions) ([]*Agent, *Response, error)
+	All(ctx context.Context, opts *AgentListOptions) iter.Seq2[*Agent, error]
+	AllBySkip(ctx context.Context, opts *AgentListOptions) iter.Seq2[*Agent, error]
+	Get(ctx context.Context, agentID string) (*Agent, *Response, error)
+	Create(ctx context.Context, agent *AgentCreate, opts *AgentCreateOptions) (*Agent, *Response, error)
+	Update(ctx context.Context, agentID string, agent *AgentUpdate) (*Agent, *Response, error)
+	Delete(ctx context.Context, agentID string) (*Response, error)
+	SetState(ctx context.Context, agentID string, activate bool) (*Agent, *Response, error)
+	Toggle(ctx context.Context, agentID string, activate bool) (*Agent, *Response, error)
+	Invoke(ctx context.Context, agentName string, req *AgentInvokeRequest) (*AgentInvokeResult, *Response, error)
+	InvokeStream(ctx context.Context, agentName string, req *AgentInvokeRequest) (*AgentStream, *Response, error)
+}
+
+// TeamsAPI is the interface implemented by TeamsService.
+type TeamsAPI interface {
+	List(ctx context.Context, opts *TeamListOptions) ([]*Team, *Response, error)
+	All(ctx context.Context, opts *TeamListOptions) iter.Seq2[*Team, error]
+	Get(ctx context.Context, teamID string) (*Team, *Response, error)
+	Create(ctx context.Context, team *TeamCreate) (*Team, *Response, error)
+	Update(ctx context.Context, teamID string, team *TeamUpdate) (*Team, *Response, error)
+	Delete(ctx context.Context, teamID string) (*Response, error)
+	ListMembers(ctx context.Context, teamID string) ([]*TeamMember, *Response, error)
+	UpdateMember(ctx context.Context, teamID, userEmail string, update *TeamMemberUpdate) (*TeamMember, *Response, error)
+	RemoveMember(ctx context.Context, teamID, userEmail string) (*Response, error)
+	InviteMember(ctx context.Context, teamID string, invite *TeamInvite) (*TeamInvitation, *Response, error)
+	ListInvitations(ctx context.Context, teamID string) ([]*TeamInvitation, *Response, error)
+	AcceptInvitation(ctx context.Context, token string) (*TeamMember, *Response, error)
+	CancelInvitation(ctx context.Context, invitationID string) (*Response, error)
+	Discover(ctx context.Context, opts *TeamDiscoverOptions) ([]*TeamDiscovery, *Response, error)
+	DiscoverAll(ctx context.Context, opts *TeamDiscoverOptions) iter.Seq2[*TeamDiscovery, error]
+	Join(ctx context.Context, teamID string, request *TeamJoinRequest) (*TeamJoinRequestResponse, *Response, error)
+	Leave(ctx context.Context, teamID string) (*Response, error)
+	ListJoinRequests(ctx context.Context, teamID string) ([]*TeamJoinRequestResponse, *Response, error)
+	ApproveJoinRequest(ctx context.Context, teamID, requestID string) (*TeamMember, *Response, error)
+	RejectJoinRequest(ctx context.Context, teamID, requestID string) (*Response, error)
+}
+
+// CancellationAPI is the interface implemented by CancellationService.
+type CancellationAPI interface {
+	Cancel(ctx context.Context, req *CancellationRequest) (*CancellationResponse, *Response, error)
+	Status(ctx context.Context, requestID string) (*CancellationStatus, *Response, error)
+}
+
+// AuthAPI is the interface implemented by AuthService.
+type AuthAPI interface {
+	Login(ctx context.Context, login *LoginRequest) (*LoginResponse, *Response, error)
+	Me(ctx context.Context) (*AuthUser, *Response, error)
+	ListTokens(ctx context.Context, opts *APITokenListOptions) ([]*APIToken, *Response, error)
+	GetToken(ctx context.Context, tokenID string) (*APIToken, *Response, error)
+	CreateToken(ctx context.Context, token *APITokenCreate) (*APITokenCreateResponse, *Response, error)
+	RevokeToken(ctx context.Context, tokenID string, reason *string) (*Response, error)
+}
+
+// ExportAPI is the interface implemented by ExportService.
+type ExportAPI interface {
+	Export(ctx context.Context, opts *ExportOptions) (*ExportDocument, *Response, error)
+	Import(ctx context.Context, doc *ExportDocument, opts *ImportOptions) (*ImportResult, *Response, error)
+	ImportStatus(ctx context.Context, importID string) (*ImportResult, *Response, error)
+}
+
+var (
+	_ ToolsAPI        = (*ToolsService)(nil)
+	_ GatewaysAPI     = (*GatewaysService)(nil)
+	_ ServersAPI      = (*ServersService)(nil)
+	_ PromptsAPI      = (*PromptsService)(nil)
+	_ ResourcesAPI    = (*ResourcesService)(nil)
+	_ AgentsAPI       = (*AgentsService)(nil)
+	_ TeamsAPI        = (*TeamsService)(nil)
+	_ CancellationAPI = (*CancellationService)(nil)
+	_ AuthAPI         = (*AuthService)(nil)
+	_ ExportAPI       = (*ExportService)(nil)
+)
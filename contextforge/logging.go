@@ -0,0 +1,230 @@
+package contextforge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// LogOptions controls the records the client writes to Client.Logger.
+//
+// A nil LogOptions (the default) behaves like DefaultLogOptions. Nothing is
+// logged unless Client.Logger is set.
+type LogOptions struct {
+	// Level is the level of the record logged for each request that
+	// completes with a 1xx, 2xx, or 3xx status.
+	Level slog.Level
+
+	// ErrorLevel is the level of the record logged for each request that
+	// fails or completes with a 4xx or 5xx status.
+	ErrorLevel slog.Level
+
+	// RetryLevel is the level of the record logged before each retry.
+	RetryLevel slog.Level
+
+	// Bodies adds the request and response bodies to the record of each
+	// request, with authentication secrets such as gateway auth tokens and
+	// agent auth values redacted. Bodies longer than MaxBodyLog bytes are
+	// truncated. Streamed responses are not logged.
+	Bodies bool
+}
+
+// MaxBodyLog is the number of bytes of each body logged when
+// LogOptions.Bodies is set.
+const MaxBodyLog = 4096
+
+// DefaultLogOptions returns LogOptions that log completed requests and
+// retries at slog.LevelDebug and failed requests at slog.LevelWarn, without
+// bodies.
+func DefaultLogOptions() *LogOptions {
+	return &LogOptions{
+		Level:      slog.LevelDebug,
+		ErrorLevel: slog.LevelWarn,
+		RetryLevel: slog.LevelDebug,
+	}
+}
+
+// logOptions returns c.LogOptions, or the defaults if it is nil.
+func (c *Client) logOptions() *LogOptions {
+	if c.LogOptions == nil {
+		return DefaultLogOptions()
+	}
+	return c.LogOptions
+}
+
+// logBodies reports whether requests should be logged with their bodies.
+func (c *Client) logBodies() bool {
+	return c.Logger != nil && c.logOptions().Bodies
+}
+
+// requestLog collects what is logged about one request.
+type requestLog struct {
+	start    time.Time
+	attempts int
+	resp     *http.Response
+	err      error
+
+	// reqBody and respBody are logged if LogOptions.Bodies is set and they
+	// were captured.
+	reqBody, respBody []byte
+}
+
+// logRequest writes the record of a request to c.Logger, if set.
+func (c *Client) logRequest(ctx context.Context, req *http.Request, l *requestLog) {
+	if c.Logger == nil {
+		return
+	}
+	opts := c.logOptions()
+
+	level, msg := opts.Level, "request completed"
+	if l.err != nil || l.resp == nil || l.resp.StatusCode >= 400 {
+		level, msg = opts.ErrorLevel, "request failed"
+	}
+	if !c.Logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", SanitizeURL(req.URL).String()),
+	}
+	if l.resp != nil {
+		attrs = append(attrs, slog.Int("status", l.resp.StatusCode))
+	}
+	attrs = append(attrs,
+		slog.Duration("duration", time.Since(l.start)),
+		slog.Int("attempts", l.attempts),
+	)
+	if l.resp != nil {
+		if rate := parseRate(l.resp); rate.Limit > 0 {
+			attrs = append(attrs,
+				slog.Int("rate_limit", rate.Limit),
+				slog.Int("rate_remaining", rate.Remaining),
+				slog.Time("rate_reset", rate.Reset),
+			)
+		}
+	}
+	if l.err != nil {
+		attrs = append(attrs, slog.Any("error", l.err))
+	}
+	if opts.Bodies {
+		if l.reqBody != nil {
+			attrs = append(attrs, slog.String("request_body", redactBody(l.reqBody)))
+		}
+		if l.respBody != nil {
+			attrs = append(attrs, slog.String("response_body", redactBody(l.respBody)))
+		}
+	}
+
+	c.Logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// requestBody returns a copy of the body of req, or nil if it has none or
+// the body cannot be recreated.
+func requestBody(req *http.Request) []byte {
+	if req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// bufferBody reads the body of resp into memory and replaces it with a
+// reader over the data read, which is returned.
+func bufferBody(resp *http.Response) []byte {
+	data, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	return data
+}
+
+// redactedFields are the JSON fields whose string values are replaced in
+// logged bodies: the authentication secrets of gateways
+// (Gateway.AuthPassword, AuthToken, AuthHeaderValue, AuthQueryParamValue,
+// and the client secret of OAuthConfig) and agents (AgentCreate.AuthValue
+// and its camelCase counterpart in updates), the credentials of logins and
+// API tokens (LoginResponse.AccessToken and
+// APITokenCreateResponse.AccessToken), the tokens of team invitations
+// (TeamInvitation.Token), and the rekey secret of imports.
+var redactedFields = map[string]bool{
+	"authPassword":           true,
+	"authToken":              true,
+	"authHeaderValue":        true,
+	"authQueryParamValue":    true,
+	"auth_query_param_value": true,
+	"authValue":              true,
+	"auth_value":             true,
+	"client_secret":          true,
+	"password":               true,
+	"access_token":           true,
+	"refresh_token":          true,
+	"token":                  true,
+	"rekey_secret":           true,
+}
+
+// redactBody returns body for logging, with the values of redactedFields
+// and of the authHeaders list replaced if it is JSON, truncated to
+// MaxBodyLog bytes.
+func redactBody(body []byte) string {
+	var v any
+	if json.Unmarshal(body, &v) == nil && redactValue(v) {
+		if data, err := json.Marshal(v); err == nil {
+			body = data
+		}
+	}
+	if len(body) > MaxBodyLog {
+		return string(body[:MaxBodyLog]) + "...(truncated)"
+	}
+	return string(body)
+}
+
+// isString reports whether the decoded JSON value v is a string. Redacted
+// fields holding objects, such as the APIToken in the "token" field of
+// APITokenCreateResponse, are searched for secrets instead.
+func isString(v any) bool {
+	_, ok := v.(string)
+	return ok
+}
+
+// redactValue redacts v in place and reports whether it changed anything.
+func redactValue(v any) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, field := range v {
+			switch {
+			case redactedFields[k] && isString(field):
+				v[k] = "REDACTED"
+				changed = true
+			case k == "authHeaders":
+				if headers, ok := field.([]any); ok {
+					for _, h := range headers {
+						if h, ok := h.(map[string]any); ok && h["value"] != nil {
+							h["value"] = "REDACTED"
+							changed = true
+						}
+					}
+				}
+			default:
+				changed = redactValue(field) || changed
+			}
+		}
+	case []any:
+		for _, item := range v {
+			changed = redactValue(item) || changed
+		}
+	}
+	return changed
+}
@@ -0,0 +1,270 @@
+package contextforge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// logRecords sets a JSON logger on client and returns a function that
+// decodes the records written to it.
+func logRecords(client *Client) func() []map[string]any {
+	var buf bytes.Buffer
+	client.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	return func() []map[string]any {
+		var records []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			var r map[string]any
+			if err := json.Unmarshal([]byte(line), &r); err != nil {
+				panic(fmt.Sprintf("invalid log record %q: %v", line, err))
+			}
+			records = append(records, r)
+		}
+		return records
+	}
+}
+
+func TestDo_LogsRequest(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	records := logRecords(client)
+
+	mux.HandleFunc("/tools/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		fmt.Fprint(w, `{"id":"1","name":"tool"}`)
+	})
+
+	if _, _, err := client.Tools.Get(context.Background(), "1"); err != nil {
+		t.Fatalf("Tools.Get returned error: %v", err)
+	}
+
+	got := records()
+	if len(got) != 1 {
+		t.Fatalf("logged %d records, want 1: %v", len(got), got)
+	}
+	r := got[0]
+	want := map[string]any{
+		"level":          "DEBUG",
+		"msg":            "request completed",
+		"method":         "GET",
+		"status":         float64(200),
+		"attempts":       float64(1),
+		"rate_limit":     float64(100),
+		"rate_remaining": float64(99),
+	}
+	for k, v := range want {
+		if r[k] != v {
+			t.Errorf("record[%q] = %v, want %v", k, r[k], v)
+		}
+	}
+	if u, _ := r["url"].(string); !strings.HasSuffix(u, "/tools/1") {
+		t.Errorf("record[url] = %v, want a URL ending in /tools/1", r["url"])
+	}
+	for _, k := range []string{"duration", "rate_reset"} {
+		if _, ok := r[k]; !ok {
+			t.Errorf("record has no %q attribute", k)
+		}
+	}
+	for _, k := range []string{"error", "request_body", "response_body"} {
+		if _, ok := r[k]; ok {
+			t.Errorf("record has unexpected %q attribute", k)
+		}
+	}
+}
+
+func TestDo_LogsFailedRequest(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	records := logRecords(client)
+	client.RetryPolicy = testRetryPolicy()
+
+	mux.HandleFunc("/tools/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, `{"message":"upstream down"}`)
+	})
+
+	if _, _, err := client.Tools.Get(context.Background(), "1"); err == nil {
+		t.Fatal("Tools.Get returned no error")
+	}
+
+	got := records()
+	if len(got) != 3 {
+		t.Fatalf("logged %d records, want 3: %v", len(got), got)
+	}
+	for i, r := range got[:2] {
+		if r["msg"] != "retrying request" || r["level"] != "DEBUG" || r["attempt"] != float64(i+1) {
+			t.Errorf("record %d = %v, want a retry of attempt %d at DEBUG", i, r, i+1)
+		}
+	}
+	r := got[2]
+	if r["msg"] != "request failed" || r["level"] != "WARN" {
+		t.Errorf("record = %v, want a failed request at WARN", r)
+	}
+	if r["status"] != float64(http.StatusBadGateway) || r["attempts"] != float64(3) {
+		t.Errorf("record status = %v, attempts = %v, want 502 and 3", r["status"], r["attempts"])
+	}
+	if e, _ := r["error"].(string); !strings.Contains(e, "upstream down") {
+		t.Errorf("record[error] = %v, want the error response", r["error"])
+	}
+}
+
+func TestDo_LogOptions(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	records := logRecords(client)
+	client.LogOptions = &LogOptions{Level: slog.LevelInfo, ErrorLevel: slog.LevelError}
+
+	mux.HandleFunc("/tools/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"1"}`)
+	})
+	mux.HandleFunc("/tools/2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	client.Tools.Get(context.Background(), "1")
+	client.Tools.Get(context.Background(), "2")
+
+	got := records()
+	if len(got) != 2 {
+		t.Fatalf("logged %d records, want 2: %v", len(got), got)
+	}
+	if got[0]["level"] != "INFO" || got[1]["level"] != "ERROR" {
+		t.Errorf("levels = %v, %v, want INFO, ERROR", got[0]["level"], got[1]["level"])
+	}
+}
+
+func TestDo_LogsRedactedBodies(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	records := logRecords(client)
+	client.LogOptions = DefaultLogOptions()
+	client.LogOptions.Bodies = true
+
+	mux.HandleFunc("/gateways", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"g1","name":"weather","authToken":"echoed-secret"}`)
+	})
+	mux.HandleFunc("/a2a", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"a1","name":"agent"}`)
+	})
+
+	gw, _, err := client.Gateways.Create(context.Background(), &Gateway{
+		Name:        "weather",
+		URL:         "http://weather.internal",
+		AuthToken:   String("gateway-secret"),
+		AuthHeaders: []map[string]string{{"key": "X-Key", "value": "header-secret"}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Gateways.Create returned error: %v", err)
+	}
+	if gw.AuthToken == nil || *gw.AuthToken != "echoed-secret" {
+		t.Errorf("Gateways.Create returned AuthToken %v, want the unredacted response", gw.AuthToken)
+	}
+	if _, _, err := client.Agents.Create(context.Background(), &AgentCreate{
+		Name:      "agent",
+		AuthValue: String("agent-secret"),
+	}, nil); err != nil {
+		t.Fatalf("Agents.Create returned error: %v", err)
+	}
+
+	got := records()
+	if len(got) != 2 {
+		t.Fatalf("logged %d records, want 2: %v", len(got), got)
+	}
+	for _, r := range got {
+		reqBody, _ := r["request_body"].(string)
+		respBody, _ := r["response_body"].(string)
+		if !strings.Contains(reqBody, `"name":`) || !strings.Contains(respBody, `"id":`) {
+			t.Errorf("record bodies = %q, %q, want the request and response", reqBody, respBody)
+		}
+		for _, secret := range []string{"gateway-secret", "header-secret", "echoed-secret", "agent-secret"} {
+			if strings.Contains(reqBody+respBody, secret) {
+				t.Errorf("logged bodies contain secret %q", secret)
+			}
+		}
+	}
+}
+
+func TestDo_NoLogger(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.LogOptions = &LogOptions{Bodies: true}
+
+	mux.HandleFunc("/tools/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"1"}`)
+	})
+
+	tool, _, err := client.Tools.Get(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("Tools.Get returned error: %v", err)
+	}
+	if tool.ID != "1" {
+		t.Errorf("Tools.Get returned ID %q, want %q", tool.ID, "1")
+	}
+}
+
+func TestDo_LogsRedactedInvitationToken(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	records := logRecords(client)
+	client.LogOptions = DefaultLogOptions()
+	client.LogOptions.Bodies = true
+
+	mux.HandleFunc("/teams/team-1/invitations/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"inv-1","team_id":"team-1","email":"ada@example.com","role":"member","token":"invitation-secret","is_active":true}`)
+	})
+
+	invitation, _, err := client.Teams.InviteMember(context.Background(), "team-1", &TeamInvite{Email: "ada@example.com"})
+	if err != nil {
+		t.Fatalf("Teams.InviteMember returned error: %v", err)
+	}
+	if invitation.Token != "invitation-secret" {
+		t.Errorf("Teams.InviteMember returned Token %q, want the unredacted response", invitation.Token)
+	}
+
+	got := records()
+	if len(got) != 1 {
+		t.Fatalf("logged %d records, want 1: %v", len(got), got)
+	}
+	respBody, _ := got[0]["response_body"].(string)
+	if strings.Contains(respBody, "invitation-secret") || !strings.Contains(respBody, `"token":"REDACTED"`) {
+		t.Errorf("logged response body %q, want the token redacted", respBody)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	long := `{"description":"` + strings.Repeat("x", MaxBodyLog) + `"}`
+
+	tests := []struct {
+		name, body, want string
+	}{
+		{"gateway", `{"name":"g","authPassword":"p","authQueryParamValue":"q"}`, `{"authPassword":"REDACTED","authQueryParamValue":"REDACTED","name":"g"}`},
+		{"auth headers", `{"authHeaders":[{"key":"X-Key","value":"v"}]}`, `{"authHeaders":[{"key":"X-Key","value":"REDACTED"}]}`},
+		{"agent", `{"agent":{"name":"a","auth_value":"v"}}`, `{"agent":{"auth_value":"REDACTED","name":"a"}}`},
+		{"oauth", `{"oauthConfig":{"client_id":"c","client_secret":"s"}}`, `{"oauthConfig":{"client_id":"c","client_secret":"REDACTED"}}`},
+		{"invitation", `[{"id":"i1","token":"t"}]`, `[{"id":"i1","token":"REDACTED"}]`},
+		{"api token", `{"token":{"id":"k1","name":"ci"},"access_token":"t"}`, `{"access_token":"REDACTED","token":{"id":"k1","name":"ci"}}`},
+		{"import", `{"dry_run":true,"rekey_secret":"s"}`, `{"dry_run":true,"rekey_secret":"REDACTED"}`},
+		{"null secret kept", `{"authToken":null}`, `{"authToken":null}`},
+		{"nothing to redact", `{"b": 1, "a": 2}`, `{"b": 1, "a": 2}`},
+		{"not JSON", `password=hunter2`, `password=hunter2`},
+		{"truncated", long, long[:MaxBodyLog] + "...(truncated)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactBody([]byte(tt.body)); got != tt.want {
+				t.Errorf("redactBody(%.40s) = %.60s, want %.60s", tt.body, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,448 @@
+package contextforge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultMCPProtocolVersion is the MCP protocol version requested by
+// ServersService.Connect unless MCPSessionOptions.ProtocolVersion is set.
+const DefaultMCPProtocolVersion = "2025-06-18"
+
+// MCP transports accepted by MCPSessionOptions.Transport. The values match
+// those of Gateway.Transport.
+const (
+	MCPTransportStreamableHTTP = "STREAMABLEHTTP"
+	MCPTransportSSE            = "SSE"
+)
+
+const (
+	headerMCPSessionID       = "Mcp-Session-Id"
+	headerMCPProtocolVersion = "MCP-Protocol-Version"
+)
+
+// jsonrpcMessage is a JSON-RPC 2.0 request, notification, or response.
+type jsonrpcMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  any             `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *MCPError       `json:"error,omitempty"`
+}
+
+// isResponseTo reports whether m is the response to the request with the given id.
+func (m *jsonrpcMessage) isResponseTo(id json.RawMessage) bool {
+	return m.Method == "" && string(m.ID) == string(id)
+}
+
+// mcpOperation returns the Operation of a request of an MCP session with the
+// server identified by serverID: method is the JSON-RPC method of the
+// message sent, "connect" for the event stream of the SSE transport, or
+// "close" for the request ending the session.
+func mcpOperation(serverID, method string) Operation {
+	return Operation{Service: "MCP", Method: method, EntityID: serverID}
+}
+
+// mcpTransport carries JSON-RPC messages between an MCPSession and a server.
+type mcpTransport interface {
+	// call sends a request and waits for the response with the same ID.
+	call(ctx context.Context, msg *jsonrpcMessage) (*jsonrpcMessage, error)
+
+	// notify sends a notification, which has no response.
+	notify(ctx context.Context, msg *jsonrpcMessage) error
+
+	// setProtocolVersion records the version negotiated during initialization.
+	setProtocolVersion(version string)
+
+	// sessionID returns the server-assigned session ID, if any.
+	sessionID() string
+
+	// close ends the session.
+	close(ctx context.Context) error
+}
+
+// MCPSession is a Model Context Protocol session with a ContextForge virtual
+// server, obtained from ServersService.Connect. Unlike the REST services, it
+// speaks JSON-RPC 2.0 to the server's MCP endpoint, so tools, resources, and
+// prompts are used the way an MCP client would use them.
+//
+// An MCPSession is safe for concurrent use.
+type MCPSession struct {
+	transport mcpTransport
+	nextID    atomic.Int64
+
+	initResult *MCPInitializeResult
+}
+
+// Connect opens an MCP session with the virtual server identified by serverID
+// and performs the initialize handshake. By default the session uses
+// ContextForge's streamable HTTP endpoint (/servers/{id}/mcp); set
+// opts.Transport to MCPTransportSSE to use the legacy SSE endpoint
+// (/servers/{id}/sse) instead. The opts parameter is optional; pass nil to use
+// the defaults. Close the session when done with it.
+func (s *ServersService) Connect(ctx context.Context, serverID string, opts *MCPSessionOptions) (*MCPSession, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("context must be non-nil")
+	}
+
+	transportName := MCPTransportStreamableHTTP
+	if opts != nil && opts.Transport != "" {
+		transportName = opts.Transport
+	}
+
+	var transport mcpTransport
+	switch transportName {
+	case MCPTransportStreamableHTTP:
+		transport = &mcpHTTPTransport{
+			client:   s.client,
+			serverID: serverID,
+			endpoint: fmt.Sprintf("servers/%s/mcp", url.PathEscape(serverID)),
+		}
+	case MCPTransportSSE:
+		t, err := newMCPSSETransport(ctx, s.client, serverID, fmt.Sprintf("servers/%s/sse", url.PathEscape(serverID)))
+		if err != nil {
+			return nil, err
+		}
+		transport = t
+	default:
+		return nil, fmt.Errorf("unsupported mcp transport %q", transportName)
+	}
+
+	session, err := newMCPSession(ctx, transport, opts)
+	if err != nil {
+		transport.close(ctx)
+		return nil, err
+	}
+	return session, nil
+}
+
+// newMCPSession performs the initialize handshake over transport.
+func newMCPSession(ctx context.Context, transport mcpTransport, opts *MCPSessionOptions) (*MCPSession, error) {
+	version := DefaultMCPProtocolVersion
+	clientInfo := &MCPImplementation{Name: "go-contextforge", Version: Version}
+	if opts != nil {
+		if opts.ProtocolVersion != "" {
+			version = opts.ProtocolVersion
+		}
+		if opts.ClientInfo != nil {
+			clientInfo = opts.ClientInfo
+		}
+	}
+
+	session := &MCPSession{transport: transport}
+
+	params := map[string]any{
+		"protocolVersion": version,
+		"capabilities":    map[string]any{},
+		"clientInfo":      clientInfo,
+	}
+	var result *MCPInitializeResult
+	if err := session.call(ctx, "initialize", params, &result); err != nil {
+		return nil, fmt.Errorf("mcp initialize; %w", err)
+	}
+	if result == nil {
+		return nil, fmt.Errorf("mcp initialize; empty result")
+	}
+	session.initResult = result
+	transport.setProtocolVersion(result.ProtocolVersion)
+
+	if err := transport.notify(ctx, &jsonrpcMessage{
+		JSONRPC: "2.0",
+		Method:  "notifications/initialized",
+	}); err != nil {
+		return nil, fmt.Errorf("mcp initialized notification; %w", err)
+	}
+
+	return session, nil
+}
+
+// MCPHandler answers the JSON-RPC requests of an MCPSession created with
+// NewMCPSession. It returns the result of the named method, which is
+// encoded as JSON, or an error, such as an *MCPError.
+type MCPHandler func(ctx context.Context, method string, params json.RawMessage) (any, error)
+
+// NewMCPSession returns an MCPSession whose requests are answered by
+// handler, for tests of code that uses ServersAPI. Its InitializeResult is
+// init and it has no session ID. The methods of a zero MCPSession return an
+// error.
+func NewMCPSession(init *MCPInitializeResult, handler MCPHandler) *MCPSession {
+	return &MCPSession{transport: handlerTransport(handler), initResult: init}
+}
+
+// handlerTransport is the mcpTransport of the sessions created by
+// NewMCPSession.
+type handlerTransport MCPHandler
+
+func (h handlerTransport) call(ctx context.Context, msg *jsonrpcMessage) (*jsonrpcMessage, error) {
+	params, err := json.Marshal(msg.Params)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s params; %w", msg.Method, err)
+	}
+	result, err := h(ctx, msg.Method, params)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s result; %w", msg.Method, err)
+	}
+	return &jsonrpcMessage{JSONRPC: "2.0", ID: msg.ID, Result: data}, nil
+}
+
+func (h handlerTransport) notify(context.Context, *jsonrpcMessage) error { return nil }
+func (h handlerTransport) setProtocolVersion(string)                     {}
+func (h handlerTransport) sessionID() string                             { return "" }
+func (h handlerTransport) close(context.Context) error                   { return nil }
+
+// InitializeResult returns the server's reply to the initialize handshake,
+// including the negotiated protocol version and the server's capabilities.
+func (s *MCPSession) InitializeResult() *MCPInitializeResult {
+	return s.initResult
+}
+
+// SessionID returns the session ID assigned by the server, or "" if the
+// server does not track sessions.
+func (s *MCPSession) SessionID() string {
+	if s.transport == nil {
+		return ""
+	}
+	return s.transport.sessionID()
+}
+
+// ListTools returns one page of the tools the server exposes. Pass the
+// NextCursor of the previous page as cursor, or "" for the first page.
+func (s *MCPSession) ListTools(ctx context.Context, cursor string) (*MCPListToolsResult, error) {
+	var params map[string]any
+	if cursor != "" {
+		params = map[string]any{"cursor": cursor}
+	}
+
+	var result *MCPListToolsResult
+	if err := s.call(ctx, "tools/list", params, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CallTool calls the named tool with args. A tool that fails reports the
+// failure with ToolCallResult.IsError rather than an error.
+func (s *MCPSession) CallTool(ctx context.Context, name string, args map[string]any) (*ToolCallResult, error) {
+	params := map[string]any{"name": name}
+	if args != nil {
+		params["arguments"] = args
+	}
+
+	var result *ToolCallResult
+	if err := s.call(ctx, "tools/call", params, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ReadResource reads the resource identified by uri.
+func (s *MCPSession) ReadResource(ctx context.Context, uri string) (*MCPReadResourceResult, error) {
+	var result *MCPReadResourceResult
+	if err := s.call(ctx, "resources/read", map[string]any{"uri": uri}, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetPrompt renders the named prompt with args.
+func (s *MCPSession) GetPrompt(ctx context.Context, name string, args map[string]string) (*MCPGetPromptResult, error) {
+	params := map[string]any{"name": name}
+	if args != nil {
+		params["arguments"] = args
+	}
+
+	var result *MCPGetPromptResult
+	if err := s.call(ctx, "prompts/get", params, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Close ends the session. The session must not be used afterwards.
+func (s *MCPSession) Close(ctx context.Context) error {
+	if s.transport == nil {
+		return nil
+	}
+	return s.transport.close(ctx)
+}
+
+// call sends a JSON-RPC request and decodes its result into v. JSON-RPC
+// errors are returned as *MCPError.
+func (s *MCPSession) call(ctx context.Context, method string, params, v any) error {
+	if ctx == nil {
+		return fmt.Errorf("context must be non-nil")
+	}
+	if s.transport == nil {
+		return fmt.Errorf("mcp session is not connected")
+	}
+
+	msg := &jsonrpcMessage{
+		JSONRPC: "2.0",
+		ID:      json.RawMessage(strconv.FormatInt(s.nextID.Add(1), 10)),
+		Method:  method,
+		Params:  params,
+	}
+
+	resp, err := s.transport.call(ctx, msg)
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if v == nil || len(resp.Result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Result, v); err != nil {
+		return fmt.Errorf("decode %s result; %w", method, err)
+	}
+	return nil
+}
+
+// mcpHTTPTransport implements the MCP streamable HTTP transport. Each message
+// is POSTed to a single endpoint, which replies with either a JSON body or an
+// event stream carrying the response.
+type mcpHTTPTransport struct {
+	client   *Client
+	serverID string
+	endpoint string
+
+	mu      sync.Mutex
+	session string
+	version string
+}
+
+func (t *mcpHTTPTransport) call(ctx context.Context, msg *jsonrpcMessage) (*jsonrpcMessage, error) {
+	resp, err := t.post(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != mediaTypeEventStream {
+		var reply jsonrpcMessage
+		if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+			return nil, fmt.Errorf("decode %s response; %w", msg.Method, err)
+		}
+		return &reply, nil
+	}
+
+	// The server may send notifications and requests of its own on the
+	// stream before the response.
+	events := newSSEReader(resp.Body)
+	for {
+		ev, err := events.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s: event stream ended without a response", msg.Method)
+		}
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			return nil, err
+		}
+		if ev.Data == "" {
+			continue
+		}
+
+		var reply jsonrpcMessage
+		if err := json.Unmarshal([]byte(ev.Data), &reply); err != nil {
+			return nil, fmt.Errorf("decode %s response; %w", msg.Method, err)
+		}
+		if reply.isResponseTo(msg.ID) {
+			return &reply, nil
+		}
+	}
+}
+
+func (t *mcpHTTPTransport) notify(ctx context.Context, msg *jsonrpcMessage) error {
+	resp, err := t.post(ctx, msg)
+	if err != nil {
+		return err
+	}
+	io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
+
+// post sends msg with the session headers and returns the open response.
+// Error responses are returned as by Client.Do, with the body closed.
+func (t *mcpHTTPTransport) post(ctx context.Context, msg *jsonrpcMessage) (*http.Response, error) {
+	req, err := t.client.newRequest(mcpOperation(t.serverID, msg.Method), http.MethodPost, t.endpoint, msg)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", mediaTypeJSON+", "+mediaTypeEventStream)
+	t.setHeaders(req)
+
+	resp, err := t.client.doStream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if id := resp.Header.Get(headerMCPSessionID); id != "" {
+		t.mu.Lock()
+		t.session = id
+		t.mu.Unlock()
+	}
+
+	return resp.Response, nil
+}
+
+// setHeaders adds the session ID and negotiated protocol version to req.
+func (t *mcpHTTPTransport) setHeaders(req *http.Request) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.session != "" {
+		req.Header.Set(headerMCPSessionID, t.session)
+	}
+	if t.version != "" {
+		req.Header.Set(headerMCPProtocolVersion, t.version)
+	}
+}
+
+func (t *mcpHTTPTransport) setProtocolVersion(version string) {
+	t.mu.Lock()
+	t.version = version
+	t.mu.Unlock()
+}
+
+func (t *mcpHTTPTransport) sessionID() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.session
+}
+
+// close asks the server to terminate the session. Servers that do not
+// support explicit termination answer 405, which is not an error.
+func (t *mcpHTTPTransport) close(ctx context.Context) error {
+	if t.sessionID() == "" {
+		return nil
+	}
+
+	req, err := t.client.newRequest(mcpOperation(t.serverID, "close"), http.MethodDelete, t.endpoint, nil)
+	if err != nil {
+		return err
+	}
+	t.setHeaders(req)
+
+	resp, err := t.client.Do(ctx, req, nil)
+	if err != nil && (resp == nil || resp.StatusCode != http.StatusMethodNotAllowed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,329 @@
+package contextforge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultSSEReconnectDelay is how long the SSE transport waits before
+	// reconnecting when the server has not sent a retry field.
+	defaultSSEReconnectDelay = time.Second
+
+	// maxSSEReconnectAttempts is how many consecutive failed reconnection
+	// attempts the SSE transport makes before giving up on the session.
+	maxSSEReconnectAttempts = 5
+)
+
+// errMCPSessionClosed is reported to calls made after an MCP session is closed.
+var errMCPSessionClosed = errors.New("mcp session closed")
+
+// mcpSSETransport implements the legacy MCP HTTP+SSE transport. The client
+// holds open a GET event stream, on which the server first announces the
+// endpoint to POST messages to and then delivers JSON-RPC responses, which
+// are matched to their requests by ID. A dropped stream is reopened with the
+// Last-Event-ID header so the server can replay missed events; if the server
+// starts a new session instead, the transport fails, since that session has
+// not been initialized.
+type mcpSSETransport struct {
+	client    *Client
+	serverID  string
+	streamURL string
+
+	// ctx scopes the event stream, which outlives the context passed to
+	// Connect; cancel ends it.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	ready     chan struct{} // closed once the first endpoint event arrives
+	readyOnce sync.Once
+	done      chan struct{} // closed when the stream reader exits
+	err       error         // why the stream reader exited; valid after done
+
+	mu          sync.Mutex
+	endpoint    *url.URL
+	lastEventID string
+	retry       time.Duration
+	pending     map[string]chan *jsonrpcMessage
+}
+
+// newMCPSSETransport opens the event stream at streamURL of the server
+// identified by serverID and waits for the server to announce its message
+// endpoint.
+func newMCPSSETransport(ctx context.Context, client *Client, serverID, streamURL string) (*mcpSSETransport, error) {
+	t := &mcpSSETransport{
+		client:    client,
+		serverID:  serverID,
+		streamURL: streamURL,
+		ready:     make(chan struct{}),
+		done:      make(chan struct{}),
+		retry:     defaultSSEReconnectDelay,
+		pending:   make(map[string]chan *jsonrpcMessage),
+	}
+	t.ctx, t.cancel = context.WithCancel(context.WithoutCancel(ctx))
+
+	body, err := t.open()
+	if err != nil {
+		t.cancel()
+		return nil, err
+	}
+	go t.run(body)
+
+	select {
+	case <-t.ready:
+		return t, nil
+	case <-t.done:
+		return nil, t.err
+	case <-ctx.Done():
+		t.close(ctx)
+		return nil, ctx.Err()
+	}
+}
+
+// open connects to the event stream, resuming after the last event seen.
+func (t *mcpSSETransport) open() (io.ReadCloser, error) {
+	req, err := t.client.newRequest(mcpOperation(t.serverID, "connect"), http.MethodGet, t.streamURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	if t.lastEventID != "" {
+		req.Header.Set("Last-Event-ID", t.lastEventID)
+	}
+	t.mu.Unlock()
+
+	resp, err := t.client.doStream(t.ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// run reads the event stream until the transport is closed, reconnecting
+// whenever the stream drops.
+func (t *mcpSSETransport) run(body io.ReadCloser) {
+	defer close(t.done)
+
+	for {
+		err := t.read(body)
+		body.Close()
+		if err != nil {
+			t.err = err
+			return
+		}
+		if t.ctx.Err() != nil {
+			t.err = errMCPSessionClosed
+			return
+		}
+
+		for attempt := 1; ; attempt++ {
+			t.mu.Lock()
+			wait := t.retry
+			t.mu.Unlock()
+			if sleep(t.ctx, wait) != nil {
+				t.err = errMCPSessionClosed
+				return
+			}
+
+			var err error
+			body, err = t.open()
+			if err == nil {
+				break
+			}
+			if attempt >= maxSSEReconnectAttempts {
+				t.err = fmt.Errorf("mcp event stream lost; %w", err)
+				return
+			}
+		}
+	}
+}
+
+// read dispatches events from one connection of the stream until it ends.
+// It returns an error only if the session can not continue.
+func (t *mcpSSETransport) read(body io.Reader) error {
+	events := newSSEReader(body)
+	for {
+		ev, err := events.Next()
+		if err != nil {
+			return nil
+		}
+
+		t.mu.Lock()
+		if ev.ID != "" {
+			t.lastEventID = ev.ID
+		}
+		if ev.Retry > 0 {
+			t.retry = ev.Retry
+		}
+		t.mu.Unlock()
+
+		switch ev.Event {
+		case "endpoint":
+			if err := t.setEndpoint(ev.Data); err != nil {
+				return err
+			}
+		case "", "message":
+			if ev.Data == "" {
+				continue
+			}
+			var msg jsonrpcMessage
+			if err := json.Unmarshal([]byte(ev.Data), &msg); err != nil {
+				continue
+			}
+			t.deliver(&msg)
+		}
+	}
+}
+
+// setEndpoint records the message endpoint announced by the server, which
+// may be relative to the stream URL. It returns an error if a reconnected
+// stream announces a different session than the one initialized.
+func (t *mcpSSETransport) setEndpoint(data string) error {
+	base, err := t.client.Address.Parse(t.streamURL)
+	if err != nil {
+		return nil
+	}
+	endpoint, err := base.Parse(data)
+	if err != nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	if t.endpoint != nil {
+		if old, id := endpointSessionID(t.endpoint), endpointSessionID(endpoint); old != id {
+			t.mu.Unlock()
+			return fmt.Errorf("mcp event stream reconnected to session %q, want %q", id, old)
+		}
+	}
+	t.endpoint = endpoint
+	t.mu.Unlock()
+	t.readyOnce.Do(func() { close(t.ready) })
+	return nil
+}
+
+// deliver hands a response to the call waiting for it. Server notifications
+// and requests are ignored.
+func (t *mcpSSETransport) deliver(msg *jsonrpcMessage) {
+	if msg.Method != "" || msg.ID == nil {
+		return
+	}
+
+	t.mu.Lock()
+	ch, ok := t.pending[string(msg.ID)]
+	t.mu.Unlock()
+	if ok {
+		select {
+		case ch <- msg:
+		default:
+		}
+	}
+}
+
+func (t *mcpSSETransport) call(ctx context.Context, msg *jsonrpcMessage) (*jsonrpcMessage, error) {
+	key := string(msg.ID)
+	ch := make(chan *jsonrpcMessage, 1)
+
+	t.mu.Lock()
+	t.pending[key] = ch
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		delete(t.pending, key)
+		t.mu.Unlock()
+	}()
+
+	// Servers may answer in the POST response instead of on the stream.
+	if reply, err := t.post(ctx, msg); err != nil {
+		return nil, err
+	} else if reply != nil && reply.isResponseTo(msg.ID) {
+		return reply, nil
+	}
+
+	select {
+	case reply := <-ch:
+		return reply, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-t.done:
+		return nil, t.err
+	}
+}
+
+func (t *mcpSSETransport) notify(ctx context.Context, msg *jsonrpcMessage) error {
+	_, err := t.post(ctx, msg)
+	return err
+}
+
+// post sends msg to the message endpoint. If the server replies with a
+// JSON-RPC message in the response body, it is returned.
+func (t *mcpSSETransport) post(ctx context.Context, msg *jsonrpcMessage) (*jsonrpcMessage, error) {
+	select {
+	case <-t.done:
+		return nil, t.err
+	default:
+	}
+
+	t.mu.Lock()
+	endpoint := t.endpoint.String()
+	t.mu.Unlock()
+
+	req, err := t.client.newRequest(mcpOperation(t.serverID, msg.Method), http.MethodPost, endpoint, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	if _, err := t.client.Do(ctx, req, &body); err != nil {
+		return nil, err
+	}
+
+	if body.Len() == 0 {
+		return nil, nil
+	}
+	var reply jsonrpcMessage
+	if err := json.Unmarshal(body.Bytes(), &reply); err != nil {
+		// Acknowledgements such as "Accepted" are not JSON-RPC messages.
+		return nil, nil
+	}
+	return &reply, nil
+}
+
+// setProtocolVersion is a no-op; the SSE transport predates the protocol
+// version header.
+func (t *mcpSSETransport) setProtocolVersion(string) {}
+
+// sessionID returns the session_id query parameter of the message endpoint,
+// which is how SSE servers identify the session.
+func (t *mcpSSETransport) sessionID() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.endpoint == nil {
+		return ""
+	}
+	return endpointSessionID(t.endpoint)
+}
+
+// endpointSessionID returns the session ID carried by a message endpoint.
+func endpointSessionID(endpoint *url.URL) string {
+	q := endpoint.Query()
+	if id := q.Get("session_id"); id != "" {
+		return id
+	}
+	return q.Get("sessionId")
+}
+
+func (t *mcpSSETransport) close(context.Context) error {
+	t.cancel()
+	<-t.done
+	return nil
+}
@@ -0,0 +1,308 @@
+package contextforge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSSEMCPServer is a legacy HTTP+SSE MCP server for tests. Responses to
+// POSTed requests are queued and delivered on whichever event stream is
+// connected, so responses queued while the stream is down are replayed on
+// reconnection.
+type fakeSSEMCPServer struct {
+	t       *testing.T
+	methods map[string]mcpMethodFunc
+
+	// dropAfter closes the first event stream after it has delivered this
+	// many messages. Zero keeps it open.
+	dropAfter int
+
+	// reconnectSession is the session announced on reconnected event
+	// streams. Empty keeps the first session.
+	reconnectSession string
+
+	outbox chan string
+
+	mu            sync.Mutex
+	nextEventID   int
+	lastEventIDs  []string
+	notifications []string
+}
+
+func newFakeSSEMCPServer(t *testing.T, mux *http.ServeMux, methods map[string]mcpMethodFunc) *fakeSSEMCPServer {
+	if methods["initialize"] == nil {
+		methods["initialize"] = initializeResult
+	}
+	f := &fakeSSEMCPServer{t: t, methods: methods, outbox: make(chan string, 16)}
+	mux.HandleFunc("/servers/srv-1/sse", f.stream)
+	mux.HandleFunc("/servers/srv-1/message", f.message)
+	return f
+}
+
+func (f *fakeSSEMCPServer) stream(w http.ResponseWriter, r *http.Request) {
+	testMethod(f.t, r, "GET")
+	if got := r.Header.Get("Accept"); got != "text/event-stream" {
+		f.t.Errorf("Accept = %q, want %q", got, "text/event-stream")
+	}
+
+	f.mu.Lock()
+	f.lastEventIDs = append(f.lastEventIDs, r.Header.Get("Last-Event-ID"))
+	first := len(f.lastEventIDs) == 1
+	f.mu.Unlock()
+
+	sessionID := "sse-session"
+	if !first && f.reconnectSession != "" {
+		sessionID = f.reconnectSession
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	fmt.Fprintf(w, "retry: 10\nevent: endpoint\ndata: /servers/srv-1/message?session_id=%s\n\n", sessionID)
+	w.(http.Flusher).Flush()
+
+	sent := 0
+	for {
+		select {
+		case msg := <-f.outbox:
+			f.mu.Lock()
+			f.nextEventID++
+			id := f.nextEventID
+			f.mu.Unlock()
+
+			fmt.Fprintf(w, "id: %d\nevent: message\ndata: %s\n\n", id, msg)
+			w.(http.Flusher).Flush()
+
+			sent++
+			if first && f.dropAfter > 0 && sent >= f.dropAfter {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (f *fakeSSEMCPServer) message(w http.ResponseWriter, r *http.Request) {
+	testMethod(f.t, r, "POST")
+	if got := r.URL.Query().Get("session_id"); got != "sse-session" {
+		f.t.Errorf("session_id = %q, want %q", got, "sse-session")
+	}
+
+	var req struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params map[string]any  `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		f.t.Fatalf("decode request: %v", err)
+	}
+
+	if req.ID == nil {
+		f.mu.Lock()
+		f.notifications = append(f.notifications, req.Method)
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	handler, ok := f.methods[req.Method]
+	if !ok {
+		handler = func(map[string]any) (any, *MCPError) {
+			return nil, &MCPError{Code: -32601, Message: "Method not found"}
+		}
+	}
+	result, rpcErr := handler(req.Params)
+
+	reply := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+	if rpcErr != nil {
+		reply["error"] = rpcErr
+	} else {
+		reply["result"] = result
+	}
+	data, _ := json.Marshal(reply)
+
+	// Server-initiated messages on the stream must not be mistaken for
+	// the response.
+	f.outbox <- `{"jsonrpc":"2.0","method":"notifications/message","params":{"level":"info"}}`
+	f.outbox <- string(data)
+
+	w.WriteHeader(http.StatusAccepted)
+	fmt.Fprint(w, "Accepted")
+}
+
+func connectSSE(t *testing.T, client *Client) *MCPSession {
+	t.Helper()
+
+	session, err := client.Servers.Connect(context.Background(), "srv-1", &MCPSessionOptions{Transport: MCPTransportSSE})
+	if err != nil {
+		t.Fatalf("Servers.Connect returned error: %v", err)
+	}
+	return session
+}
+
+func TestServersService_Connect_SSE(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	fake := newFakeSSEMCPServer(t, mux, map[string]mcpMethodFunc{
+		"tools/call": func(params map[string]any) (any, *MCPError) {
+			return map[string]any{"content": []any{map[string]any{"type": "text", "text": "pong"}}}, nil
+		},
+	})
+
+	session := connectSSE(t, client)
+	defer session.Close(context.Background())
+
+	if got := session.SessionID(); got != "sse-session" {
+		t.Errorf("SessionID() = %q, want %q", got, "sse-session")
+	}
+	if got := session.InitializeResult().ServerInfo.Name; got != "contextforge" {
+		t.Errorf("ServerInfo.Name = %q, want %q", got, "contextforge")
+	}
+	fake.mu.Lock()
+	if fmt.Sprint(fake.notifications) != "[notifications/initialized]" {
+		t.Errorf("notifications = %v, want [notifications/initialized]", fake.notifications)
+	}
+	fake.mu.Unlock()
+
+	got, err := session.CallTool(context.Background(), "ping", nil)
+	if err != nil {
+		t.Fatalf("CallTool returned error: %v", err)
+	}
+	if got.Text() != "pong" {
+		t.Errorf("Text() = %q, want %q", got.Text(), "pong")
+	}
+
+	_, err = session.GetPrompt(context.Background(), "missing", nil)
+	var rpcErr *MCPError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32601 {
+		t.Errorf("GetPrompt error = %v, want method not found", err)
+	}
+}
+
+func TestServersService_Connect_SSE_Reconnect(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	fake := newFakeSSEMCPServer(t, mux, map[string]mcpMethodFunc{
+		"tools/list": func(map[string]any) (any, *MCPError) {
+			return map[string]any{"tools": []any{map[string]any{"name": "search"}}}, nil
+		},
+	})
+	// Drop the first stream right after the initialize notification and response.
+	fake.dropAfter = 2
+
+	session := connectSSE(t, client)
+	defer session.Close(context.Background())
+
+	got, err := session.ListTools(context.Background(), "")
+	if err != nil {
+		t.Fatalf("ListTools returned error: %v", err)
+	}
+	if len(got.Tools) != 1 || got.Tools[0].Name != "search" {
+		t.Errorf("Tools = %+v, want [search]", got.Tools)
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if len(fake.lastEventIDs) != 2 {
+		t.Fatalf("stream opened %d times, want 2", len(fake.lastEventIDs))
+	}
+	if fake.lastEventIDs[0] != "" || fake.lastEventIDs[1] != "2" {
+		t.Errorf("Last-Event-ID headers = %q, want [\"\" \"2\"]", fake.lastEventIDs)
+	}
+}
+
+func TestServersService_Connect_SSE_ReconnectNewSession(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	fake := newFakeSSEMCPServer(t, mux, map[string]mcpMethodFunc{})
+	fake.dropAfter = 2
+	fake.reconnectSession = "other-session"
+
+	session := connectSSE(t, client)
+	defer session.Close(context.Background())
+
+	_, err := session.ListTools(context.Background(), "")
+	if err == nil || !strings.Contains(err.Error(), "other-session") {
+		t.Fatalf("ListTools error = %v, want session change error", err)
+	}
+	if _, err := session.ListTools(context.Background(), ""); err == nil {
+		t.Error("ListTools after session change returned nil error")
+	}
+}
+
+func TestServersService_Connect_SSE_OutlivesClientTimeout(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.client.Timeout = 50 * time.Millisecond
+
+	fake := newFakeSSEMCPServer(t, mux, map[string]mcpMethodFunc{
+		"tools/list": func(map[string]any) (any, *MCPError) {
+			return map[string]any{"tools": []any{}}, nil
+		},
+	})
+
+	session := connectSSE(t, client)
+	defer session.Close(context.Background())
+
+	time.Sleep(200 * time.Millisecond)
+	if _, err := session.ListTools(context.Background(), ""); err != nil {
+		t.Fatalf("ListTools returned error: %v", err)
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if len(fake.lastEventIDs) != 1 {
+		t.Errorf("stream opened %d times, want 1", len(fake.lastEventIDs))
+	}
+}
+
+func TestServersService_Connect_SSE_StreamError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/servers/missing/sse", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Server not found"}`)
+	})
+
+	_, err := client.Servers.Connect(context.Background(), "missing", &MCPSessionOptions{Transport: MCPTransportSSE})
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("Servers.Connect error = %v, want *ErrorResponse", err)
+	}
+}
+
+func TestServersService_Connect_UnsupportedTransport(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	_, err := client.Servers.Connect(context.Background(), "srv-1", &MCPSessionOptions{Transport: "STDIO"})
+	if err == nil {
+		t.Fatal("Servers.Connect expected error for unsupported transport, got nil")
+	}
+}
+
+func TestMCPSession_SSE_CallAfterClose(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	newFakeSSEMCPServer(t, mux, map[string]mcpMethodFunc{})
+	session := connectSSE(t, client)
+
+	if err := session.Close(context.Background()); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	_, err := session.ListTools(context.Background(), "")
+	if !errors.Is(err, errMCPSessionClosed) {
+		t.Errorf("ListTools after Close error = %v, want %v", err, errMCPSessionClosed)
+	}
+}
@@ -0,0 +1,452 @@
+package contextforge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// mcpMethodFunc handles one JSON-RPC method of a fake MCP server.
+type mcpMethodFunc func(params map[string]any) (any, *MCPError)
+
+// fakeMCPServer is a streamable HTTP MCP endpoint for tests. Methods listed
+// in streamed reply over an event stream instead of a JSON body.
+type fakeMCPServer struct {
+	t        *testing.T
+	methods  map[string]mcpMethodFunc
+	streamed map[string]bool
+
+	mu            sync.Mutex
+	notifications []string
+	deleted       bool
+}
+
+const fakeMCPSessionID = "session-abc"
+
+func (f *fakeMCPServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	t := f.t
+
+	if r.Method == http.MethodDelete {
+		if got := r.Header.Get("Mcp-Session-Id"); got != fakeMCPSessionID {
+			t.Errorf("DELETE Mcp-Session-Id = %q, want %q", got, fakeMCPSessionID)
+		}
+		f.mu.Lock()
+		f.deleted = true
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	testMethod(t, r, "POST")
+	if got := r.Header.Get("Accept"); got != "application/json, text/event-stream" {
+		t.Errorf("Accept = %q, want JSON and event stream", got)
+	}
+
+	var req struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params map[string]any  `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		t.Fatalf("decode request: %v", err)
+	}
+
+	if req.Method == "initialize" {
+		if got := r.Header.Get("Mcp-Session-Id"); got != "" {
+			t.Errorf("initialize sent Mcp-Session-Id %q before one was assigned", got)
+		}
+		w.Header().Set("Mcp-Session-Id", fakeMCPSessionID)
+	} else {
+		if got := r.Header.Get("Mcp-Session-Id"); got != fakeMCPSessionID {
+			t.Errorf("%s Mcp-Session-Id = %q, want %q", req.Method, got, fakeMCPSessionID)
+		}
+		if got := r.Header.Get("MCP-Protocol-Version"); got != "2025-06-18" {
+			t.Errorf("%s MCP-Protocol-Version = %q, want %q", req.Method, got, "2025-06-18")
+		}
+	}
+
+	if req.ID == nil {
+		f.mu.Lock()
+		f.notifications = append(f.notifications, req.Method)
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	handler, ok := f.methods[req.Method]
+	if !ok {
+		handler = func(map[string]any) (any, *MCPError) {
+			return nil, &MCPError{Code: -32601, Message: "Method not found"}
+		}
+	}
+	result, rpcErr := handler(req.Params)
+
+	reply := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+	if rpcErr != nil {
+		reply["error"] = rpcErr
+	} else {
+		reply["result"] = result
+	}
+	data, _ := json.Marshal(reply)
+
+	if f.streamed[req.Method] {
+		w.Header().Set("Content-Type", "text/event-stream")
+		// A progress notification precedes the response on the stream.
+		writeSSE(t, w, "", `{"jsonrpc":"2.0","method":"notifications/progress","params":{"progress":1}}`)
+		writeSSE(t, w, "", string(data))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func initializeResult(map[string]any) (any, *MCPError) {
+	return map[string]any{
+		"protocolVersion": "2025-06-18",
+		"capabilities":    map[string]any{"tools": map[string]any{"listChanged": true}},
+		"serverInfo":      map[string]any{"name": "contextforge", "version": "1.0.0"},
+	}, nil
+}
+
+// setupMCP registers a fake MCP server for server srv-1 and connects to it.
+func setupMCP(t *testing.T, methods map[string]mcpMethodFunc, streamed ...string) (*MCPSession, *fakeMCPServer) {
+	t.Helper()
+
+	client, mux, _, teardown := setup()
+	t.Cleanup(teardown)
+
+	if methods["initialize"] == nil {
+		methods["initialize"] = initializeResult
+	}
+	fake := &fakeMCPServer{t: t, methods: methods, streamed: map[string]bool{}}
+	for _, m := range streamed {
+		fake.streamed[m] = true
+	}
+	mux.Handle("/servers/srv-1/mcp", fake)
+
+	session, err := client.Servers.Connect(context.Background(), "srv-1", nil)
+	if err != nil {
+		t.Fatalf("Servers.Connect returned error: %v", err)
+	}
+	return session, fake
+}
+
+func TestServersService_Connect(t *testing.T) {
+	var initParams map[string]any
+	session, fake := setupMCP(t, map[string]mcpMethodFunc{
+		"initialize": func(params map[string]any) (any, *MCPError) {
+			initParams = params
+			return initializeResult(params)
+		},
+	})
+
+	if initParams["protocolVersion"] != DefaultMCPProtocolVersion {
+		t.Errorf("initialize protocolVersion = %v, want %q", initParams["protocolVersion"], DefaultMCPProtocolVersion)
+	}
+	if info, _ := initParams["clientInfo"].(map[string]any); info["name"] != "go-contextforge" {
+		t.Errorf("initialize clientInfo = %v, want go-contextforge", initParams["clientInfo"])
+	}
+
+	init := session.InitializeResult()
+	if init.ServerInfo == nil || init.ServerInfo.Name != "contextforge" {
+		t.Errorf("ServerInfo = %+v, want contextforge", init.ServerInfo)
+	}
+	if init.Capabilities == nil || init.Capabilities.Tools == nil {
+		t.Errorf("Capabilities = %+v, want tools capability", init.Capabilities)
+	}
+	if got := session.SessionID(); got != fakeMCPSessionID {
+		t.Errorf("SessionID() = %q, want %q", got, fakeMCPSessionID)
+	}
+	if fmt.Sprint(fake.notifications) != "[notifications/initialized]" {
+		t.Errorf("notifications = %v, want [notifications/initialized]", fake.notifications)
+	}
+
+	if err := session.Close(context.Background()); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if !fake.deleted {
+		t.Error("Close did not terminate the session")
+	}
+}
+
+func TestServersService_Connect_HTTPError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/servers/missing/mcp", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Server not found"}`)
+	})
+
+	_, err := client.Servers.Connect(context.Background(), "missing", nil)
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("Servers.Connect error = %v, want *ErrorResponse", err)
+	}
+}
+
+func TestMCPSession_ListTools(t *testing.T) {
+	session, _ := setupMCP(t, map[string]mcpMethodFunc{
+		"tools/list": func(params map[string]any) (any, *MCPError) {
+			if params["cursor"] != "page-2" {
+				t.Errorf("cursor = %v, want page-2", params["cursor"])
+			}
+			return map[string]any{
+				"tools": []any{map[string]any{
+					"name":        "search",
+					"description": "Search the web",
+					"inputSchema": map[string]any{"type": "object"},
+				}},
+				"nextCursor": "page-3",
+			}, nil
+		},
+	})
+
+	got, err := session.ListTools(context.Background(), "page-2")
+	if err != nil {
+		t.Fatalf("ListTools returned error: %v", err)
+	}
+
+	if len(got.Tools) != 1 || got.Tools[0].Name != "search" {
+		t.Fatalf("Tools = %+v, want [search]", got.Tools)
+	}
+	if got.Tools[0].InputSchema["type"] != "object" {
+		t.Errorf("InputSchema = %v, want object schema", got.Tools[0].InputSchema)
+	}
+	if got.NextCursor != "page-3" {
+		t.Errorf("NextCursor = %q, want %q", got.NextCursor, "page-3")
+	}
+}
+
+func TestMCPSession_CallTool_Streamed(t *testing.T) {
+	session, _ := setupMCP(t, map[string]mcpMethodFunc{
+		"tools/call": func(params map[string]any) (any, *MCPError) {
+			args, _ := params["arguments"].(map[string]any)
+			if params["name"] != "search" || args["q"] != "go" {
+				t.Errorf("tools/call params = %v, want search with q=go", params)
+			}
+			return map[string]any{
+				"content": []any{
+					map[string]any{"type": "text", "text": "result"},
+					map[string]any{"type": "image", "data": "aGk=", "mimeType": "image/png"},
+					map[string]any{"type": "resource", "resource": map[string]any{"uri": "file:///a.txt", "text": "a"}},
+				},
+				"structuredContent": map[string]any{"hits": 3},
+				"isError":           false,
+			}, nil
+		},
+	}, "tools/call")
+
+	got, err := session.CallTool(context.Background(), "search", map[string]any{"q": "go"})
+	if err != nil {
+		t.Fatalf("CallTool returned error: %v", err)
+	}
+
+	if len(got.Content) != 3 {
+		t.Fatalf("Content has %d blocks, want 3", len(got.Content))
+	}
+	if got.Text() != "result" {
+		t.Errorf("Text() = %q, want %q", got.Text(), "result")
+	}
+	if img := got.Content[1]; img.Type != "image" || img.Data != "aGk=" || StringValue(img.MimeType) != "image/png" {
+		t.Errorf("Content[1] = %+v, want png image", img)
+	}
+	if res := got.Content[2].Resource; res == nil || res.URI != "file:///a.txt" || StringValue(res.Text) != "a" {
+		t.Errorf("Content[2].Resource = %+v, want embedded file:///a.txt", res)
+	}
+	if sc, _ := got.StructuredContent.(map[string]any); sc["hits"] != float64(3) {
+		t.Errorf("StructuredContent = %v, want hits 3", got.StructuredContent)
+	}
+}
+
+func TestMCPSession_CallTool_ToolError(t *testing.T) {
+	session, _ := setupMCP(t, map[string]mcpMethodFunc{
+		"tools/call": func(map[string]any) (any, *MCPError) {
+			return map[string]any{
+				"content": []any{map[string]any{"type": "text", "text": "rate limited upstream"}},
+				"isError": true,
+			}, nil
+		},
+	})
+
+	got, err := session.CallTool(context.Background(), "search", nil)
+	if err != nil {
+		t.Fatalf("CallTool returned error: %v", err)
+	}
+	if !got.IsError {
+		t.Error("IsError = false, want true")
+	}
+}
+
+func TestMCPSession_ProtocolError(t *testing.T) {
+	session, _ := setupMCP(t, map[string]mcpMethodFunc{})
+
+	_, err := session.CallTool(context.Background(), "missing", nil)
+	var rpcErr *MCPError
+	if !errors.As(err, &rpcErr) {
+		t.Fatalf("CallTool error = %v, want *MCPError", err)
+	}
+	if rpcErr.Code != -32601 {
+		t.Errorf("Code = %d, want -32601", rpcErr.Code)
+	}
+}
+
+func TestMCPSession_ReadResource(t *testing.T) {
+	session, _ := setupMCP(t, map[string]mcpMethodFunc{
+		"resources/read": func(params map[string]any) (any, *MCPError) {
+			if params["uri"] != "file:///readme.md" {
+				t.Errorf("uri = %v, want file:///readme.md", params["uri"])
+			}
+			return map[string]any{
+				"contents": []any{map[string]any{"uri": "file:///readme.md", "mimeType": "text/markdown", "text": "# Hi"}},
+			}, nil
+		},
+	})
+
+	got, err := session.ReadResource(context.Background(), "file:///readme.md")
+	if err != nil {
+		t.Fatalf("ReadResource returned error: %v", err)
+	}
+	if len(got.Contents) != 1 || StringValue(got.Contents[0].Text) != "# Hi" {
+		t.Errorf("Contents = %+v, want readme text", got.Contents)
+	}
+}
+
+func TestMCPSession_GetPrompt(t *testing.T) {
+	session, _ := setupMCP(t, map[string]mcpMethodFunc{
+		"prompts/get": func(params map[string]any) (any, *MCPError) {
+			args, _ := params["arguments"].(map[string]any)
+			if params["name"] != "greet" || args["name"] != "Ada" {
+				t.Errorf("prompts/get params = %v, want greet with name=Ada", params)
+			}
+			return map[string]any{
+				"description": "A greeting",
+				"messages": []any{map[string]any{
+					"role":    "user",
+					"content": map[string]any{"type": "text", "text": "Hello, Ada"},
+				}},
+			}, nil
+		},
+	}, "prompts/get")
+
+	got, err := session.GetPrompt(context.Background(), "greet", map[string]string{"name": "Ada"})
+	if err != nil {
+		t.Fatalf("GetPrompt returned error: %v", err)
+	}
+	if StringValue(got.Description) != "A greeting" {
+		t.Errorf("Description = %q, want %q", StringValue(got.Description), "A greeting")
+	}
+	if len(got.Messages) != 1 || got.Messages[0].Content.Text != "Hello, Ada" {
+		t.Errorf("Messages = %+v, want one greeting", got.Messages)
+	}
+}
+
+func TestNewMCPSession(t *testing.T) {
+	init := &MCPInitializeResult{ProtocolVersion: DefaultMCPProtocolVersion}
+	session := NewMCPSession(init, func(ctx context.Context, method string, params json.RawMessage) (any, error) {
+		switch method {
+		case "tools/list":
+			return map[string]any{"tools": []any{map[string]any{"name": "search"}}}, nil
+		case "tools/call":
+			var p struct {
+				Name      string         `json:"name"`
+				Arguments map[string]any `json:"arguments"`
+			}
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+			return map[string]any{"content": []any{map[string]any{"type": "text", "text": p.Name + " " + p.Arguments["q"].(string)}}}, nil
+		}
+		return nil, &MCPError{Code: -32601, Message: "method not found"}
+	})
+
+	if session.InitializeResult() != init {
+		t.Errorf("InitializeResult = %v, want %v", session.InitializeResult(), init)
+	}
+	if session.SessionID() != "" {
+		t.Errorf("SessionID = %q, want empty", session.SessionID())
+	}
+
+	tools, err := session.ListTools(context.Background(), "")
+	if err != nil {
+		t.Fatalf("ListTools returned error: %v", err)
+	}
+	if len(tools.Tools) != 1 || tools.Tools[0].Name != "search" {
+		t.Errorf("ListTools = %+v, want the search tool", tools.Tools)
+	}
+
+	result, err := session.CallTool(context.Background(), "search", map[string]any{"q": "go"})
+	if err != nil {
+		t.Fatalf("CallTool returned error: %v", err)
+	}
+	if len(result.Content) != 1 || result.Content[0].Text != "search go" {
+		t.Errorf("CallTool content = %+v, want %q", result.Content, "search go")
+	}
+
+	_, err = session.ReadResource(context.Background(), "file:///a")
+	var rpcErr *MCPError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32601 {
+		t.Errorf("ReadResource error = %v, want method not found", err)
+	}
+	if err := session.Close(context.Background()); err != nil {
+		t.Errorf("Close returned error: %v", err)
+	}
+}
+
+func TestMCPSession_Zero(t *testing.T) {
+	session := &MCPSession{}
+	if _, err := session.ListTools(context.Background(), ""); err == nil {
+		t.Error("ListTools on a zero session returned no error")
+	}
+	if session.SessionID() != "" {
+		t.Errorf("SessionID = %q, want empty", session.SessionID())
+	}
+	if err := session.Close(context.Background()); err != nil {
+		t.Errorf("Close returned error: %v", err)
+	}
+}
+
+func TestMCPSession_Instrumentation(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	ri := &recordingInstrumentation{}
+	client.Instrumentation = ri
+
+	fake := &fakeMCPServer{t: t, methods: map[string]mcpMethodFunc{
+		"initialize": initializeResult,
+		"tools/call": func(map[string]any) (any, *MCPError) {
+			return map[string]any{"content": []any{}}, nil
+		},
+	}, streamed: map[string]bool{"tools/call": true}}
+	mux.Handle("/servers/srv-1/mcp", fake)
+
+	ctx := context.Background()
+	session, err := client.Servers.Connect(ctx, "srv-1", nil)
+	if err != nil {
+		t.Fatalf("Servers.Connect returned error: %v", err)
+	}
+	if _, err := session.CallTool(ctx, "search", nil); err != nil {
+		t.Fatalf("CallTool returned error: %v", err)
+	}
+	if err := session.Close(ctx); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	want := []Operation{
+		{Service: "MCP", Method: "initialize", EntityID: "srv-1"},
+		{Service: "MCP", Method: "notifications/initialized", EntityID: "srv-1"},
+		{Service: "MCP", Method: "tools/call", EntityID: "srv-1"},
+		{Service: "MCP", Method: "close", EntityID: "srv-1"},
+	}
+	if fmt.Sprint(ri.ops) != fmt.Sprint(want) {
+		t.Errorf("operations = %+v, want %+v", ri.ops, want)
+	}
+	if fmt.Sprint(ri.statuses) != "[200 202 200 204]" {
+		t.Errorf("statuses = %v, want [200 202 200 204]", ri.statuses)
+	}
+}
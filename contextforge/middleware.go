@@ -0,0 +1,97 @@
+package contextforge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// Call is an API request passing through the middleware chain of a Client.
+type Call struct {
+	// Operation identifies the service method the request is made for. It
+	// is the zero Operation for requests not made by a service method.
+	Operation Operation
+
+	// Request is the HTTP request that will be sent. Middleware may modify
+	// its headers or replace it.
+	Request *http.Request
+
+	// Body is the value that was encoded as the request body, such as the
+	// *Tool passed to ToolsService.Create, or nil if the request has none.
+	// Changes to Body are only sent after calling SetBody.
+	Body any
+}
+
+// SetBody sets the body of the call and re-encodes the request body from
+// it. Call SetBody(call.Body) to send changes made to the value in place.
+func (call *Call) SetBody(body any) error {
+	req := call.Request
+	if body == nil {
+		req.Body, req.ContentLength = http.NoBody, 0
+		req.GetBody = func() (io.ReadCloser, error) { return http.NoBody, nil }
+		req.Header.Del("Content-Type")
+		call.Body = nil
+		return nil
+	}
+
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(body); err != nil {
+		return err
+	}
+	data := buf.Bytes()
+
+	req.Body, req.ContentLength = io.NopCloser(bytes.NewReader(data)), int64(len(data))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	req.Header.Set("Content-Type", mediaTypeJSON)
+	call.Body = body
+	return nil
+}
+
+// Handler sends the request of call and decodes the response body into v,
+// with the semantics of Client.Do. It must return a non-nil Response if it
+// returns a nil error.
+//
+// Requests whose responses are streamed, those of AgentsService.InvokeStream
+// and of MCP sessions, pass through the same chain with a nil v. The body of
+// their Response is left open for the SDK to read, so middleware must not
+// read it, and middleware that short-circuits such a request must return a
+// Response with a body, such as a canned event stream.
+type Handler func(ctx context.Context, call *Call, v any) (*Response, error)
+
+// Middleware wraps the Handler that sends API requests, for example to add
+// headers, audit requests, or serve responses from a cache. A middleware
+// may modify the call before passing it to next, inspect or modify the
+// Response and error returned by next, or return without calling next to
+// short-circuit the request; when it does, it should decode its response
+// into v itself. Logging and Instrumentation happen inside the chain, so
+// short-circuited requests are neither logged nor traced.
+type Middleware func(next Handler) Handler
+
+// bodyKey is the context key under which NewRequest stores the value it
+// encoded as the request body.
+type bodyKey struct{}
+
+// newCall returns the Call for req, with the Operation and body recorded by
+// NewRequest.
+func newCall(req *http.Request) *Call {
+	call := &Call{Request: req}
+	call.Operation, _ = OperationFromContext(req.Context())
+	call.Body = req.Context().Value(bodyKey{})
+	return call
+}
+
+// chain returns the Handler that passes calls through the client's
+// middleware, the first outermost, to send.
+func (c *Client) chain(send Handler) Handler {
+	h := send
+	for i := len(c.Middleware) - 1; i >= 0; i-- {
+		h = c.Middleware[i](h)
+	}
+	return h
+}
@@ -0,0 +1,269 @@
+package contextforge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDo_MiddlewareOrder(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/tools/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"1","name":"tool"}`)
+	})
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, call *Call, v any) (*Response, error) {
+				order = append(order, name+" "+call.Operation.String())
+				resp, err := next(ctx, call, v)
+				order = append(order, fmt.Sprintf("%s %d", name, resp.StatusCode))
+				return resp, err
+			}
+		}
+	}
+	client.Middleware = []Middleware{trace("outer"), trace("inner")}
+
+	if _, _, err := client.Tools.Get(context.Background(), "1"); err != nil {
+		t.Fatalf("Tools.Get returned error: %v", err)
+	}
+
+	want := "outer Tools.Get,inner Tools.Get,inner 200,outer 200"
+	if got := strings.Join(order, ","); got != want {
+		t.Errorf("middleware ran %q, want %q", got, want)
+	}
+}
+
+func TestDo_MiddlewareModifiesCall(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/tools", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		if got := r.Header.Get("X-Tenant"); got != "acme" {
+			t.Errorf("X-Tenant header = %q, want %q", got, "acme")
+		}
+		body, _ := io.ReadAll(r.Body)
+		if r.ContentLength != int64(len(body)) {
+			t.Errorf("ContentLength = %d, want %d", r.ContentLength, len(body))
+		}
+		var got struct {
+			Tool Tool `json:"tool"`
+		}
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(w, `{"id":"1","name":%q}`, got.Tool.Name)
+	})
+
+	var seen any
+	client.Middleware = []Middleware{func(next Handler) Handler {
+		return func(ctx context.Context, call *Call, v any) (*Response, error) {
+			seen = call.Body
+			call.Request.Header.Set("X-Tenant", "acme")
+			if body, ok := call.Body.(map[string]any); ok {
+				tool := *body["tool"].(*Tool) // copy, leaving the caller's value alone
+				tool.Name = "acme-" + tool.Name
+				body["tool"] = &tool
+				if err := call.SetBody(body); err != nil {
+					return nil, err
+				}
+			}
+			return next(ctx, call, v)
+		}
+	}}
+
+	input := &Tool{Name: "search"}
+	tool, _, err := client.Tools.Create(context.Background(), input, nil)
+	if err != nil {
+		t.Fatalf("Tools.Create returned error: %v", err)
+	}
+	if _, ok := seen.(map[string]any); !ok {
+		t.Errorf("Call.Body = %T, want the map built by Tools.Create", seen)
+	}
+	if input.Name != "search" {
+		t.Errorf("caller's tool renamed to %q", input.Name)
+	}
+	if tool.Name != "acme-search" {
+		t.Errorf("Tools.Create returned name %q, want %q", tool.Name, "acme-search")
+	}
+}
+
+func TestDo_MiddlewareShortCircuit(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	client.Middleware = []Middleware{func(next Handler) Handler {
+		return func(ctx context.Context, call *Call, v any) (*Response, error) {
+			if call.Operation.String() != "Tools.Get" || call.Operation.EntityID != "cached" {
+				return next(ctx, call, v)
+			}
+			resp := &Response{Response: &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}}
+			return resp, json.Unmarshal([]byte(`{"id":"cached","name":"from-cache"}`), v)
+		}
+	}}
+
+	tool, resp, err := client.Tools.Get(context.Background(), "cached")
+	if err != nil {
+		t.Fatalf("Tools.Get returned error: %v", err)
+	}
+	if tool.Name != "from-cache" || resp.StatusCode != http.StatusOK {
+		t.Errorf("Tools.Get returned %q with status %d, want the cached tool", tool.Name, resp.StatusCode)
+	}
+
+	// Other calls reach the server, which has no handler for them.
+	if _, _, err := client.Tools.Get(context.Background(), "other"); err == nil {
+		t.Error("Tools.Get of an uncached tool returned no error")
+	}
+}
+
+func TestDo_MiddlewareReplacesError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/tools/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"detail":"Tool not found"}`)
+	})
+
+	errMissing := errors.New("missing")
+	client.Middleware = []Middleware{func(next Handler) Handler {
+		return func(ctx context.Context, call *Call, v any) (*Response, error) {
+			resp, err := next(ctx, call, v)
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				return resp, errMissing
+			}
+			return resp, err
+		}
+	}}
+
+	_, resp, err := client.Tools.Get(context.Background(), "1")
+	if !errors.Is(err, errMissing) {
+		t.Errorf("Tools.Get error = %v, want the middleware error", err)
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Tools.Get returned response %v, want the 404 response", resp)
+	}
+}
+
+func TestCall_SetBody(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	req, err := client.NewRequest(http.MethodPost, "tools", map[string]string{"name": "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	call := newCall(req)
+	if fmt.Sprint(call.Body) != "map[name:a]" {
+		t.Errorf("Call.Body = %v, want the value passed to NewRequest", call.Body)
+	}
+
+	if err := call.SetBody(map[string]string{"name": "b"}); err != nil {
+		t.Fatalf("SetBody returned error: %v", err)
+	}
+	for i := range 2 { // the body can be read again for retries
+		body, _ := req.GetBody()
+		data, _ := io.ReadAll(body)
+		if got := strings.TrimSpace(string(data)); got != `{"name":"b"}` {
+			t.Errorf("read %d: body = %s, want %s", i, got, `{"name":"b"}`)
+		}
+	}
+
+	if err := call.SetBody(nil); err != nil {
+		t.Fatalf("SetBody(nil) returned error: %v", err)
+	}
+	if req.ContentLength != 0 || req.Header.Get("Content-Type") != "" {
+		t.Errorf("after SetBody(nil), ContentLength = %d and Content-Type = %q, want neither", req.ContentLength, req.Header.Get("Content-Type"))
+	}
+	if err := call.SetBody(func() {}); err == nil {
+		t.Error("SetBody of an unencodable value returned no error")
+	}
+}
+
+func TestDoStream_Middleware(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/a2a/echo/invoke", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Tenant"); got != "acme" {
+			t.Errorf("X-Tenant header = %q, want %q", got, "acme")
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeSSE(t, w, "1", `{"kind":"message","role":"agent","messageId":"m1","parts":[]}`)
+	})
+	mux.Handle("/servers/srv-1/mcp", &fakeMCPServer{t: t, methods: map[string]mcpMethodFunc{
+		"initialize": initializeResult,
+	}, streamed: map[string]bool{}})
+
+	var ops []string
+	client.Middleware = []Middleware{func(next Handler) Handler {
+		return func(ctx context.Context, call *Call, v any) (*Response, error) {
+			ops = append(ops, call.Operation.String())
+			call.Request.Header.Set("X-Tenant", "acme")
+			return next(ctx, call, v)
+		}
+	}}
+
+	ctx := context.Background()
+	stream, _, err := client.Agents.InvokeStream(ctx, "echo", nil)
+	if err != nil {
+		t.Fatalf("Agents.InvokeStream returned error: %v", err)
+	}
+	for ev, err := range stream.Events() {
+		if err != nil {
+			t.Fatalf("Events yielded error: %v", err)
+		}
+		if ev.Message == nil || ev.Message.MessageID != "m1" {
+			t.Errorf("event = %+v, want message m1", ev)
+		}
+	}
+	if _, err := client.Servers.Connect(ctx, "srv-1", nil); err != nil {
+		t.Fatalf("Servers.Connect returned error: %v", err)
+	}
+
+	want := "Agents.InvokeStream,MCP.initialize,MCP.notifications/initialized"
+	if got := strings.Join(ops, ","); got != want {
+		t.Errorf("middleware saw %q, want %q", got, want)
+	}
+}
+
+func TestDoStream_MiddlewareShortCircuit(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	client.Middleware = []Middleware{func(next Handler) Handler {
+		return func(ctx context.Context, call *Call, v any) (*Response, error) {
+			body := "data: {\"kind\":\"message\",\"role\":\"agent\",\"messageId\":\"canned\",\"parts\":[]}\n\n"
+			return &Response{Response: &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"text/event-stream"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Request:    call.Request,
+			}}, nil
+		}
+	}}
+
+	stream, _, err := client.Agents.InvokeStream(context.Background(), "echo", nil)
+	if err != nil {
+		t.Fatalf("Agents.InvokeStream returned error: %v", err)
+	}
+	var ids []string
+	for ev, err := range stream.Events() {
+		if err != nil {
+			t.Fatalf("Events yielded error: %v", err)
+		}
+		ids = append(ids, ev.Message.MessageID)
+	}
+	if fmt.Sprint(ids) != "[canned]" {
+		t.Errorf("events = %v, want the canned message", ids)
+	}
+}
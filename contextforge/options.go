@@ -0,0 +1,154 @@
+package contextforge
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option configures a Client created by NewClientWithOptions.
+type Option func(*Client) error
+
+// WithHTTPClient sets the HTTP client used to communicate with the API.
+// A nil httpClient keeps the default client. Options that change the HTTP
+// client, such as WithTimeout and WithTransport, should come after it.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient != nil {
+			c.client = httpClient
+		}
+		return nil
+	}
+}
+
+// WithTimeout sets the overall timeout for each HTTP request.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		if timeout < 0 {
+			return fmt.Errorf("timeout must not be negative")
+		}
+		hc := *c.client
+		hc.Timeout = timeout
+		c.client = &hc
+		return nil
+	}
+}
+
+// WithTransport sets the http.RoundTripper used to make HTTP requests.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) error {
+		if transport == nil {
+			return fmt.Errorf("transport must be non-nil")
+		}
+		hc := *c.client
+		hc.Transport = transport
+		c.client = &hc
+		return nil
+	}
+}
+
+// WithBearerToken sets the static bearer token (JWT) used for authentication.
+func WithBearerToken(token string) Option {
+	return func(c *Client) error {
+		c.BearerToken = token
+		return nil
+	}
+}
+
+// WithTokenSource sets the TokenSource consulted for a bearer token on every
+// request. It takes precedence over a static bearer token.
+func WithTokenSource(ts TokenSource) Option {
+	return func(c *Client) error {
+		if ts == nil {
+			return fmt.Errorf("token source must be non-nil")
+		}
+		c.TokenSource = ts
+		return nil
+	}
+}
+
+// WithUserAgentSuffix appends suffix to the default User-Agent header, so that
+// API logs identify the calling application as well as the SDK version.
+func WithUserAgentSuffix(suffix string) Option {
+	return func(c *Client) error {
+		if suffix != "" {
+			c.UserAgent = c.UserAgent + " " + suffix
+		}
+		return nil
+	}
+}
+
+// WithHeader adds a header sent with every request. It may be repeated to
+// add several headers or several values for the same header.
+func WithHeader(key, value string) Option {
+	return func(c *Client) error {
+		if key == "" {
+			return fmt.Errorf("header key must be non-empty")
+		}
+		c.headers.Add(key, value)
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the policy used to retry failed requests.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(c *Client) error {
+		c.RetryPolicy = policy
+		return nil
+	}
+}
+
+// WithLogger sets the logger that receives the client's diagnostic output.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) error {
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithLogOptions sets the levels of the request records written to the
+// client's logger and whether they include bodies.
+func WithLogOptions(opts *LogOptions) Option {
+	return func(c *Client) error {
+		c.LogOptions = opts
+		return nil
+	}
+}
+
+// WithInstrumentation sets the instrumentation that observes every API
+// request made by the client.
+func WithInstrumentation(i Instrumentation) Option {
+	return func(c *Client) error {
+		c.Instrumentation = i
+		return nil
+	}
+}
+
+// WithMiddleware appends middleware to the chain that wraps every API
+// request. Middleware added first runs outermost.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) error {
+		c.Middleware = append(c.Middleware, mw...)
+		return nil
+	}
+}
+
+// WithBasePath appends a path prefix to the client's address, for ContextForge
+// instances served below the root of their host (for example behind a reverse
+// proxy at https://example.com/contextforge/).
+func WithBasePath(prefix string) Option {
+	return func(c *Client) error {
+		prefix = strings.Trim(prefix, "/")
+		if prefix == "" {
+			return nil
+		}
+		u, err := c.Address.Parse(prefix + "/")
+		if err != nil {
+			return fmt.Errorf("invalid base path; %w", err)
+		}
+		c.Address = u
+		return nil
+	}
+}
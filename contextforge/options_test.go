@@ -0,0 +1,181 @@
+package contextforge
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestNewClientWithOptions(t *testing.T) {
+	transport := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("not used")
+	})
+	policy := DefaultRetryPolicy()
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	logOpts := &LogOptions{Level: slog.LevelInfo, Bodies: true}
+
+	c, err := NewClientWithOptions("https://api.example.com",
+		WithBearerToken("test-token"),
+		WithTimeout(5*time.Second),
+		WithTransport(transport),
+		WithUserAgentSuffix("my-app/1.0"),
+		WithHeader("X-Tenant", "acme"),
+		WithRetryPolicy(policy),
+		WithLogger(logger),
+		WithLogOptions(logOpts),
+		WithInstrumentation(&recordingInstrumentation{}),
+		WithMiddleware(func(next Handler) Handler { return next }),
+		WithBasePath("/contextforge/"),
+	)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() unexpected error: %v", err)
+	}
+
+	if got, want := c.Address.String(), "https://api.example.com/contextforge/"; got != want {
+		t.Errorf("Address = %q, want %q", got, want)
+	}
+	if c.BearerToken != "test-token" {
+		t.Errorf("BearerToken = %q, want %q", c.BearerToken, "test-token")
+	}
+	if c.client.Timeout != 5*time.Second {
+		t.Errorf("HTTP client Timeout = %v, want %v", c.client.Timeout, 5*time.Second)
+	}
+	if c.client.Transport == nil {
+		t.Error("HTTP client Transport not set")
+	}
+	if got, want := c.UserAgent, userAgent+" my-app/1.0"; got != want {
+		t.Errorf("UserAgent = %q, want %q", got, want)
+	}
+	if c.RetryPolicy != policy {
+		t.Error("RetryPolicy not set")
+	}
+	if c.Logger != logger {
+		t.Error("Logger not set")
+	}
+	if c.LogOptions != logOpts {
+		t.Error("LogOptions not set")
+	}
+	if c.Instrumentation == nil {
+		t.Error("Instrumentation not set")
+	}
+	if len(c.Middleware) != 1 {
+		t.Errorf("len(Middleware) = %d, want 1", len(c.Middleware))
+	}
+	if c.Tools == nil || c.Teams == nil || c.Cancel == nil {
+		t.Error("services not initialized")
+	}
+
+	req, err := c.NewRequest("GET", "tools", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() unexpected error: %v", err)
+	}
+	if got := req.Header.Get("X-Tenant"); got != "acme" {
+		t.Errorf("X-Tenant header = %q, want %q", got, "acme")
+	}
+	if got, want := req.URL.String(), "https://api.example.com/contextforge/tools"; got != want {
+		t.Errorf("request URL = %q, want %q", got, want)
+	}
+}
+
+func TestNewClientWithOptions_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		opts    []Option
+		wantErr string
+	}{
+		{"invalid address", "://invalid-url", nil, "invalid address"},
+		{"negative timeout", "http://localhost:8000", []Option{WithTimeout(-time.Second)}, "timeout"},
+		{"nil transport", "http://localhost:8000", []Option{WithTransport(nil)}, "transport"},
+		{"nil token source", "http://localhost:8000", []Option{WithTokenSource(nil)}, "token source"},
+		{"empty header key", "http://localhost:8000", []Option{WithHeader("", "v")}, "header key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClientWithOptions(tt.address, tt.opts...)
+			if err == nil {
+				t.Fatal("NewClientWithOptions() expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewClientWithOptions() error = %q, want to contain %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithTimeout_DoesNotMutateCallerClient(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Minute}
+
+	c, err := NewClientWithOptions("http://localhost:8000/", WithHTTPClient(httpClient), WithTimeout(time.Second))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() unexpected error: %v", err)
+	}
+
+	if httpClient.Timeout != time.Minute {
+		t.Errorf("caller's http.Client Timeout = %v, want %v", httpClient.Timeout, time.Minute)
+	}
+	if c.client.Timeout != time.Second {
+		t.Errorf("client Timeout = %v, want %v", c.client.Timeout, time.Second)
+	}
+}
+
+func TestWithBasePath(t *testing.T) {
+	tests := []struct {
+		address string
+		prefix  string
+		want    string
+	}{
+		{"http://localhost:8000/", "", "http://localhost:8000/"},
+		{"http://localhost:8000/", "api", "http://localhost:8000/api/"},
+		{"http://localhost:8000/", "/api/v1/", "http://localhost:8000/api/v1/"},
+		{"http://localhost:8000/proxy/", "api", "http://localhost:8000/proxy/api/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			c, err := NewClientWithOptions(tt.address, WithBasePath(tt.prefix))
+			if err != nil {
+				t.Fatalf("NewClientWithOptions() unexpected error: %v", err)
+			}
+			if got := c.Address.String(); got != tt.want {
+				t.Errorf("Address = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithTokenSource(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		fmt.Fprint(w, `[]`)
+	}))
+	defer server.Close()
+
+	c, err := NewClientWithOptions(server.URL,
+		WithBearerToken("static-token"),
+		WithTokenSource(StaticTokenSource("dynamic-token")),
+	)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() unexpected error: %v", err)
+	}
+
+	if _, _, err := c.Tools.List(context.Background(), nil); err != nil {
+		t.Fatalf("Tools.List returned error: %v", err)
+	}
+	if gotAuth != "Bearer dynamic-token" {
+		t.Errorf("Authorization = %q, want %q", gotAuth, "Bearer dynamic-token")
+	}
+}
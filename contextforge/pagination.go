@@ -0,0 +1,113 @@
+package contextforge
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// cursorPageFunc fetches a single page of items starting at the given cursor.
+type cursorPageFunc[T any] func(ctx context.Context, cursor string) ([]*T, *Response, error)
+
+// allByCursor returns an iterator that walks every page produced by fetch,
+// following Response.NextCursor until it is empty. Iteration stops after
+// maxItems items when maxItems is greater than zero.
+//
+// Errors (including ctx cancellation) are yielded once as the final element
+// of the sequence.
+func allByCursor[T any](ctx context.Context, cursor string, maxItems int, fetch cursorPageFunc[T]) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		count := 0
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			items, resp, err := fetch(ctx, cursor)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, item := range items {
+				if maxItems > 0 && count >= maxItems {
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+				count++
+			}
+
+			if maxItems > 0 && count >= maxItems {
+				return
+			}
+			if resp == nil || resp.NextCursor == "" {
+				return
+			}
+			if resp.NextCursor == cursor {
+				yield(nil, fmt.Errorf("pagination cursor %q did not advance", cursor))
+				return
+			}
+			cursor = resp.NextCursor
+		}
+	}
+}
+
+// offsetPageFunc fetches a single page of items starting at the given skip offset.
+type offsetPageFunc[T any] func(ctx context.Context, skip int) ([]*T, *Response, error)
+
+// allByOffset returns an iterator that walks every page produced by fetch,
+// advancing skip by the number of items returned on each page. Iteration ends
+// when Response.Total is reached, when a page is empty, or, if the endpoint
+// does not report a total, when a page holds fewer than limit items. Iteration
+// stops after maxItems items when maxItems is greater than zero.
+//
+// Errors (including ctx cancellation) are yielded once as the final element
+// of the sequence.
+func allByOffset[T any](ctx context.Context, skip, limit, maxItems int, fetch offsetPageFunc[T]) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		count := 0
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			items, resp, err := fetch(ctx, skip)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, item := range items {
+				if maxItems > 0 && count >= maxItems {
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+				count++
+			}
+
+			if maxItems > 0 && count >= maxItems {
+				return
+			}
+			if len(items) == 0 {
+				return
+			}
+			skip += len(items)
+
+			if resp != nil && resp.Total > 0 {
+				if skip >= resp.Total {
+					return
+				}
+				continue
+			}
+			if limit > 0 && len(items) < limit {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,454 @@
+package contextforge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestToolsService_All(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/tools", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		switch cursor := r.URL.Query().Get("cursor"); cursor {
+		case "":
+			// First page advertises the next cursor via header.
+			w.Header().Set("X-Next-Cursor", "page-2")
+			fmt.Fprint(w, `[{"id":"1","name":"tool-one"},{"id":"2","name":"tool-two"}]`)
+		case "page-2":
+			// Second page advertises the next cursor via the envelope.
+			fmt.Fprint(w, `{"tools":[{"id":"3","name":"tool-three"}],"nextCursor":"page-3"}`)
+		case "page-3":
+			fmt.Fprint(w, `{"tools":[{"id":"4","name":"tool-four"}],"nextCursor":""}`)
+		default:
+			t.Errorf("unexpected cursor %q", cursor)
+		}
+	})
+
+	var ids []string
+	for tool, err := range client.Tools.All(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("Tools.All returned error: %v", err)
+		}
+		ids = append(ids, tool.ID)
+	}
+
+	want := []string{"1", "2", "3", "4"}
+	if fmt.Sprint(ids) != fmt.Sprint(want) {
+		t.Errorf("Tools.All yielded %v, want %v", ids, want)
+	}
+	if calls != 3 {
+		t.Errorf("Tools.All made %d requests, want 3", calls)
+	}
+}
+
+func TestToolsService_All_MaxItems(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/tools", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"tools":[{"id":"1"},{"id":"2"}],"nextCursor":"more"}`)
+	})
+
+	opts := &ToolListOptions{ListOptions: ListOptions{MaxItems: 3}}
+	count := 0
+	for _, err := range client.Tools.All(context.Background(), opts) {
+		if err != nil {
+			t.Fatalf("Tools.All returned error: %v", err)
+		}
+		count++
+	}
+
+	if count != 3 {
+		t.Errorf("Tools.All yielded %d items, want 3", count)
+	}
+	if calls != 2 {
+		t.Errorf("Tools.All made %d requests, want 2", calls)
+	}
+	if opts.Cursor != "" {
+		t.Errorf("Tools.All modified caller options; Cursor = %q", opts.Cursor)
+	}
+}
+
+func TestToolsService_All_MaxItemsNotSent(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/tools", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("max_items") || r.URL.Query().Has("MaxItems") {
+			t.Errorf("MaxItems leaked into query string: %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `[]`)
+	})
+
+	for _, err := range client.Tools.All(context.Background(), &ToolListOptions{ListOptions: ListOptions{MaxItems: 5}}) {
+		if err != nil {
+			t.Fatalf("Tools.All returned error: %v", err)
+		}
+	}
+}
+
+func TestToolsService_All_BreakStopsFetching(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/tools", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-Next-Cursor", fmt.Sprintf("c%d", calls))
+		fmt.Fprint(w, `[{"id":"1"},{"id":"2"}]`)
+	})
+
+	for range client.Tools.All(context.Background(), nil) {
+		break
+	}
+
+	if calls != 1 {
+		t.Errorf("Tools.All made %d requests after break, want 1", calls)
+	}
+}
+
+func TestToolsService_All_Error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/tools", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			w.Header().Set("X-Next-Cursor", "broken")
+			fmt.Fprint(w, `[{"id":"1"}]`)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"message":"boom"}`)
+	})
+
+	var (
+		count   int
+		lastErr error
+	)
+	for tool, err := range client.Tools.All(context.Background(), nil) {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if tool == nil {
+			t.Fatal("Tools.All yielded nil tool without error")
+		}
+		count++
+	}
+
+	if count != 1 {
+		t.Errorf("Tools.All yielded %d items before error, want 1", count)
+	}
+	var errResp *ErrorResponse
+	if !errors.As(lastErr, &errResp) {
+		t.Fatalf("Tools.All error = %v, want *ErrorResponse", lastErr)
+	}
+}
+
+func TestToolsService_All_StuckCursor(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/tools", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Next-Cursor", "same")
+		fmt.Fprint(w, `[{"id":"1"}]`)
+	})
+
+	var lastErr error
+	for _, err := range client.Tools.All(context.Background(), &ToolListOptions{ListOptions: ListOptions{Cursor: "same"}}) {
+		if err != nil {
+			lastErr = err
+		}
+	}
+
+	if lastErr == nil {
+		t.Fatal("Tools.All expected error for non-advancing cursor, got nil")
+	}
+}
+
+func TestToolsService_All_ContextCanceled(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := 0
+	mux.HandleFunc("/tools", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-Next-Cursor", fmt.Sprintf("c%d", calls))
+		fmt.Fprint(w, `[{"id":"1"}]`)
+	})
+
+	var lastErr error
+	for _, err := range client.Tools.All(ctx, nil) {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		cancel()
+	}
+
+	if !errors.Is(lastErr, context.Canceled) {
+		t.Errorf("Tools.All error = %v, want %v", lastErr, context.Canceled)
+	}
+	if calls != 1 {
+		t.Errorf("Tools.All made %d requests after cancel, want 1", calls)
+	}
+}
+
+func TestListServices_All(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	endpoints := map[string]string{
+		"/resources": "resources",
+		"/gateways":  "gateways",
+		"/servers":   "servers",
+		"/prompts":   "prompts",
+		"/a2a":       "agents",
+	}
+	for path, key := range endpoints {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("cursor") == "" {
+				fmt.Fprintf(w, `{"%s":[{"id":"a","name":"a"}],"nextCursor":"next"}`, key)
+				return
+			}
+			fmt.Fprintf(w, `{"%s":[{"id":"b","name":"b"}]}`, key)
+		})
+	}
+
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		seq  func(yield func(string, error) bool)
+	}{
+		{"Resources", func(yield func(string, error) bool) {
+			for v, err := range client.Resources.All(ctx, nil) {
+				if err != nil {
+					yield("", err)
+					return
+				}
+				if !yield(v.Name, nil) {
+					return
+				}
+			}
+		}},
+		{"Gateways", func(yield func(string, error) bool) {
+			for v, err := range client.Gateways.All(ctx, nil) {
+				if err != nil {
+					yield("", err)
+					return
+				}
+				if !yield(v.Name, nil) {
+					return
+				}
+			}
+		}},
+		{"Servers", func(yield func(string, error) bool) {
+			for v, err := range client.Servers.All(ctx, nil) {
+				if err != nil {
+					yield("", err)
+					return
+				}
+				if !yield(v.Name, nil) {
+					return
+				}
+			}
+		}},
+		{"Prompts", func(yield func(string, error) bool) {
+			for v, err := range client.Prompts.All(ctx, nil) {
+				if err != nil {
+					yield("", err)
+					return
+				}
+				if !yield(v.Name, nil) {
+					return
+				}
+			}
+		}},
+		{"Agents", func(yield func(string, error) bool) {
+			for v, err := range client.Agents.All(ctx, nil) {
+				if err != nil {
+					yield("", err)
+					return
+				}
+				if !yield(v.Name, nil) {
+					return
+				}
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for name, err := range tt.seq {
+				if err != nil {
+					t.Fatalf("%s.All returned error: %v", tt.name, err)
+				}
+				names = append(names, name)
+			}
+			if fmt.Sprint(names) != "[a b]" {
+				t.Errorf("%s.All yielded %v, want [a b]", tt.name, names)
+			}
+		})
+	}
+}
+
+func TestTeamsService_List_Total(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/teams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"teams":[{"id":"1"}],"total":42}`)
+	})
+
+	_, resp, err := client.Teams.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Teams.List returned error: %v", err)
+	}
+	if resp.Total != 42 {
+		t.Errorf("Response.Total = %d, want 42", resp.Total)
+	}
+}
+
+func TestTeamsService_All(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var skips []string
+	mux.HandleFunc("/teams", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		skip := r.URL.Query().Get("skip")
+		skips = append(skips, skip)
+		w.Header().Set("Content-Type", "application/json")
+		switch skip {
+		case "":
+			fmt.Fprint(w, `{"teams":[{"id":"1"},{"id":"2"}],"total":5}`)
+		case "2":
+			fmt.Fprint(w, `{"teams":[{"id":"3"},{"id":"4"}],"total":5}`)
+		case "4":
+			fmt.Fprint(w, `{"teams":[{"id":"5"}],"total":5}`)
+		default:
+			t.Errorf("unexpected skip %q", skip)
+			fmt.Fprint(w, `{"teams":[],"total":5}`)
+		}
+	})
+
+	var ids []string
+	for team, err := range client.Teams.All(context.Background(), &TeamListOptions{Limit: 2}) {
+		if err != nil {
+			t.Fatalf("Teams.All returned error: %v", err)
+		}
+		ids = append(ids, team.ID)
+	}
+
+	if got, want := fmt.Sprint(ids), "[1 2 3 4 5]"; got != want {
+		t.Errorf("Teams.All yielded %v, want %v", got, want)
+	}
+	if got, want := fmt.Sprint(skips), "[ 2 4]"; got != want {
+		t.Errorf("Teams.All requested skips %v, want %v", got, want)
+	}
+}
+
+func TestTeamsService_All_MaxItems(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/teams", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"teams":[{"id":"1"},{"id":"2"}],"total":100}`)
+	})
+
+	count := 0
+	for _, err := range client.Teams.All(context.Background(), &TeamListOptions{MaxItems: 3}) {
+		if err != nil {
+			t.Fatalf("Teams.All returned error: %v", err)
+		}
+		count++
+	}
+
+	if count != 3 {
+		t.Errorf("Teams.All yielded %d items, want 3", count)
+	}
+	if calls != 2 {
+		t.Errorf("Teams.All made %d requests, want 2", calls)
+	}
+}
+
+func TestTeamsService_DiscoverAll(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/teams/discover", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("skip") {
+		case "":
+			fmt.Fprint(w, `[{"id":"1"},{"id":"2"}]`)
+		case "2":
+			fmt.Fprint(w, `[{"id":"3"}]`)
+		default:
+			t.Errorf("unexpected skip %q", r.URL.Query().Get("skip"))
+			fmt.Fprint(w, `[]`)
+		}
+	})
+
+	var ids []string
+	for team, err := range client.Teams.DiscoverAll(context.Background(), &TeamDiscoverOptions{Limit: 2}) {
+		if err != nil {
+			t.Fatalf("Teams.DiscoverAll returned error: %v", err)
+		}
+		ids = append(ids, team.ID)
+	}
+
+	if got, want := fmt.Sprint(ids), "[1 2 3]"; got != want {
+		t.Errorf("Teams.DiscoverAll yielded %v, want %v", got, want)
+	}
+}
+
+func TestAgentsService_AllBySkip(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/a2a", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("cursor") != "" {
+			t.Errorf("cursor = %q, want empty", q.Get("cursor"))
+		}
+		switch q.Get("skip") {
+		case "":
+			fmt.Fprint(w, `{"agents":[{"id":"a"},{"id":"b"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"agents":[]}`)
+		default:
+			t.Errorf("unexpected skip %q", q.Get("skip"))
+			fmt.Fprint(w, `[]`)
+		}
+	})
+
+	var ids []string
+	for agent, err := range client.Agents.AllBySkip(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("Agents.AllBySkip returned error: %v", err)
+		}
+		ids = append(ids, agent.ID)
+	}
+
+	if got, want := fmt.Sprint(ids), "[a b]"; got != want {
+		t.Errorf("Agents.AllBySkip yielded %v, want %v", got, want)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -27,7 +28,7 @@ func (s *PromptsService) List(ctx context.Context, opts *PromptListOptions) ([]*
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Prompts", Method: "List"}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -49,6 +50,22 @@ func (s *PromptsService) List(ctx context.Context, opts *PromptListOptions) ([]*
 	return prompts, resp, nil
 }
 
+// All returns an iterator over every prompt matching opts, following
+// pagination cursors until the result set is exhausted or opts.MaxItems
+// prompts have been yielded. Iteration stops with ctx.Err() if ctx is done.
+func (s *PromptsService) All(ctx context.Context, opts *PromptListOptions) iter.Seq2[*Prompt, error] {
+	reqOpts := &PromptListOptions{}
+	if opts != nil {
+		*reqOpts = *opts
+	}
+
+	return allByCursor(ctx, reqOpts.Cursor, reqOpts.MaxItems, func(ctx context.Context, cursor string) ([]*Prompt, *Response, error) {
+		pageOpts := *reqOpts
+		pageOpts.Cursor = cursor
+		return s.List(ctx, &pageOpts)
+	})
+}
+
 // Get retrieves a prompt by ID and renders it with the provided arguments.
 // This is a hybrid REST endpoint (POST /prompts/{id}) that provides MCP prompts/get functionality via REST.
 func (s *PromptsService) Get(ctx context.Context, promptID string, args map[string]string) (*PromptResult, *Response, error) {
@@ -60,7 +77,7 @@ func (s *PromptsService) Get(ctx context.Context, promptID string, args map[stri
 		body = make(map[string]string)
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, body)
+	req, err := s.client.newRequest(Operation{Service: "Prompts", Method: "Get"}, http.MethodPost, u, body)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -79,7 +96,7 @@ func (s *PromptsService) Get(ctx context.Context, promptID string, args map[stri
 func (s *PromptsService) GetNoArgs(ctx context.Context, promptID string) (*PromptResult, *Response, error) {
 	u := fmt.Sprintf("prompts/%s", promptID)
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Prompts", Method: "GetNoArgs"}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -113,7 +130,7 @@ func (s *PromptsService) Create(ctx context.Context, prompt *PromptCreate, opts
 		}
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, body)
+	req, err := s.client.newRequest(Operation{Service: "Prompts", Method: "Create"}, http.MethodPost, u, body)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -133,7 +150,7 @@ func (s *PromptsService) Create(ctx context.Context, prompt *PromptCreate, opts
 func (s *PromptsService) Update(ctx context.Context, promptID string, prompt *PromptUpdate) (*Prompt, *Response, error) {
 	u := fmt.Sprintf("prompts/%s", promptID)
 
-	req, err := s.client.NewRequest(http.MethodPut, u, prompt)
+	req, err := s.client.newRequest(Operation{Service: "Prompts", Method: "Update"}, http.MethodPut, u, prompt)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -152,7 +169,7 @@ func (s *PromptsService) Update(ctx context.Context, promptID string, prompt *Pr
 func (s *PromptsService) Delete(ctx context.Context, promptID string) (*Response, error) {
 	u := fmt.Sprintf("prompts/%s", promptID)
 
-	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Prompts", Method: "Delete"}, http.MethodDelete, u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -167,19 +184,19 @@ func (s *PromptsService) Delete(ctx context.Context, promptID string) (*Response
 
 // SetState sets a prompt's active status using the preferred /state endpoint.
 func (s *PromptsService) SetState(ctx context.Context, promptID string, activate bool) (*Prompt, *Response, error) {
-	return s.setState(ctx, promptID, activate, "state")
+	return s.setState(ctx, "SetState", promptID, activate, "state")
 }
 
 // Toggle toggles a prompt's active status using the legacy /toggle endpoint.
 // Note: promptID changed from int to string in v1.0.0.
 func (s *PromptsService) Toggle(ctx context.Context, promptID string, activate bool) (*Prompt, *Response, error) {
-	return s.setState(ctx, promptID, activate, "toggle")
+	return s.setState(ctx, "Toggle", promptID, activate, "toggle")
 }
 
-func (s *PromptsService) setState(ctx context.Context, promptID string, activate bool, endpoint string) (*Prompt, *Response, error) {
+func (s *PromptsService) setState(ctx context.Context, method, promptID string, activate bool, endpoint string) (*Prompt, *Response, error) {
 	u := fmt.Sprintf("prompts/%s/%s?activate=%t", promptID, endpoint, activate)
 
-	req, err := s.client.NewRequest(http.MethodPost, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Prompts", Method: method}, http.MethodPost, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
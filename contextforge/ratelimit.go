@@ -0,0 +1,101 @@
+package contextforge
+
+import (
+	"context"
+	"io"
+	"maps"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimits returns a copy of the most recent rate limit information the
+// client has observed, keyed by request URL path.
+func (c *Client) RateLimits() map[string]Rate {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+
+	return maps.Clone(c.rateLimits)
+}
+
+// recordRate stores rate limit information observed in resp for path. When
+// resp is a 429 carrying a Retry-After header, the path is marked as exhausted
+// until the Retry-After time so that later requests wait for it.
+func (c *Client) recordRate(path string, resp *http.Response) {
+	rate := parseRate(resp)
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if retryAfter, ok := parseRetryAfter(resp); ok {
+			rate.Remaining = 0
+			if until := time.Now().Add(retryAfter); until.After(rate.Reset) {
+				rate.Reset = until
+			}
+		}
+	}
+
+	c.rateMu.Lock()
+	c.rateLimits[path] = rate
+	c.rateMu.Unlock()
+}
+
+// waitForRateLimit blocks until the rate limit window for req's path resets,
+// if the last observed Rate for that path has no requests remaining. It is a
+// no-op unless c.RespectRateLimits is set.
+//
+// If ctx would expire before the window resets, waitForRateLimit returns a
+// *RateLimitError immediately without making a remote request.
+func (c *Client) waitForRateLimit(ctx context.Context, req *http.Request) error {
+	if !c.RespectRateLimits {
+		return nil
+	}
+
+	c.rateMu.Lock()
+	rate, ok := c.rateLimits[req.URL.Path]
+	c.rateMu.Unlock()
+	if !ok || rate.Remaining > 0 || rate.Reset.IsZero() {
+		return nil
+	}
+
+	wait := time.Until(rate.Reset)
+	if wait <= 0 {
+		return nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(rate.Reset) {
+		return &RateLimitError{
+			Rate: rate,
+			Response: &http.Response{
+				Status:     http.StatusText(http.StatusTooManyRequests),
+				StatusCode: http.StatusTooManyRequests,
+				Request:    req,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader("")),
+			},
+			Message: "API rate limit exhausted until reset; not making remote request",
+		}
+	}
+
+	return sleep(ctx, wait)
+}
+
+// parseRetryAfter parses the Retry-After header of r, which may hold either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(r *http.Response) (time.Duration, bool) {
+	v := r.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			secs = 0
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+
+	return 0, false
+}
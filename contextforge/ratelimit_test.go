@@ -0,0 +1,225 @@
+package contextforge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_RateLimits(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/tools", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		fmt.Fprint(w, `[]`)
+	})
+
+	if _, _, err := client.Tools.List(context.Background(), nil); err != nil {
+		t.Fatalf("Tools.List returned error: %v", err)
+	}
+
+	limits := client.RateLimits()
+	rate, ok := limits["/tools"]
+	if !ok {
+		t.Fatalf("RateLimits() = %v, want entry for /tools", limits)
+	}
+	if rate.Limit != 100 || rate.Remaining != 99 {
+		t.Errorf("RateLimits()[/tools] = %+v, want Limit 100, Remaining 99", rate)
+	}
+
+	// The returned map must be a copy.
+	limits["/tools"] = Rate{}
+	if got := client.RateLimits()["/tools"]; got.Limit != 100 {
+		t.Errorf("RateLimits() returned internal map; mutation leaked: %+v", got)
+	}
+}
+
+func TestDo_RespectRateLimits_WaitsForReset(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RespectRateLimits = true
+
+	mux.HandleFunc("/tools/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"1"}`)
+	})
+
+	reset := time.Now().Add(100 * time.Millisecond)
+	client.rateLimits["/tools/1"] = Rate{Limit: 10, Remaining: 0, Reset: reset}
+
+	if _, _, err := client.Tools.Get(context.Background(), "1"); err != nil {
+		t.Fatalf("Tools.Get returned error: %v", err)
+	}
+	if now := time.Now(); now.Before(reset) {
+		t.Errorf("Tools.Get returned at %v, before rate limit reset at %v", now, reset)
+	}
+}
+
+func TestDo_RespectRateLimits_Disabled(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/tools/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"1"}`)
+	})
+
+	client.rateLimits["/tools/1"] = Rate{Limit: 10, Remaining: 0, Reset: time.Now().Add(time.Hour)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, _, err := client.Tools.Get(ctx, "1"); err != nil {
+		t.Fatalf("Tools.Get returned error: %v", err)
+	}
+}
+
+func TestDo_RespectRateLimits_DeadlineBeforeReset(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RespectRateLimits = true
+
+	called := false
+	mux.HandleFunc("/tools/1", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		fmt.Fprint(w, `{"id":"1"}`)
+	})
+
+	client.rateLimits["/tools/1"] = Rate{Limit: 10, Remaining: 0, Reset: time.Now().Add(time.Hour)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, _, err := client.Tools.Get(ctx, "1")
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("Tools.Get error = %v, want *RateLimitError", err)
+	}
+	if called {
+		t.Error("Tools.Get made a remote request while rate limited")
+	}
+}
+
+func TestDo_RespectRateLimits_ContextCanceled(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+	client.RespectRateLimits = true
+
+	client.rateLimits["/tools/1"] = Rate{Limit: 10, Remaining: 0, Reset: time.Now().Add(time.Hour)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	_, _, err := client.Tools.Get(ctx, "1")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Tools.Get error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestDo_RespectRateLimits_RetryAfter(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RespectRateLimits = true
+
+	calls := 0
+	mux.HandleFunc("/tools", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"id":"1","name":"t"}`)
+	})
+
+	// POST is not retried by a retry policy, but a 429 means the request was
+	// not processed, so the limiter retries it once.
+	_, resp, err := client.Tools.Create(context.Background(), &Tool{Name: "t"}, nil)
+	if err != nil {
+		t.Fatalf("Tools.Create returned error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("server saw %d calls, want 2", calls)
+	}
+	if resp.Attempts != 2 {
+		t.Errorf("Response.Attempts = %d, want 2", resp.Attempts)
+	}
+}
+
+func TestDo_RetryAfterIgnoredWhenLimiterDisabled(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/tools", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	_, _, err := client.Tools.List(context.Background(), nil)
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("Tools.List error = %v, want *RateLimitError", err)
+	}
+	if calls != 1 {
+		t.Errorf("server saw %d calls, want 1", calls)
+	}
+}
+
+func TestRecordRate_RetryAfterMarksPathExhausted(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"30"}},
+	}
+	client.recordRate("/tools", resp)
+
+	rate := client.RateLimits()["/tools"]
+	if rate.Remaining != 0 {
+		t.Errorf("Remaining = %d, want 0", rate.Remaining)
+	}
+	if until := time.Until(rate.Reset); until < 29*time.Second || until > 31*time.Second {
+		t.Errorf("Reset in %v, want about 30s", until)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	future := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+
+	tests := []struct {
+		name   string
+		value  string
+		wantOK bool
+		min    time.Duration
+		max    time.Duration
+	}{
+		{"missing", "", false, 0, 0},
+		{"seconds", "5", true, 5 * time.Second, 5 * time.Second},
+		{"negative seconds", "-5", true, 0, 0},
+		{"http date", future, true, 58 * time.Second, time.Minute},
+		{"past http date", "Mon, 02 Jan 2006 15:04:05 GMT", true, 0, 0},
+		{"invalid", "soon", false, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.value != "" {
+				resp.Header.Set("Retry-After", tt.value)
+			}
+
+			got, ok := parseRetryAfter(resp)
+			if ok != tt.wantOK {
+				t.Fatalf("parseRetryAfter() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if got < tt.min || got > tt.max {
+				t.Errorf("parseRetryAfter() = %v, want between %v and %v", got, tt.min, tt.max)
+			}
+		})
+	}
+}
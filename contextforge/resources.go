@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -45,7 +46,7 @@ func (s *ResourcesService) List(ctx context.Context, opts *ResourceListOptions)
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Resources", Method: "List"}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -70,12 +71,28 @@ func (s *ResourcesService) List(ctx context.Context, opts *ResourceListOptions)
 	return resources, resp, nil
 }
 
+// All returns an iterator over every resource matching opts, following
+// pagination cursors until the result set is exhausted or opts.MaxItems
+// resources have been yielded. Iteration stops with ctx.Err() if ctx is done.
+func (s *ResourcesService) All(ctx context.Context, opts *ResourceListOptions) iter.Seq2[*Resource, error] {
+	reqOpts := &ResourceListOptions{}
+	if opts != nil {
+		*reqOpts = *opts
+	}
+
+	return allByCursor(ctx, reqOpts.Cursor, reqOpts.MaxItems, func(ctx context.Context, cursor string) ([]*Resource, *Response, error) {
+		pageOpts := *reqOpts
+		pageOpts.Cursor = cursor
+		return s.List(ctx, &pageOpts)
+	})
+}
+
 // Get retrieves the content of a specific resource by its ID.
 // This is a hybrid REST endpoint that returns resource content in MCP-compatible format.
 func (s *ResourcesService) Get(ctx context.Context, resourceID string) (*ResourceContent, *Response, error) {
 	u := fmt.Sprintf("resources/%s", url.PathEscape(resourceID))
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Resources", Method: "Get", EntityID: resourceID}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -98,7 +115,7 @@ func (s *ResourcesService) GetInfo(ctx context.Context, resourceID string, opts
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Resources", Method: "GetInfo", EntityID: resourceID}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -132,7 +149,7 @@ func (s *ResourcesService) Create(ctx context.Context, resource *ResourceCreate,
 		}
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, body)
+	req, err := s.client.newRequest(Operation{Service: "Resources", Method: "Create"}, http.MethodPost, u, body)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -152,7 +169,7 @@ func (s *ResourcesService) Update(ctx context.Context, resourceID string, resour
 	u := fmt.Sprintf("resources/%s", url.PathEscape(resourceID))
 
 	// No wrapper for update (direct ResourceUpdate object)
-	req, err := s.client.NewRequest(http.MethodPut, u, resource)
+	req, err := s.client.newRequest(Operation{Service: "Resources", Method: "Update", EntityID: resourceID}, http.MethodPut, u, resource)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -170,7 +187,7 @@ func (s *ResourcesService) Update(ctx context.Context, resourceID string, resour
 func (s *ResourcesService) Delete(ctx context.Context, resourceID string) (*Response, error) {
 	u := fmt.Sprintf("resources/%s", url.PathEscape(resourceID))
 
-	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Resources", Method: "Delete", EntityID: resourceID}, http.MethodDelete, u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -212,18 +229,18 @@ type toggleResourceResponse struct {
 
 // SetState enables or disables a resource using the preferred /state endpoint.
 func (s *ResourcesService) SetState(ctx context.Context, resourceID string, activate bool) (*Resource, *Response, error) {
-	return s.setState(ctx, resourceID, activate, "state")
+	return s.setState(ctx, "SetState", resourceID, activate, "state")
 }
 
 // Toggle enables or disables a resource using the legacy /toggle endpoint.
 func (s *ResourcesService) Toggle(ctx context.Context, resourceID string, activate bool) (*Resource, *Response, error) {
-	return s.setState(ctx, resourceID, activate, "toggle")
+	return s.setState(ctx, "Toggle", resourceID, activate, "toggle")
 }
 
-func (s *ResourcesService) setState(ctx context.Context, resourceID string, activate bool, endpoint string) (*Resource, *Response, error) {
+func (s *ResourcesService) setState(ctx context.Context, method, resourceID string, activate bool, endpoint string) (*Resource, *Response, error) {
 	u := fmt.Sprintf("resources/%s/%s?activate=%t", url.PathEscape(resourceID), endpoint, activate)
 
-	req, err := s.client.NewRequest(http.MethodPost, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Resources", Method: method, EntityID: resourceID}, http.MethodPost, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -281,7 +298,7 @@ func (s *ResourcesService) setState(ctx context.Context, resourceID string, acti
 func (s *ResourcesService) ListTemplates(ctx context.Context) (*ListResourceTemplatesResult, *Response, error) {
 	u := "resources/templates/list"
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Resources", Method: "ListTemplates"}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,233 @@
+package contextforge
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// RetryPolicy configures automatic retries in Client.Do.
+//
+// A nil policy (the default) disables retries, so every request is sent
+// exactly once. Requests are only retried when the request body can be
+// rewound, which is always the case for requests built by Client.NewRequest.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values less than 2 disable retries.
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry. Each subsequent retry
+	// doubles the delay, up to MaxBackoff.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the delay between attempts.
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction (0 to 1) of each delay that is randomized, to
+	// avoid many clients retrying in lockstep. Zero disables jitter.
+	Jitter float64
+
+	// RetryableStatusCodes lists the HTTP status codes that trigger a retry.
+	// Transport errors are always considered retryable.
+	RetryableStatusCodes []int
+
+	// RetryNonIdempotent allows retrying POST and PATCH requests. By default
+	// only idempotent methods (GET, HEAD, OPTIONS, PUT, DELETE) are retried.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy suitable for most callers: three
+// attempts with jittered exponential backoff between 500ms and 30s, retrying
+// idempotent requests on 429, 502, 503 and 504 responses.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.5,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// attemptsFor returns the number of attempts allowed for req.
+func (p *RetryPolicy) attemptsFor(req *http.Request) int {
+	if p == nil || p.MaxAttempts < 2 {
+		return 1
+	}
+	if !canRewind(req) {
+		return 1
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(req.Method) {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// shouldRetry reports whether an attempt that produced resp and err should
+// be retried.
+func (p *RetryPolicy) shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return slices.Contains(p.RetryableStatusCodes, resp.StatusCode)
+}
+
+// backoff returns the delay to wait after the given (1-based) attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 && d > 0 {
+		jitter := min(p.Jitter, 1)
+		d -= time.Duration(rand.Float64() * jitter * float64(d))
+	}
+	return d
+}
+
+// canRewind reports whether req can be sent again, either because it has no
+// body or because its body can be recreated with GetBody.
+func canRewind(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// isIdempotent reports whether method is idempotent per RFC 9110.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace,
+		http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// send performs req with hc, retrying according to c.RetryPolicy. It
+// returns the final response together with the number of attempts made.
+//
+// When c.RespectRateLimits is set, send waits for exhausted rate limit windows
+// before each attempt and retries a 429 response carrying Retry-After once,
+// even if the retry policy would not. Likewise, a 401 response to a request
+// authenticated by a RefreshableTokenSource is retried once after the token
+// source is invalidated.
+func (c *Client) send(ctx context.Context, hc *http.Client, req *http.Request) (*http.Response, int, error) {
+	policy := c.RetryPolicy
+	maxAttempts := policy.attemptsFor(req)
+	rateLimitRetried := false
+	authRetried := false
+
+	for attempt := 1; ; attempt++ {
+		if err := c.waitForRateLimit(ctx, req); err != nil {
+			return nil, attempt - 1, err
+		}
+
+		attemptReq := req.WithContext(ctx)
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, attempt - 1, err
+			}
+			attemptReq.Body = body
+		}
+		if c.TokenSource != nil {
+			token, err := c.TokenSource.Token(ctx)
+			if err != nil {
+				return nil, attempt - 1, fmt.Errorf("token source; %w", err)
+			}
+			if token != "" {
+				attemptReq.Header = req.Header.Clone()
+				attemptReq.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+
+		resp, err := hc.Do(attemptReq)
+		if err != nil {
+			// If we got an error, and the context has been canceled,
+			// the context's error is probably more useful.
+			select {
+			case <-ctx.Done():
+				return nil, attempt, ctx.Err()
+			default:
+			}
+		}
+
+		if err == nil {
+			c.recordRate(req.URL.Path, resp)
+		}
+
+		retry := attempt < maxAttempts && policy.shouldRetry(resp, err)
+		var wait time.Duration
+		if retry {
+			wait = policy.backoff(attempt)
+		}
+		if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+			if retryAfter, ok := parseRetryAfter(resp); ok {
+				switch {
+				case retry:
+					wait = max(wait, retryAfter)
+				case c.RespectRateLimits && !rateLimitRetried && canRewind(req):
+					retry = true
+					wait = retryAfter
+					rateLimitRetried = true
+				}
+			}
+		}
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && !retry && !authRetried && canRewind(req) {
+			if ts, ok := c.TokenSource.(RefreshableTokenSource); ok {
+				ts.Invalidate()
+				retry = true
+				authRetried = true
+			}
+		}
+		if !retry {
+			return resp, attempt, err
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			// Not enough time left for another attempt; report what we have.
+			return resp, attempt, err
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if c.Logger != nil {
+			c.Logger.Log(ctx, c.logOptions().RetryLevel, "retrying request",
+				"method", req.Method, "url", SanitizeURL(req.URL).String(),
+				"attempt", attempt, "wait", wait)
+		}
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, attempt, err
+		}
+	}
+}
+
+// sleep pauses for d or until ctx is done, whichever happens first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,287 @@
+package contextforge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:          3,
+		MinBackoff:           time.Millisecond,
+		MaxBackoff:           5 * time.Millisecond,
+		RetryableStatusCodes: []int{http.StatusBadGateway, http.StatusServiceUnavailable},
+	}
+}
+
+func TestDo_RetryOnRetryableStatus(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+
+	calls := 0
+	mux.HandleFunc("/tools/1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"id":"1","name":"tool"}`)
+	})
+
+	tool, resp, err := client.Tools.Get(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("Tools.Get returned error: %v", err)
+	}
+	if tool.ID != "1" {
+		t.Errorf("Tools.Get returned ID %q, want %q", tool.ID, "1")
+	}
+	if calls != 3 {
+		t.Errorf("server saw %d calls, want 3", calls)
+	}
+	if resp.Attempts != 3 {
+		t.Errorf("Response.Attempts = %d, want 3", resp.Attempts)
+	}
+}
+
+func TestDo_RetryExhausted(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+
+	calls := 0
+	mux.HandleFunc("/tools/1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, `{"message":"upstream down"}`)
+	})
+
+	_, resp, err := client.Tools.Get(context.Background(), "1")
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("Tools.Get error = %v, want *ErrorResponse", err)
+	}
+	if errResp.Message != "upstream down" {
+		t.Errorf("ErrorResponse.Message = %q, want %q", errResp.Message, "upstream down")
+	}
+	if calls != 3 {
+		t.Errorf("server saw %d calls, want 3", calls)
+	}
+	if resp.Attempts != 3 {
+		t.Errorf("Response.Attempts = %d, want 3", resp.Attempts)
+	}
+}
+
+func TestDo_NoRetryByDefault(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/tools/1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, resp, err := client.Tools.Get(context.Background(), "1")
+	if err == nil {
+		t.Fatal("Tools.Get expected error, got nil")
+	}
+	if calls != 1 {
+		t.Errorf("server saw %d calls, want 1", calls)
+	}
+	if resp.Attempts != 1 {
+		t.Errorf("Response.Attempts = %d, want 1", resp.Attempts)
+	}
+}
+
+func TestDo_NoRetryOnNonRetryableStatus(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+
+	calls := 0
+	mux.HandleFunc("/tools/1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	client.Tools.Get(context.Background(), "1")
+	if calls != 1 {
+		t.Errorf("server saw %d calls, want 1", calls)
+	}
+}
+
+func TestDo_RetryIdempotentOnly(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+
+	calls := 0
+	mux.HandleFunc("/tools", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	client.Tools.Create(context.Background(), &Tool{Name: "t"}, nil)
+	if calls != 1 {
+		t.Errorf("POST was attempted %d times, want 1", calls)
+	}
+}
+
+func TestDo_RetryNonIdempotentRewindsBody(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+	client.RetryPolicy.RetryNonIdempotent = true
+
+	var bodies []string
+	mux.HandleFunc("/tools", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) < 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"id":"1","name":"t"}`)
+	})
+
+	_, resp, err := client.Tools.Create(context.Background(), &Tool{Name: "t"}, nil)
+	if err != nil {
+		t.Fatalf("Tools.Create returned error: %v", err)
+	}
+	if resp.Attempts != 2 {
+		t.Errorf("Response.Attempts = %d, want 2", resp.Attempts)
+	}
+	if len(bodies) != 2 || bodies[0] == "" || bodies[0] != bodies[1] {
+		t.Errorf("request bodies = %q, want two identical non-empty bodies", bodies)
+	}
+}
+
+func TestDo_RetryRespectsDeadline(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RetryPolicy = &RetryPolicy{
+		MaxAttempts:          5,
+		MinBackoff:           time.Hour,
+		MaxBackoff:           time.Hour,
+		RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+	}
+
+	calls := 0
+	mux.HandleFunc("/tools/1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, resp, err := client.Tools.Get(ctx, "1")
+	if err == nil {
+		t.Fatal("Tools.Get expected error, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Tools.Get took %v, want it to give up before the deadline", elapsed)
+	}
+	if calls != 1 {
+		t.Errorf("server saw %d calls, want 1", calls)
+	}
+	if resp == nil || resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Tools.Get response = %v, want the last 503 response", resp)
+	}
+}
+
+func TestDo_RetryContextCanceledDuringBackoff(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RetryPolicy = &RetryPolicy{
+		MaxAttempts:          3,
+		MinBackoff:           time.Hour,
+		RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mux.HandleFunc("/tools/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		time.AfterFunc(10*time.Millisecond, cancel)
+	})
+
+	_, _, err := client.Tools.Get(ctx, "1")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Tools.Get error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{10, time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("attempt %d", tt.attempt), func(t *testing.T) {
+			if got := p.backoff(tt.attempt); got != tt.want {
+				t.Errorf("backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_BackoffJitter(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Jitter: 0.5}
+
+	for range 100 {
+		got := p.backoff(1)
+		if got < 50*time.Millisecond || got > 100*time.Millisecond {
+			t.Fatalf("backoff(1) = %v, want between 50ms and 100ms", got)
+		}
+	}
+}
+
+func TestRetryPolicy_AttemptsFor(t *testing.T) {
+	get, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	post, _ := http.NewRequest(http.MethodPost, "http://example.com", nil)
+	unrewindable, _ := http.NewRequest(http.MethodPut, "http://example.com", nil)
+	unrewindable.Body = io.NopCloser(errReader{})
+
+	tests := []struct {
+		name   string
+		policy *RetryPolicy
+		req    *http.Request
+		want   int
+	}{
+		{"nil policy", nil, get, 1},
+		{"single attempt", &RetryPolicy{MaxAttempts: 1}, get, 1},
+		{"idempotent", &RetryPolicy{MaxAttempts: 4}, get, 4},
+		{"non-idempotent", &RetryPolicy{MaxAttempts: 4}, post, 1},
+		{"non-idempotent allowed", &RetryPolicy{MaxAttempts: 4, RetryNonIdempotent: true}, post, 4},
+		{"body cannot be rewound", &RetryPolicy{MaxAttempts: 4}, unrewindable, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.attemptsFor(tt.req); got != tt.want {
+				t.Errorf("attemptsFor() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errors.New("read error") }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -24,10 +25,12 @@ func normalizeServer(server *Server) *Server {
 // ServersService handles communication with the server-related
 // methods of the ContextForge API.
 //
-// Note: This service intentionally excludes certain MCP protocol transport endpoints:
+// Note: The MCP protocol side of a virtual server is not a REST resource.
+// Connect opens an MCPSession over the streamable HTTP endpoint
+// (/servers/{server_id}/mcp); the remaining methods are REST management
+// endpoints. The legacy SSE transport endpoints are not covered:
 // - GET /servers/{server_id}/sse - SSE connection for MCP protocol proxying
 // - POST /servers/{server_id}/message - JSON-RPC message relay for SSE sessions
-// These endpoints are for MCP protocol transport infrastructure, not REST API management.
 //
 // The /rpc endpoint handles MCP JSON-RPC protocol which is separate from these REST management endpoints.
 
@@ -45,7 +48,7 @@ func (s *ServersService) List(ctx context.Context, opts *ServerListOptions) ([]*
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Servers", Method: "List"}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -70,11 +73,27 @@ func (s *ServersService) List(ctx context.Context, opts *ServerListOptions) ([]*
 	return servers, resp, nil
 }
 
+// All returns an iterator over every server matching opts, following
+// pagination cursors until the result set is exhausted or opts.MaxItems
+// servers have been yielded. Iteration stops with ctx.Err() if ctx is done.
+func (s *ServersService) All(ctx context.Context, opts *ServerListOptions) iter.Seq2[*Server, error] {
+	reqOpts := &ServerListOptions{}
+	if opts != nil {
+		*reqOpts = *opts
+	}
+
+	return allByCursor(ctx, reqOpts.Cursor, reqOpts.MaxItems, func(ctx context.Context, cursor string) ([]*Server, *Response, error) {
+		pageOpts := *reqOpts
+		pageOpts.Cursor = cursor
+		return s.List(ctx, &pageOpts)
+	})
+}
+
 // Get retrieves a specific server by its ID.
 func (s *ServersService) Get(ctx context.Context, serverID string) (*Server, *Response, error) {
 	u := fmt.Sprintf("servers/%s", url.PathEscape(serverID))
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Servers", Method: "Get", EntityID: serverID}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -107,7 +126,7 @@ func (s *ServersService) Create(ctx context.Context, server *ServerCreate, opts
 		}
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, body)
+	req, err := s.client.newRequest(Operation{Service: "Servers", Method: "Create"}, http.MethodPost, u, body)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -126,7 +145,7 @@ func (s *ServersService) Create(ctx context.Context, server *ServerCreate, opts
 func (s *ServersService) Update(ctx context.Context, serverID string, server *ServerUpdate) (*Server, *Response, error) {
 	u := fmt.Sprintf("servers/%s", url.PathEscape(serverID))
 
-	req, err := s.client.NewRequest(http.MethodPut, u, server)
+	req, err := s.client.newRequest(Operation{Service: "Servers", Method: "Update", EntityID: serverID}, http.MethodPut, u, server)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -144,7 +163,7 @@ func (s *ServersService) Update(ctx context.Context, serverID string, server *Se
 func (s *ServersService) Delete(ctx context.Context, serverID string) (*Response, error) {
 	u := fmt.Sprintf("servers/%s", url.PathEscape(serverID))
 
-	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Servers", Method: "Delete", EntityID: serverID}, http.MethodDelete, u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -159,18 +178,18 @@ func (s *ServersService) Delete(ctx context.Context, serverID string) (*Response
 
 // SetState sets a server's active status using the preferred /state endpoint.
 func (s *ServersService) SetState(ctx context.Context, serverID string, activate bool) (*Server, *Response, error) {
-	return s.setState(ctx, serverID, activate, "state")
+	return s.setState(ctx, "SetState", serverID, activate, "state")
 }
 
 // Toggle toggles a server's active status using the legacy /toggle endpoint.
 func (s *ServersService) Toggle(ctx context.Context, serverID string, activate bool) (*Server, *Response, error) {
-	return s.setState(ctx, serverID, activate, "toggle")
+	return s.setState(ctx, "Toggle", serverID, activate, "toggle")
 }
 
-func (s *ServersService) setState(ctx context.Context, serverID string, activate bool, endpoint string) (*Server, *Response, error) {
+func (s *ServersService) setState(ctx context.Context, method, serverID string, activate bool, endpoint string) (*Server, *Response, error) {
 	u := fmt.Sprintf("servers/%s/%s?activate=%t", url.PathEscape(serverID), endpoint, activate)
 
-	req, err := s.client.NewRequest(http.MethodPost, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Servers", Method: method, EntityID: serverID}, http.MethodPost, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -192,7 +211,7 @@ func (s *ServersService) ListTools(ctx context.Context, serverID string, opts *S
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Servers", Method: "ListTools", EntityID: serverID}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -214,7 +233,7 @@ func (s *ServersService) ListResources(ctx context.Context, serverID string, opt
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Servers", Method: "ListResources", EntityID: serverID}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -236,7 +255,7 @@ func (s *ServersService) ListPrompts(ctx context.Context, serverID string, opts
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Servers", Method: "ListPrompts", EntityID: serverID}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
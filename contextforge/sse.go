@@ -0,0 +1,173 @@
+package contextforge
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const mediaTypeEventStream = "text/event-stream"
+
+// sseEvent is a single server-sent event.
+type sseEvent struct {
+	ID    string
+	Event string
+	Data  string
+	Retry time.Duration
+}
+
+// sseReader parses a text/event-stream body as described by the HTML living
+// standard. Comments are skipped and multi-line data fields are joined with
+// newlines.
+type sseReader struct {
+	r *bufio.Reader
+}
+
+func newSSEReader(r io.Reader) *sseReader {
+	return &sseReader{r: bufio.NewReader(r)}
+}
+
+// Next returns the next event in the stream. It returns io.EOF once the
+// stream ends; an event left unterminated at the end of the stream is
+// discarded.
+func (s *sseReader) Next() (*sseEvent, error) {
+	var (
+		ev      sseEvent
+		data    strings.Builder
+		hasData bool
+		hasAny  bool
+	)
+
+	for {
+		line, err := s.r.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if err == io.EOF {
+				return nil, io.EOF
+			}
+			if !hasAny {
+				continue
+			}
+			if hasData {
+				ev.Data = data.String()
+				return &ev, nil
+			}
+			// Events with no data are not dispatched, but their id
+			// still counts as the last event ID.
+			if ev.ID != "" {
+				return &ev, nil
+			}
+			ev, hasAny = sseEvent{}, false
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		hasAny = true
+
+		switch field {
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+			hasData = true
+		case "event":
+			ev.Event = value
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				ev.ID = value
+			}
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+				ev.Retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+	}
+}
+
+// doStream sends an API request that expects a text/event-stream response,
+// or may receive one if req already accepts it. Unlike Do, it leaves the
+// response body open for the caller to read and close. API errors are
+// returned as by Do, with the body already closed.
+func (c *Client) doStream(ctx context.Context, req *http.Request) (*Response, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("context must be non-nil")
+	}
+
+	if !strings.Contains(req.Header.Get("Accept"), mediaTypeEventStream) {
+		req.Header.Set("Accept", mediaTypeEventStream)
+	}
+
+	if len(c.Middleware) == 0 {
+		return c.stream(ctx, req)
+	}
+	call := newCall(req)
+	if call.Operation != (Operation{}) {
+		ctx = context.WithValue(ctx, operationKey{}, call.Operation)
+	}
+	return c.chain(func(ctx context.Context, call *Call, _ any) (*Response, error) {
+		return c.stream(ctx, call.Request)
+	})(ctx, call, nil)
+}
+
+// streamingClient returns the HTTP client for requests whose responses are
+// streamed: the client's, without the Timeout that would also bound reading
+// the body and so cut long streams off. Only their context limits them.
+func (c *Client) streamingClient() *http.Client {
+	if c.client.Timeout == 0 {
+		return c.client
+	}
+	hc := *c.client
+	hc.Timeout = 0
+	return &hc
+}
+
+// stream sends req at the end of the middleware chain of doStream.
+func (c *Client) stream(ctx context.Context, req *http.Request) (*Response, error) {
+	ctx, done := c.instrument(ctx, req)
+	var response *Response
+	l := &requestLog{start: time.Now()}
+	if c.logBodies() {
+		l.reqBody = requestBody(req)
+	}
+	defer func() {
+		c.logRequest(ctx, req, l)
+		done(response, l.err)
+	}()
+
+	resp, attempts, err := c.send(ctx, c.streamingClient(), req)
+	l.attempts, l.err = attempts, err
+	if err != nil {
+		return nil, err
+	}
+	l.resp = resp
+
+	response = newResponse(resp)
+	response.Attempts = attempts
+
+	if err := CheckResponse(resp); err != nil {
+		l.err = err
+		resp.Body.Close()
+		return response, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,81 @@
+package contextforge
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSSEReader(t *testing.T) {
+	tests := []struct {
+		name   string
+		stream string
+		want   []sseEvent
+	}{
+		{
+			name:   "single event",
+			stream: "data: hello\n\n",
+			want:   []sseEvent{{Data: "hello"}},
+		},
+		{
+			name:   "all fields",
+			stream: "id: 7\nevent: update\nretry: 1500\ndata: {\"a\":1}\n\n",
+			want:   []sseEvent{{ID: "7", Event: "update", Retry: 1500 * time.Millisecond, Data: `{"a":1}`}},
+		},
+		{
+			name:   "multi-line data",
+			stream: "data: one\ndata: two\n\n",
+			want:   []sseEvent{{Data: "one\ntwo"}},
+		},
+		{
+			name:   "crlf line endings and comments",
+			stream: ": keep-alive\r\ndata:no-space\r\n\r\n",
+			want:   []sseEvent{{Data: "no-space"}},
+		},
+		{
+			name:   "multiple events with blank lines between",
+			stream: "data: a\n\n\n\ndata: b\n\n",
+			want:   []sseEvent{{Data: "a"}, {Data: "b"}},
+		},
+		{
+			name:   "event without data is dropped",
+			stream: "event: ping\n\ndata: a\n\n",
+			want:   []sseEvent{{Data: "a"}},
+		},
+		{
+			name:   "id-only event is returned",
+			stream: "id: 3\n\n",
+			want:   []sseEvent{{ID: "3"}},
+		},
+		{
+			name:   "unterminated event is discarded",
+			stream: "data: a\n\ndata: partial",
+			want:   []sseEvent{{Data: "a"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newSSEReader(strings.NewReader(tt.stream))
+
+			var got []sseEvent
+			for {
+				ev, err := r.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Next() unexpected error: %v", err)
+				}
+				got = append(got, *ev)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("events = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package contextforge
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -22,7 +23,7 @@ func (s *TeamsService) List(ctx context.Context, opts *TeamListOptions) ([]*Team
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Teams", Method: "List"}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -32,15 +33,35 @@ func (s *TeamsService) List(ctx context.Context, opts *TeamListOptions) ([]*Team
 	if err != nil {
 		return nil, resp, err
 	}
+	if result == nil {
+		return nil, resp, nil
+	}
+	resp.Total = result.Total
 
 	return result.Teams, resp, nil
 }
 
+// All returns an iterator over every team, advancing Skip by the size of each
+// page until the Total reported by the API is reached or opts.MaxItems teams
+// have been yielded. Iteration stops with ctx.Err() if ctx is done.
+func (s *TeamsService) All(ctx context.Context, opts *TeamListOptions) iter.Seq2[*Team, error] {
+	reqOpts := &TeamListOptions{}
+	if opts != nil {
+		*reqOpts = *opts
+	}
+
+	return allByOffset(ctx, reqOpts.Skip, reqOpts.Limit, reqOpts.MaxItems, func(ctx context.Context, skip int) ([]*Team, *Response, error) {
+		pageOpts := *reqOpts
+		pageOpts.Skip = skip
+		return s.List(ctx, &pageOpts)
+	})
+}
+
 // Get retrieves a specific team by its ID.
 func (s *TeamsService) Get(ctx context.Context, teamID string) (*Team, *Response, error) {
 	u := fmt.Sprintf("teams/%s/", url.PathEscape(teamID))
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Teams", Method: "Get", EntityID: teamID}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -59,7 +80,7 @@ func (s *TeamsService) Get(ctx context.Context, teamID string) (*Team, *Response
 func (s *TeamsService) Create(ctx context.Context, team *TeamCreate) (*Team, *Response, error) {
 	u := "teams"
 
-	req, err := s.client.NewRequest(http.MethodPost, u, team)
+	req, err := s.client.newRequest(Operation{Service: "Teams", Method: "Create"}, http.MethodPost, u, team)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -78,7 +99,7 @@ func (s *TeamsService) Create(ctx context.Context, team *TeamCreate) (*Team, *Re
 func (s *TeamsService) Update(ctx context.Context, teamID string, team *TeamUpdate) (*Team, *Response, error) {
 	u := fmt.Sprintf("teams/%s/", url.PathEscape(teamID))
 
-	req, err := s.client.NewRequest(http.MethodPut, u, team)
+	req, err := s.client.newRequest(Operation{Service: "Teams", Method: "Update", EntityID: teamID}, http.MethodPut, u, team)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -96,7 +117,7 @@ func (s *TeamsService) Update(ctx context.Context, teamID string, team *TeamUpda
 func (s *TeamsService) Delete(ctx context.Context, teamID string) (*Response, error) {
 	u := fmt.Sprintf("teams/%s/", url.PathEscape(teamID))
 
-	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Teams", Method: "Delete", EntityID: teamID}, http.MethodDelete, u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -109,7 +130,7 @@ func (s *TeamsService) Delete(ctx context.Context, teamID string) (*Response, er
 func (s *TeamsService) ListMembers(ctx context.Context, teamID string) ([]*TeamMember, *Response, error) {
 	u := fmt.Sprintf("teams/%s/members/", url.PathEscape(teamID))
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Teams", Method: "ListMembers", EntityID: teamID}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -128,7 +149,7 @@ func (s *TeamsService) ListMembers(ctx context.Context, teamID string) ([]*TeamM
 func (s *TeamsService) UpdateMember(ctx context.Context, teamID, userEmail string, update *TeamMemberUpdate) (*TeamMember, *Response, error) {
 	u := fmt.Sprintf("teams/%s/members/%s/", url.PathEscape(teamID), url.PathEscape(userEmail))
 
-	req, err := s.client.NewRequest(http.MethodPut, u, update)
+	req, err := s.client.newRequest(Operation{Service: "Teams", Method: "UpdateMember", EntityID: teamID}, http.MethodPut, u, update)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -147,7 +168,7 @@ func (s *TeamsService) UpdateMember(ctx context.Context, teamID, userEmail strin
 func (s *TeamsService) RemoveMember(ctx context.Context, teamID, userEmail string) (*Response, error) {
 	u := fmt.Sprintf("teams/%s/members/%s/", url.PathEscape(teamID), url.PathEscape(userEmail))
 
-	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Teams", Method: "RemoveMember", EntityID: teamID}, http.MethodDelete, u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -160,7 +181,7 @@ func (s *TeamsService) RemoveMember(ctx context.Context, teamID, userEmail strin
 func (s *TeamsService) InviteMember(ctx context.Context, teamID string, invite *TeamInvite) (*TeamInvitation, *Response, error) {
 	u := fmt.Sprintf("teams/%s/invitations/", url.PathEscape(teamID))
 
-	req, err := s.client.NewRequest(http.MethodPost, u, invite)
+	req, err := s.client.newRequest(Operation{Service: "Teams", Method: "InviteMember", EntityID: teamID}, http.MethodPost, u, invite)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -178,7 +199,7 @@ func (s *TeamsService) InviteMember(ctx context.Context, teamID string, invite *
 func (s *TeamsService) ListInvitations(ctx context.Context, teamID string) ([]*TeamInvitation, *Response, error) {
 	u := fmt.Sprintf("teams/%s/invitations/", url.PathEscape(teamID))
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Teams", Method: "ListInvitations", EntityID: teamID}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -196,7 +217,7 @@ func (s *TeamsService) ListInvitations(ctx context.Context, teamID string) ([]*T
 func (s *TeamsService) AcceptInvitation(ctx context.Context, token string) (*TeamMember, *Response, error) {
 	u := fmt.Sprintf("teams/invitations/%s/accept/", url.PathEscape(token))
 
-	req, err := s.client.NewRequest(http.MethodPost, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Teams", Method: "AcceptInvitation"}, http.MethodPost, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -214,7 +235,7 @@ func (s *TeamsService) AcceptInvitation(ctx context.Context, token string) (*Tea
 func (s *TeamsService) CancelInvitation(ctx context.Context, invitationID string) (*Response, error) {
 	u := fmt.Sprintf("teams/invitations/%s/", url.PathEscape(invitationID))
 
-	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Teams", Method: "CancelInvitation", EntityID: invitationID}, http.MethodDelete, u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -231,7 +252,7 @@ func (s *TeamsService) Discover(ctx context.Context, opts *TeamDiscoverOptions)
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Teams", Method: "Discover"}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -245,11 +266,29 @@ func (s *TeamsService) Discover(ctx context.Context, opts *TeamDiscoverOptions)
 	return teams, resp, nil
 }
 
+// DiscoverAll returns an iterator over every discoverable public team,
+// advancing Skip by the size of each page. The discovery endpoint does not
+// report a total, so iteration ends at the first empty or short page, or once
+// opts.MaxItems teams have been yielded. Iteration stops with ctx.Err() if
+// ctx is done.
+func (s *TeamsService) DiscoverAll(ctx context.Context, opts *TeamDiscoverOptions) iter.Seq2[*TeamDiscovery, error] {
+	reqOpts := &TeamDiscoverOptions{}
+	if opts != nil {
+		*reqOpts = *opts
+	}
+
+	return allByOffset(ctx, reqOpts.Skip, reqOpts.Limit, reqOpts.MaxItems, func(ctx context.Context, skip int) ([]*TeamDiscovery, *Response, error) {
+		pageOpts := *reqOpts
+		pageOpts.Skip = skip
+		return s.Discover(ctx, &pageOpts)
+	})
+}
+
 // Join requests to join a public team.
 func (s *TeamsService) Join(ctx context.Context, teamID string, request *TeamJoinRequest) (*TeamJoinRequestResponse, *Response, error) {
 	u := fmt.Sprintf("teams/%s/join/", url.PathEscape(teamID))
 
-	req, err := s.client.NewRequest(http.MethodPost, u, request)
+	req, err := s.client.newRequest(Operation{Service: "Teams", Method: "Join", EntityID: teamID}, http.MethodPost, u, request)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -267,7 +306,7 @@ func (s *TeamsService) Join(ctx context.Context, teamID string, request *TeamJoi
 func (s *TeamsService) Leave(ctx context.Context, teamID string) (*Response, error) {
 	u := fmt.Sprintf("teams/%s/leave/", url.PathEscape(teamID))
 
-	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Teams", Method: "Leave", EntityID: teamID}, http.MethodDelete, u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -281,7 +320,7 @@ func (s *TeamsService) Leave(ctx context.Context, teamID string) (*Response, err
 func (s *TeamsService) ListJoinRequests(ctx context.Context, teamID string) ([]*TeamJoinRequestResponse, *Response, error) {
 	u := fmt.Sprintf("teams/%s/join-requests/", url.PathEscape(teamID))
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Teams", Method: "ListJoinRequests", EntityID: teamID}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -299,7 +338,7 @@ func (s *TeamsService) ListJoinRequests(ctx context.Context, teamID string) ([]*
 func (s *TeamsService) ApproveJoinRequest(ctx context.Context, teamID, requestID string) (*TeamMember, *Response, error) {
 	u := fmt.Sprintf("teams/%s/join-requests/%s/approve/", url.PathEscape(teamID), url.PathEscape(requestID))
 
-	req, err := s.client.NewRequest(http.MethodPost, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Teams", Method: "ApproveJoinRequest", EntityID: teamID}, http.MethodPost, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -317,7 +356,7 @@ func (s *TeamsService) ApproveJoinRequest(ctx context.Context, teamID, requestID
 func (s *TeamsService) RejectJoinRequest(ctx context.Context, teamID, requestID string) (*Response, error) {
 	u := fmt.Sprintf("teams/%s/join-requests/%s/", url.PathEscape(teamID), url.PathEscape(requestID))
 
-	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Teams", Method: "RejectJoinRequest", EntityID: teamID}, http.MethodDelete, u, nil)
 	if err != nil {
 		return nil, err
 	}
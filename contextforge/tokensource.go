@@ -0,0 +1,196 @@
+package contextforge
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultRefreshSkew is how long before expiry PasswordTokenSource refreshes
+// its token when RefreshSkew is zero.
+const defaultRefreshSkew = time.Minute
+
+// TokenSource supplies bearer tokens for API requests. The client calls Token
+// before every request attempt, so implementations may cache and refresh
+// tokens as needed. Implementations must be safe for concurrent use.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// RefreshableTokenSource is a TokenSource whose cached token can be discarded.
+// When a request authenticated by a RefreshableTokenSource is rejected with
+// 401 Unauthorized, the client calls Invalidate and retries the request once
+// with a freshly obtained token.
+type RefreshableTokenSource interface {
+	TokenSource
+	Invalidate()
+}
+
+// StaticTokenSource returns a TokenSource that always returns token.
+func StaticTokenSource(token string) TokenSource {
+	return staticTokenSource(token)
+}
+
+type staticTokenSource string
+
+func (s staticTokenSource) Token(context.Context) (string, error) {
+	return string(s), nil
+}
+
+// PasswordTokenSource is a RefreshableTokenSource that logs in to ContextForge
+// with an email and password via POST auth/login. The JWT it receives is cached
+// until shortly before the expiry recorded in its exp claim, then replaced by
+// logging in again.
+type PasswordTokenSource struct {
+	// RefreshSkew is how long before the token expires a new one is
+	// obtained. Defaults to one minute when zero.
+	RefreshSkew time.Duration
+
+	client   *http.Client
+	loginURL string
+	email    string
+	password string
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// NewPasswordTokenSource returns a PasswordTokenSource that logs in to the
+// ContextForge instance at address. If a nil httpClient is provided, a new
+// http.Client will be used.
+func NewPasswordTokenSource(httpClient *http.Client, address, email, password string) (*PasswordTokenSource, error) {
+	if !strings.HasSuffix(address, "/") {
+		address = address + "/"
+	}
+
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address; %w", err)
+	}
+	loginURL, err := u.Parse("auth/login")
+	if err != nil {
+		return nil, err
+	}
+
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: 30 * time.Second,
+		}
+	}
+
+	return &PasswordTokenSource{
+		client:   httpClient,
+		loginURL: loginURL.String(),
+		email:    email,
+		password: password,
+	}, nil
+}
+
+// Token returns a valid JWT, logging in if no token is cached or the cached
+// token is about to expire.
+func (s *PasswordTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	skew := s.RefreshSkew
+	if skew == 0 {
+		skew = defaultRefreshSkew
+	}
+	if s.token != "" && (s.expiry.IsZero() || time.Now().Add(skew).Before(s.expiry)) {
+		return s.token, nil
+	}
+
+	token, expiry, err := s.login(ctx)
+	if err != nil {
+		return "", err
+	}
+	s.token, s.expiry = token, expiry
+
+	return token, nil
+}
+
+// Invalidate discards the cached token so the next call to Token logs in again.
+func (s *PasswordTokenSource) Invalidate() {
+	s.mu.Lock()
+	s.token, s.expiry = "", time.Time{}
+	s.mu.Unlock()
+}
+
+// login exchanges the configured credentials for a JWT and its expiry time.
+func (s *PasswordTokenSource) login(ctx context.Context) (string, time.Time, error) {
+	body, err := json.Marshal(map[string]string{
+		"username": s.email,
+		"password": s.password,
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.loginURL, bytes.NewReader(body))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set("Content-Type", mediaTypeJSON)
+	req.Header.Set("Accept", mediaTypeJSON)
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("login; %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := CheckResponse(resp); err != nil {
+		return "", time.Time{}, err
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", time.Time{}, fmt.Errorf("decode login response; %w", err)
+	}
+	if result.AccessToken == "" {
+		return "", time.Time{}, fmt.Errorf("login response did not contain an access token")
+	}
+
+	expiry, ok := jwtExpiry(result.AccessToken)
+	if !ok && result.ExpiresIn > 0 {
+		expiry = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	}
+
+	return result.AccessToken, expiry, nil
+}
+
+// jwtExpiry returns the time held in the exp claim of a JWT. The token's
+// signature is not verified.
+func jwtExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var claims struct {
+		Exp *float64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == nil {
+		return time.Time{}, false
+	}
+
+	sec := int64(*claims.Exp)
+	return time.Unix(sec, 0), true
+}
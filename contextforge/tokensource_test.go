@@ -0,0 +1,263 @@
+package contextforge
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testJWT returns an unsigned JWT whose payload holds the given claims.
+func testJWT(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("marshal claims: %v", err)
+	}
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"none"}`)) + "." + enc.EncodeToString(payload) + ".sig"
+}
+
+func TestJWTExpiry(t *testing.T) {
+	exp := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		token  string
+		want   time.Time
+		wantOK bool
+	}{
+		{"valid exp", testJWT(t, map[string]any{"sub": "a", "exp": exp.Unix()}), exp, true},
+		{"missing exp", testJWT(t, map[string]any{"sub": "a"}), time.Time{}, false},
+		{"not a jwt", "opaque-token", time.Time{}, false},
+		{"bad payload", "a.!!!.c", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := jwtExpiry(tt.token)
+			if ok != tt.wantOK {
+				t.Fatalf("jwtExpiry() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("jwtExpiry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStaticTokenSource(t *testing.T) {
+	got, err := StaticTokenSource("abc").Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token() unexpected error: %v", err)
+	}
+	if got != "abc" {
+		t.Errorf("Token() = %q, want %q", got, "abc")
+	}
+}
+
+// loginServer starts a server whose auth/login endpoint issues tokens produced
+// by issue, and returns it along with a pointer to the login count.
+func loginServer(t *testing.T, issue func(n int) string) (*httptest.Server, *int) {
+	t.Helper()
+	logins := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth/login", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode login body: %v", err)
+		}
+		if body["username"] != "admin@test.local" || body["password"] != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message":"invalid credentials"}`)
+			return
+		}
+		logins++
+		fmt.Fprintf(w, `{"access_token":%q,"token_type":"bearer"}`, issue(logins))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, &logins
+}
+
+func TestPasswordTokenSource_CachesToken(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Unix()
+	server, logins := loginServer(t, func(n int) string {
+		return testJWT(t, map[string]any{"n": n, "exp": exp})
+	})
+
+	ts, err := NewPasswordTokenSource(nil, server.URL, "admin@test.local", "secret")
+	if err != nil {
+		t.Fatalf("NewPasswordTokenSource() unexpected error: %v", err)
+	}
+
+	first, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token() unexpected error: %v", err)
+	}
+	second, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token() unexpected error: %v", err)
+	}
+
+	if first != second {
+		t.Error("Token() returned different tokens for a cached, unexpired token")
+	}
+	if *logins != 1 {
+		t.Errorf("logged in %d times, want 1", *logins)
+	}
+}
+
+func TestPasswordTokenSource_RefreshesBeforeExpiry(t *testing.T) {
+	// Tokens expire in 30s, inside the default one-minute refresh skew.
+	server, logins := loginServer(t, func(n int) string {
+		return testJWT(t, map[string]any{"n": n, "exp": time.Now().Add(30 * time.Second).Unix()})
+	})
+
+	ts, err := NewPasswordTokenSource(nil, server.URL, "admin@test.local", "secret")
+	if err != nil {
+		t.Fatalf("NewPasswordTokenSource() unexpected error: %v", err)
+	}
+
+	first, _ := ts.Token(context.Background())
+	second, _ := ts.Token(context.Background())
+	if first == second {
+		t.Error("Token() reused a token that expires within the refresh skew")
+	}
+	if *logins != 2 {
+		t.Errorf("logged in %d times, want 2", *logins)
+	}
+
+	// With a shorter skew the cached token is still fresh enough to reuse.
+	ts.RefreshSkew = time.Second
+	third, _ := ts.Token(context.Background())
+	if third != second {
+		t.Error("Token() did not reuse a token outside the refresh skew")
+	}
+	if *logins != 2 {
+		t.Errorf("logged in %d times with a 1s skew, want 2", *logins)
+	}
+}
+
+func TestPasswordTokenSource_Invalidate(t *testing.T) {
+	server, logins := loginServer(t, func(n int) string {
+		return fmt.Sprintf("opaque-%d", n)
+	})
+
+	ts, err := NewPasswordTokenSource(nil, server.URL, "admin@test.local", "secret")
+	if err != nil {
+		t.Fatalf("NewPasswordTokenSource() unexpected error: %v", err)
+	}
+
+	ts.Token(context.Background())
+	ts.Invalidate()
+	got, _ := ts.Token(context.Background())
+
+	if got != "opaque-2" {
+		t.Errorf("Token() after Invalidate = %q, want %q", got, "opaque-2")
+	}
+	if *logins != 2 {
+		t.Errorf("logged in %d times, want 2", *logins)
+	}
+}
+
+func TestPasswordTokenSource_LoginFailure(t *testing.T) {
+	server, _ := loginServer(t, func(int) string { return "unused" })
+
+	ts, err := NewPasswordTokenSource(nil, server.URL, "admin@test.local", "wrong")
+	if err != nil {
+		t.Fatalf("NewPasswordTokenSource() unexpected error: %v", err)
+	}
+
+	_, err = ts.Token(context.Background())
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("Token() error = %v, want *ErrorResponse", err)
+	}
+	if errResp.Response.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", errResp.Response.StatusCode, http.StatusUnauthorized)
+	}
+}
+
+func TestDo_TokenSourceRetriesOnUnauthorized(t *testing.T) {
+	server, logins := loginServer(t, func(n int) string {
+		return fmt.Sprintf("token-%d", n)
+	})
+
+	ts, err := NewPasswordTokenSource(nil, server.URL, "admin@test.local", "secret")
+	if err != nil {
+		t.Fatalf("NewPasswordTokenSource() unexpected error: %v", err)
+	}
+
+	var seen []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		seen = append(seen, auth)
+		if auth != "Bearer token-2" {
+			// The first token has been revoked server-side.
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `[]`)
+	}))
+	defer api.Close()
+
+	client, err := NewClientWithOptions(api.URL, WithTokenSource(ts))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() unexpected error: %v", err)
+	}
+
+	_, resp, err := client.Tools.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Tools.List returned error: %v", err)
+	}
+	if fmt.Sprint(seen) != "[Bearer token-1 Bearer token-2]" {
+		t.Errorf("Authorization headers = %v, want token-1 then token-2", seen)
+	}
+	if *logins != 2 {
+		t.Errorf("logged in %d times, want 2", *logins)
+	}
+	if resp.Attempts != 2 {
+		t.Errorf("Response.Attempts = %d, want 2", resp.Attempts)
+	}
+}
+
+func TestDo_TokenSourceRetriesUnauthorizedOnce(t *testing.T) {
+	server, logins := loginServer(t, func(n int) string {
+		return fmt.Sprintf("token-%d", n)
+	})
+
+	ts, err := NewPasswordTokenSource(nil, server.URL, "admin@test.local", "secret")
+	if err != nil {
+		t.Fatalf("NewPasswordTokenSource() unexpected error: %v", err)
+	}
+
+	calls := 0
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer api.Close()
+
+	client, err := NewClientWithOptions(api.URL, WithTokenSource(ts))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() unexpected error: %v", err)
+	}
+
+	_, _, err = client.Tools.List(context.Background(), nil)
+	if err == nil {
+		t.Fatal("Tools.List expected error, got nil")
+	}
+	if calls != 2 {
+		t.Errorf("server saw %d calls, want 2", calls)
+	}
+	if *logins != 2 {
+		t.Errorf("logged in %d times, want 2", *logins)
+	}
+}
@@ -3,16 +3,21 @@ package contextforge
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
+
+	"github.com/leefowlercu/go-contextforge/jsonschema"
 )
 
 // ToolsService handles communication with the tool-related
 // methods of the ContextForge API.
 //
 // Note: All /tools/* endpoints are REST API management endpoints.
-// There are no MCP protocol endpoints to exclude for this service.
+// Tools are executed with Invoke, which speaks JSON-RPC to the gateway's
+// /rpc endpoint.
 
 // List retrieves a paginated list of tools from the ContextForge API.
 func (s *ToolsService) List(ctx context.Context, opts *ToolListOptions) ([]*Tool, *Response, error) {
@@ -28,7 +33,7 @@ func (s *ToolsService) List(ctx context.Context, opts *ToolListOptions) ([]*Tool
 		return nil, nil, err
 	}
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Tools", Method: "List"}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -50,11 +55,27 @@ func (s *ToolsService) List(ctx context.Context, opts *ToolListOptions) ([]*Tool
 	return tools, resp, nil
 }
 
+// All returns an iterator over every tool matching opts, following
+// pagination cursors until the result set is exhausted or opts.MaxItems
+// tools have been yielded. Iteration stops with ctx.Err() if ctx is done.
+func (s *ToolsService) All(ctx context.Context, opts *ToolListOptions) iter.Seq2[*Tool, error] {
+	reqOpts := &ToolListOptions{}
+	if opts != nil {
+		*reqOpts = *opts
+	}
+
+	return allByCursor(ctx, reqOpts.Cursor, reqOpts.MaxItems, func(ctx context.Context, cursor string) ([]*Tool, *Response, error) {
+		pageOpts := *reqOpts
+		pageOpts.Cursor = cursor
+		return s.List(ctx, &pageOpts)
+	})
+}
+
 // Get retrieves a specific tool by its ID.
 func (s *ToolsService) Get(ctx context.Context, toolID string) (*Tool, *Response, error) {
 	u := fmt.Sprintf("tools/%s", url.PathEscape(toolID))
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Tools", Method: "Get", EntityID: toolID}, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -88,7 +109,7 @@ func (s *ToolsService) Create(ctx context.Context, tool *Tool, opts *ToolCreateO
 		}
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, body)
+	req, err := s.client.newRequest(Operation{Service: "Tools", Method: "Create"}, http.MethodPost, u, body)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -109,7 +130,7 @@ func (s *ToolsService) Update(ctx context.Context, toolID string, tool *Tool) (*
 	// Send the tool directly (UPDATE endpoint does not use wrapper, unlike CREATE)
 	body := tool
 
-	req, err := s.client.NewRequest(http.MethodPut, u, body)
+	req, err := s.client.newRequest(Operation{Service: "Tools", Method: "Update", EntityID: toolID}, http.MethodPut, u, body)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -127,7 +148,7 @@ func (s *ToolsService) Update(ctx context.Context, toolID string, tool *Tool) (*
 func (s *ToolsService) Delete(ctx context.Context, toolID string) (*Response, error) {
 	u := fmt.Sprintf("tools/%s", url.PathEscape(toolID))
 
-	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Tools", Method: "Delete", EntityID: toolID}, http.MethodDelete, u, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -142,18 +163,18 @@ func (s *ToolsService) Delete(ctx context.Context, toolID string) (*Response, er
 
 // SetState sets a tool's active status using the preferred /state endpoint.
 func (s *ToolsService) SetState(ctx context.Context, toolID string, activate bool) (*Tool, *Response, error) {
-	return s.setState(ctx, toolID, activate, "state")
+	return s.setState(ctx, "SetState", toolID, activate, "state")
 }
 
 // Toggle toggles a tool's active status using the legacy /toggle endpoint.
 func (s *ToolsService) Toggle(ctx context.Context, toolID string, activate bool) (*Tool, *Response, error) {
-	return s.setState(ctx, toolID, activate, "toggle")
+	return s.setState(ctx, "Toggle", toolID, activate, "toggle")
 }
 
-func (s *ToolsService) setState(ctx context.Context, toolID string, activate bool, endpoint string) (*Tool, *Response, error) {
+func (s *ToolsService) setState(ctx context.Context, method, toolID string, activate bool, endpoint string) (*Tool, *Response, error) {
 	u := fmt.Sprintf("tools/%s/%s?activate=%t", url.PathEscape(toolID), endpoint, activate)
 
-	req, err := s.client.NewRequest(http.MethodPost, u, nil)
+	req, err := s.client.newRequest(Operation{Service: "Tools", Method: method, EntityID: toolID}, http.MethodPost, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -174,3 +195,67 @@ func (s *ToolsService) setState(ctx context.Context, toolID string, activate boo
 
 	return tool, resp, nil
 }
+
+// Invoke calls the tool with the given name through the gateway's JSON-RPC
+// endpoint (/rpc) and returns its result. A tool that fails reports the
+// failure with ToolCallResult.IsError rather than an error; JSON-RPC errors
+// are returned as *MCPError.
+//
+// If opts.InputSchema is set, args are checked against it first and a
+// *ToolArgumentsError is returned without contacting the server when they
+// do not satisfy it. The opts parameter is optional; pass nil to send args
+// unchecked.
+func (s *ToolsService) Invoke(ctx context.Context, name string, args map[string]any, opts *ToolInvokeOptions) (*ToolCallResult, *Response, error) {
+	if opts != nil && opts.InputSchema != nil {
+		validator, err := jsonschema.Compile(opts.InputSchema)
+		if err != nil {
+			return nil, nil, fmt.Errorf("compile input schema for tool %q; %w", name, err)
+		}
+		if err := validator.Validate(args); err != nil {
+			var errs jsonschema.ValidationErrors
+			if !errors.As(err, &errs) {
+				return nil, nil, err
+			}
+			return nil, nil, &ToolArgumentsError{Tool: name, Errors: errs}
+		}
+	}
+
+	params := map[string]any{"name": name}
+	if args != nil {
+		params["arguments"] = args
+	}
+
+	requestID, err := newRequestID()
+	if err != nil {
+		return nil, nil, err
+	}
+	id, _ := json.Marshal(requestID)
+
+	req, err := s.client.newRequest(Operation{Service: "Tools", Method: "Invoke", EntityID: name}, http.MethodPost, "rpc", &jsonrpcMessage{
+		JSONRPC: "2.0",
+		ID:      id,
+		Method:  "tools/call",
+		Params:  params,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var reply jsonrpcMessage
+	resp, err := s.client.Do(ctx, req, &reply)
+	if err != nil {
+		return nil, resp, err
+	}
+	if reply.Error != nil {
+		return nil, resp, reply.Error
+	}
+
+	var result *ToolCallResult
+	if len(reply.Result) > 0 {
+		if err := json.Unmarshal(reply.Result, &result); err != nil {
+			return nil, resp, fmt.Errorf("decode tools/call result; %w", err)
+		}
+	}
+
+	return result, resp, nil
+}
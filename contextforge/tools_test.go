@@ -3,10 +3,14 @@ package contextforge
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
+
+	"github.com/leefowlercu/go-contextforge/jsonschema"
 )
 
 func setup() (client *Client, mux *http.ServeMux, serverURL string, teardown func()) {
@@ -259,3 +263,118 @@ func TestToolsService_SetState(t *testing.T) {
 		t.Errorf("Tools.SetState returned enabled = %v, want false", tool.Enabled)
 	}
 }
+
+func TestToolsService_Invoke(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/rpc", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var req struct {
+			JSONRPC string          `json:"jsonrpc"`
+			ID      json.RawMessage `json:"id"`
+			Method  string          `json:"method"`
+			Params  map[string]any  `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if req.JSONRPC != "2.0" || req.Method != "tools/call" || len(req.ID) == 0 {
+			t.Errorf("request = %+v, want a JSON-RPC 2.0 tools/call request", req)
+		}
+		if req.Params["name"] != "weather-get" {
+			t.Errorf("params.name = %v, want %q", req.Params["name"], "weather-get")
+		}
+		if args, _ := req.Params["arguments"].(map[string]any); args["city"] != "Paris" {
+			t.Errorf("params.arguments = %v, want city=Paris", req.Params["arguments"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"content":[{"type":"text","text":"Sunny"},{"type":"image","data":"aGk=","mimeType":"image/png"}],"structuredContent":{"temp":21},"isError":false}}`, req.ID)
+	})
+
+	result, _, err := client.Tools.Invoke(context.Background(), "weather-get", map[string]any{"city": "Paris"}, nil)
+	if err != nil {
+		t.Fatalf("Tools.Invoke returned error: %v", err)
+	}
+	if got := result.Text(); got != "Sunny" {
+		t.Errorf("Text() = %q, want %q", got, "Sunny")
+	}
+	if len(result.Content) != 2 || result.Content[1].Type != "image" || result.Content[1].Data != "aGk=" {
+		t.Errorf("Content = %+v, want text and image blocks", result.Content)
+	}
+	if got, _ := result.StructuredContent.(map[string]any); got["temp"] != float64(21) {
+		t.Errorf("StructuredContent = %v, want temp=21", result.StructuredContent)
+	}
+}
+
+func TestToolsService_Invoke_RPCError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/rpc", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":"1","error":{"code":-32601,"message":"Tool not found: missing"}}`)
+	})
+
+	_, _, err := client.Tools.Invoke(context.Background(), "missing", nil, nil)
+	var rpcErr *MCPError
+	if !errors.As(err, &rpcErr) {
+		t.Fatalf("Tools.Invoke error = %v, want *MCPError", err)
+	}
+	if rpcErr.Code != -32601 {
+		t.Errorf("MCPError.Code = %d, want %d", rpcErr.Code, -32601)
+	}
+}
+
+func TestToolsService_Invoke_InvalidArguments(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/rpc", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Tools.Invoke sent a request despite invalid arguments")
+	})
+
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"city":  map[string]any{"type": "string"},
+			"days":  map[string]any{"type": "integer"},
+			"units": map[string]any{"type": "string", "enum": []any{"metric", "imperial"}},
+		},
+		"required": []any{"city"},
+	}
+	args := map[string]any{"days": 1.5, "units": "kelvin"}
+
+	_, _, err := client.Tools.Invoke(context.Background(), "weather-get", args, &ToolInvokeOptions{InputSchema: schema})
+	var argErr *ToolArgumentsError
+	if !errors.As(err, &argErr) {
+		t.Fatalf("Tools.Invoke error = %v, want *ToolArgumentsError", err)
+	}
+	want := jsonschema.ValidationErrors{
+		{Path: "city", Message: "is required"},
+		{Path: "days", Message: "must be of type integer"},
+		{Path: "units", Message: `must be one of "metric", "imperial"`},
+	}
+	if !reflect.DeepEqual(argErr.Errors, want) {
+		t.Errorf("ToolArgumentsError.Errors = %v, want %v", argErr.Errors, want)
+	}
+}
+
+func TestToolsService_Invoke_InvalidSchema(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	schema := map[string]any{"type": "object", "properties": map[string]any{"city": map[string]any{"type": "text"}}}
+
+	_, _, err := client.Tools.Invoke(context.Background(), "weather-get", nil, &ToolInvokeOptions{InputSchema: schema})
+	var errs jsonschema.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Tools.Invoke error = %v, want jsonschema.ValidationErrors", err)
+	}
+	var argErr *ToolArgumentsError
+	if errors.As(err, &argErr) {
+		t.Errorf("Tools.Invoke error = %v, want a schema error rather than *ToolArgumentsError", err)
+	}
+}
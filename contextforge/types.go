@@ -3,6 +3,7 @@ package contextforge
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"sync"
@@ -89,8 +90,7 @@ func (f FlexibleID) String() string {
 
 // Client manages communication with the ContextForge MCP Gateway API.
 type Client struct {
-	clientMu sync.Mutex   // protects the client during calls
-	client   *http.Client // HTTP client used to communicate with the API
+	client *http.Client // HTTP client used to communicate with the API
 
 	// Address for API requests.
 	// Defaults to http://localhost:8000/, but can be
@@ -103,6 +103,37 @@ type Client struct {
 	// Bearer token (JWT) for API authentication
 	BearerToken string
 
+	// TokenSource, if set, supplies the bearer token for every request
+	// attempt and takes precedence over BearerToken.
+	TokenSource TokenSource
+
+	// Logger receives diagnostic output from the client, including a record
+	// of every request. A nil Logger disables logging.
+	Logger *slog.Logger
+
+	// LogOptions controls the levels of the request records written to
+	// Logger and whether they include bodies. A nil LogOptions uses
+	// DefaultLogOptions.
+	LogOptions *LogOptions
+
+	// Instrumentation, if set, observes every API request, for example to
+	// trace it. See the otelcontextforge package.
+	Instrumentation Instrumentation
+
+	// Middleware wraps every API request, including streamed ones, the
+	// first element outermost. See Middleware.
+	Middleware []Middleware
+
+	// RetryPolicy controls automatic retries of failed requests.
+	// A nil policy disables retries.
+	RetryPolicy *RetryPolicy
+
+	// RespectRateLimits enables the client-side rate limiter. When true,
+	// requests to a path whose last observed Rate has no remaining requests
+	// block until Rate.Reset, and 429 responses carrying Retry-After are
+	// retried once after the indicated delay.
+	RespectRateLimits bool
+
 	common service // Reuse a single struct instead of allocating one for each service
 
 	// Services used for talking to different parts of the ContextForge API
@@ -114,6 +145,11 @@ type Client struct {
 	Agents    *AgentsService
 	Teams     *TeamsService
 	Cancel    *CancellationService
+	Auth      *AuthService
+	Export    *ExportService
+
+	// Additional headers sent with every request
+	headers http.Header
 
 	// Rate limit tracking
 	rateMu     sync.Mutex
@@ -157,6 +193,14 @@ type TeamsService service
 // methods of the ContextForge API.
 type CancellationService service
 
+// AuthService handles communication with the authentication and API token
+// related methods of the ContextForge API.
+type AuthService service
+
+// ExportService handles communication with the catalog export and import
+// related methods of the ContextForge API.
+type ExportService service
+
 // Response wraps the standard http.Response and provides convenient access to
 // pagination and rate limit information.
 type Response struct {
@@ -165,8 +209,16 @@ type Response struct {
 	// Pagination cursor extracted from response
 	NextCursor string
 
+	// Total number of items reported by offset-paginated list endpoints
+	// (for example TeamsService.List). Zero when the endpoint does not report it.
+	Total int
+
 	// Rate limiting information
 	Rate Rate
+
+	// Attempts is the number of HTTP attempts made for this request,
+	// including retries performed according to Client.RetryPolicy.
+	Attempts int
 }
 
 // Rate represents the rate limit information returned in API responses.
@@ -196,6 +248,10 @@ type ListOptions struct {
 	// IncludePagination requests body-based pagination metadata in API responses.
 	// When true, list endpoints return an object with items and nextCursor fields.
 	IncludePagination bool `url:"include_pagination,omitempty"`
+
+	// MaxItems caps the total number of items yielded by the All iterators.
+	// Zero means no cap. It is not sent to the API.
+	MaxItems int `url:"-"`
 }
 
 // Tag represents a tag that can be unmarshaled from either a string or an object.
@@ -326,6 +382,15 @@ type ToolCreateOptions struct {
 	Visibility *string
 }
 
+// ToolInvokeOptions specifies the optional parameters to the
+// ToolsService.Invoke method.
+type ToolInvokeOptions struct {
+	// InputSchema, if set, is the JSON Schema the arguments are checked
+	// against before the tool is invoked, typically the InputSchema of the
+	// Tool being called. See package jsonschema for the keywords checked.
+	InputSchema map[string]any
+}
+
 // Resource represents a ContextForge resource (read response).
 type Resource struct {
 	// Core fields
@@ -918,6 +983,11 @@ type AgentListOptions struct {
 	// IncludePagination requests body-based pagination metadata in responses.
 	IncludePagination bool `url:"include_pagination,omitempty"`
 
+	// MaxItems caps the total number of items yielded by AgentsService.All
+	// and AgentsService.AllBySkip.
+	// Zero means no cap. It is not sent to the API.
+	MaxItems int `url:"-"`
+
 	// IncludeInactive includes inactive agents in the results
 	IncludeInactive bool `url:"include_inactive,omitempty"`
 
@@ -944,6 +1014,282 @@ type AgentInvokeRequest struct {
 	InteractionType string         `json:"interaction_type,omitempty"` // default: "query"
 }
 
+// A2ATaskState is the lifecycle state of an A2A task.
+type A2ATaskState string
+
+// A2A task states.
+const (
+	A2ATaskStateSubmitted     A2ATaskState = "submitted"
+	A2ATaskStateWorking       A2ATaskState = "working"
+	A2ATaskStateInputRequired A2ATaskState = "input-required"
+	A2ATaskStateAuthRequired  A2ATaskState = "auth-required"
+	A2ATaskStateCompleted     A2ATaskState = "completed"
+	A2ATaskStateCanceled      A2ATaskState = "canceled"
+	A2ATaskStateFailed        A2ATaskState = "failed"
+	A2ATaskStateRejected      A2ATaskState = "rejected"
+	A2ATaskStateUnknown       A2ATaskState = "unknown"
+)
+
+// Terminal reports whether the task can make no further progress.
+func (s A2ATaskState) Terminal() bool {
+	switch s {
+	case A2ATaskStateCompleted, A2ATaskStateCanceled, A2ATaskStateFailed, A2ATaskStateRejected:
+		return true
+	}
+	return false
+}
+
+// A2ATask represents an A2A task returned by an agent.
+type A2ATask struct {
+	ID        string         `json:"id"`
+	ContextID string         `json:"contextId,omitempty"`
+	Status    *A2ATaskStatus `json:"status,omitempty"`
+	Artifacts []*A2AArtifact `json:"artifacts,omitempty"`
+	History   []*A2AMessage  `json:"history,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+	Kind      string         `json:"kind,omitempty"` // Always "task"
+}
+
+// A2ATaskStatus represents the current status of an A2A task.
+type A2ATaskStatus struct {
+	State     A2ATaskState `json:"state"`
+	Message   *A2AMessage  `json:"message,omitempty"`
+	Timestamp *Timestamp   `json:"timestamp,omitempty"`
+}
+
+// A2AMessage represents a message exchanged with an A2A agent.
+type A2AMessage struct {
+	Role      string         `json:"role"` // "user" or "agent"
+	Parts     []*A2APart     `json:"parts"`
+	MessageID string         `json:"messageId,omitempty"`
+	TaskID    string         `json:"taskId,omitempty"`
+	ContextID string         `json:"contextId,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+	Kind      string         `json:"kind,omitempty"` // Always "message"
+}
+
+// Text returns the concatenated content of the message's text parts.
+func (m *A2AMessage) Text() string {
+	if m == nil {
+		return ""
+	}
+	var text string
+	for _, p := range m.Parts {
+		if p != nil && p.Kind == "text" {
+			text += p.Text
+		}
+	}
+	return text
+}
+
+// A2APart represents one part of an A2A message or artifact.
+// Kind selects which of Text, File, or Data is populated.
+type A2APart struct {
+	Kind     string         `json:"kind"` // "text", "file", or "data"
+	Text     string         `json:"text,omitempty"`
+	File     *A2AFile       `json:"file,omitempty"`
+	Data     map[string]any `json:"data,omitempty"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler for A2APart. Agents implementing
+// earlier revisions of the A2A protocol name the discriminator "type" rather
+// than "kind"; both are accepted.
+func (p *A2APart) UnmarshalJSON(data []byte) error {
+	type part A2APart
+	var aux struct {
+		part
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*p = A2APart(aux.part)
+	if p.Kind == "" {
+		p.Kind = aux.Type
+	}
+	return nil
+}
+
+// A2AFile represents file content in an A2A part. Exactly one of Bytes
+// (base64-encoded content) or URI is set.
+type A2AFile struct {
+	Name     *string `json:"name,omitempty"`
+	MimeType *string `json:"mimeType,omitempty"`
+	Bytes    *string `json:"bytes,omitempty"`
+	URI      *string `json:"uri,omitempty"`
+}
+
+// A2AArtifact represents an output produced by an A2A task.
+type A2AArtifact struct {
+	ArtifactID  string         `json:"artifactId"`
+	Name        *string        `json:"name,omitempty"`
+	Description *string        `json:"description,omitempty"`
+	Parts       []*A2APart     `json:"parts"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+}
+
+// A2AError represents a JSON-RPC error returned by an A2A agent.
+type A2AError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+func (e *A2AError) Error() string {
+	return fmt.Sprintf("a2a error %d: %s", e.Code, e.Message)
+}
+
+// AgentInvokeResult represents the response from invoking an A2A agent.
+//
+// Agents reply with a JSON-RPC envelope whose result is either a task or a
+// direct message, or whose error describes a failure. The decoded payload is
+// available in Task, Message, or Error; agents that reply with another shape
+// leave all three nil. Raw always holds the response body as received.
+type AgentInvokeResult struct {
+	Task    *A2ATask
+	Message *A2AMessage
+	Error   *A2AError
+
+	// Raw is the undecoded response body.
+	Raw json.RawMessage
+}
+
+// UnmarshalJSON implements json.Unmarshaler for AgentInvokeResult.
+func (r *AgentInvokeResult) UnmarshalJSON(data []byte) error {
+	p, err := decodeA2APayload(data)
+	if err != nil {
+		return err
+	}
+
+	*r = AgentInvokeResult{
+		Task:    p.task,
+		Message: p.message,
+		Error:   p.err,
+		Raw:     append(json.RawMessage(nil), data...),
+	}
+	return nil
+}
+
+// a2aPayload holds the decoded form of an A2A response or stream event.
+type a2aPayload struct {
+	task           *A2ATask
+	message        *A2AMessage
+	statusUpdate   *A2ATaskStatusUpdateEvent
+	artifactUpdate *A2ATaskArtifactUpdateEvent
+	err            *A2AError
+}
+
+// decodeA2APayload decodes data, optionally wrapped in a JSON-RPC envelope,
+// according to its A2A kind. Data that is not a recognizable A2A object
+// decodes to an empty payload without error.
+func decodeA2APayload(data []byte) (*a2aPayload, error) {
+	p := &a2aPayload{}
+
+	var envelope struct {
+		Result json.RawMessage `json:"result"`
+		Error  *A2AError       `json:"error"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return p, nil
+	}
+	p.err = envelope.Error
+
+	// Agents that reply without a JSON-RPC envelope return the object itself.
+	payload := envelope.Result
+	if payload == nil && envelope.Error == nil {
+		payload = data
+	}
+
+	var probe struct {
+		Kind     string          `json:"kind"`
+		Status   json.RawMessage `json:"status"`
+		Parts    json.RawMessage `json:"parts"`
+		Artifact json.RawMessage `json:"artifact"`
+		TaskID   string          `json:"taskId"`
+	}
+	if err := json.Unmarshal(payload, &probe); err != nil {
+		return p, nil
+	}
+
+	// Older agents omit kind, so fall back to the shape of the object.
+	kind := probe.Kind
+	if kind == "" {
+		switch {
+		case len(probe.Artifact) > 0 && probe.TaskID != "":
+			kind = "artifact-update"
+		case len(probe.Status) > 0 && probe.Status[0] == '{' && probe.TaskID != "":
+			kind = "status-update"
+		case len(probe.Status) > 0 && probe.Status[0] == '{':
+			kind = "task"
+		case len(probe.Parts) > 0:
+			kind = "message"
+		}
+	}
+
+	var target any
+	switch kind {
+	case "task":
+		p.task = &A2ATask{}
+		target = p.task
+	case "message":
+		p.message = &A2AMessage{}
+		target = p.message
+	case "status-update":
+		p.statusUpdate = &A2ATaskStatusUpdateEvent{}
+		target = p.statusUpdate
+	case "artifact-update":
+		p.artifactUpdate = &A2ATaskArtifactUpdateEvent{}
+		target = p.artifactUpdate
+	default:
+		return p, nil
+	}
+	if err := json.Unmarshal(payload, target); err != nil {
+		return nil, fmt.Errorf("decode a2a %s; %w", kind, err)
+	}
+
+	return p, nil
+}
+
+// A2ATaskStatusUpdateEvent reports a change in a streaming task's status.
+type A2ATaskStatusUpdateEvent struct {
+	TaskID    string         `json:"taskId"`
+	ContextID string         `json:"contextId,omitempty"`
+	Status    *A2ATaskStatus `json:"status"`
+	Final     bool           `json:"final,omitempty"` // True for the last event of the stream
+	Metadata  map[string]any `json:"metadata,omitempty"`
+	Kind      string         `json:"kind,omitempty"` // Always "status-update"
+}
+
+// A2ATaskArtifactUpdateEvent delivers an artifact, or a chunk of one, produced
+// by a streaming task.
+type A2ATaskArtifactUpdateEvent struct {
+	TaskID    string         `json:"taskId"`
+	ContextID string         `json:"contextId,omitempty"`
+	Artifact  *A2AArtifact   `json:"artifact"`
+	Append    bool           `json:"append,omitempty"`    // Append to a previously sent artifact with the same ID
+	LastChunk bool           `json:"lastChunk,omitempty"` // Final chunk of the artifact
+	Metadata  map[string]any `json:"metadata,omitempty"`
+	Kind      string         `json:"kind,omitempty"` // Always "artifact-update"
+}
+
+// AgentStreamEvent is one event received from AgentsService.InvokeStream.
+// Exactly one of StatusUpdate, ArtifactUpdate, Task, Message, or Error is set
+// for A2A events; agents that send another shape leave all of them nil.
+type AgentStreamEvent struct {
+	// ID is the server-sent event ID, if any.
+	ID string
+
+	StatusUpdate   *A2ATaskStatusUpdateEvent
+	ArtifactUpdate *A2ATaskArtifactUpdateEvent
+	Task           *A2ATask
+	Message        *A2AMessage
+	Error          *A2AError
+
+	// Raw is the undecoded event data.
+	Raw json.RawMessage
+}
+
 // ResourceInfoOptions specifies optional parameters for ResourcesService.GetInfo.
 type ResourceInfoOptions struct {
 	IncludeInactive bool `url:"include_inactive,omitempty"`
@@ -996,6 +1342,223 @@ type CancellationStatus struct {
 	CancelReason *string  `json:"cancel_reason,omitempty"`
 }
 
+// Export entity types, as accepted by ExportOptions.Types and
+// ExportOptions.ExcludeTypes.
+const (
+	ExportTypeTools     = "tools"
+	ExportTypeGateways  = "gateways"
+	ExportTypeServers   = "servers"
+	ExportTypePrompts   = "prompts"
+	ExportTypeResources = "resources"
+	ExportTypeRoots     = "roots"
+)
+
+// ExportOptions specifies the optional parameters to the
+// ExportService.Export method.
+type ExportOptions struct {
+	// Types limits the export to the given entity types (ExportTypeTools,
+	// ExportTypeGateways, ...). All types are exported when empty.
+	Types []string `url:"types,comma,omitempty"`
+
+	// ExcludeTypes omits the given entity types from the export
+	ExcludeTypes []string `url:"exclude_types,comma,omitempty"`
+
+	// Tags filters entities by tags (comma-separated)
+	Tags string `url:"tags,omitempty"`
+
+	// IncludeInactive includes inactive entities in the export
+	IncludeInactive bool `url:"include_inactive,omitempty"`
+
+	// IncludeDependencies includes the entities that exported servers and
+	// gateways depend on, even when their types are not selected
+	IncludeDependencies *bool `url:"include_dependencies,omitempty"`
+}
+
+// ExportDocument represents a ContextForge catalog export, as returned by
+// ExportService.Export and accepted by ExportService.Import.
+type ExportDocument struct {
+	Version          string          `json:"version"`
+	ExportedAt       *Timestamp      `json:"exported_at,omitempty"`
+	ExportedBy       string          `json:"exported_by,omitempty"`
+	SourceGateway    string          `json:"source_gateway,omitempty"`
+	EncryptionMethod string          `json:"encryption_method,omitempty"`
+	Entities         ExportEntities  `json:"entities"`
+	Metadata         *ExportMetadata `json:"metadata,omitempty"`
+}
+
+// ExportEntities holds the exported entities, grouped by type.
+type ExportEntities struct {
+	Tools     []ExportEntity `json:"tools,omitempty"`
+	Gateways  []ExportEntity `json:"gateways,omitempty"`
+	Servers   []ExportEntity `json:"servers,omitempty"`
+	Prompts   []ExportEntity `json:"prompts,omitempty"`
+	Resources []ExportEntity `json:"resources,omitempty"`
+	Roots     []ExportEntity `json:"roots,omitempty"`
+}
+
+// ExportEntity is a single exported entity. Its fields follow the export
+// format of the entity type rather than the API types (Tool, Gateway, ...)
+// and are kept as decoded, so that a document read by Export is imported
+// unchanged. Authentication values are encrypted with the exporting
+// gateway's secret.
+type ExportEntity map[string]any
+
+// Name returns the entity's name, or its URI for roots.
+func (e ExportEntity) Name() string {
+	if name, ok := e["name"].(string); ok {
+		return name
+	}
+	uri, _ := e["uri"].(string)
+	return uri
+}
+
+// Tags returns the entity's tags.
+func (e ExportEntity) Tags() []string {
+	raw, _ := e["tags"].([]any)
+	tags := make([]string, 0, len(raw))
+	for _, t := range raw {
+		switch t := t.(type) {
+		case string:
+			tags = append(tags, t)
+		case map[string]any:
+			if id, ok := t["id"].(string); ok {
+				tags = append(tags, id)
+			}
+		}
+	}
+	return tags
+}
+
+// IsActive reports whether the entity was active when exported. Entities
+// without an is_active field are reported active.
+func (e ExportEntity) IsActive() bool {
+	active, ok := e["is_active"].(bool)
+	return active || !ok
+}
+
+// ExportMetadata represents the metadata section of an ExportDocument.
+type ExportMetadata struct {
+	EntityCounts  map[string]int `json:"entity_counts,omitempty"`
+	Dependencies  map[string]any `json:"dependencies,omitempty"`
+	ExportOptions map[string]any `json:"export_options,omitempty"`
+}
+
+// ConflictStrategy selects how ExportService.Import handles entities that
+// already exist on the target gateway.
+type ConflictStrategy string
+
+// Conflict strategies accepted by ImportOptions.ConflictStrategy.
+const (
+	// ConflictSkip leaves existing entities unchanged.
+	ConflictSkip ConflictStrategy = "skip"
+	// ConflictUpdate overwrites existing entities with the imported ones.
+	ConflictUpdate ConflictStrategy = "update"
+	// ConflictRename imports conflicting entities under a new name.
+	ConflictRename ConflictStrategy = "rename"
+	// ConflictFail fails the import at the first conflict.
+	ConflictFail ConflictStrategy = "fail"
+)
+
+// ImportOptions specifies the optional parameters to the
+// ExportService.Import method.
+type ImportOptions struct {
+	// ConflictStrategy defaults to ConflictUpdate on the server
+	ConflictStrategy ConflictStrategy
+
+	// DryRun validates the document and reports what would change without
+	// modifying anything
+	DryRun bool
+
+	// RekeySecret re-encrypts authentication values with a new secret when
+	// the document was exported from a gateway with a different one
+	RekeySecret *string
+
+	// SelectedEntities limits the import to the named entities, keyed by
+	// entity type (for example {"tools": {"search"}})
+	SelectedEntities map[string][]string
+}
+
+// importRequest is the request body of ExportService.Import.
+type importRequest struct {
+	ImportData       *ExportDocument     `json:"import_data"`
+	ConflictStrategy ConflictStrategy    `json:"conflict_strategy,omitempty"`
+	DryRun           bool                `json:"dry_run"`
+	RekeySecret      *string             `json:"rekey_secret,omitempty"`
+	SelectedEntities map[string][]string `json:"selected_entities,omitempty"`
+}
+
+// ImportResult reports the outcome of an import: entity counts in Progress,
+// what happened to each entity in Entities, and messages about individual
+// entities, such as conflicts that were skipped or entities that failed to
+// import, in Warnings and Errors.
+type ImportResult struct {
+	ImportID    string          `json:"import_id"`
+	Status      string          `json:"status"`
+	Progress    *ImportProgress `json:"progress,omitempty"`
+	Warnings    []string        `json:"warnings,omitempty"`
+	Errors      []string        `json:"errors,omitempty"`
+	StartedAt   *Timestamp      `json:"started_at,omitempty"`
+	CompletedAt *Timestamp      `json:"completed_at,omitempty"`
+
+	// DryRun reports whether the import was a dry run, in which case
+	// Entities describes what the import would have done.
+	DryRun bool `json:"dry_run,omitempty"`
+
+	// Entities reports the action taken for each entity, as listed by the
+	// server in its per-entity results.
+	//
+	// Servers that report only counts and messages return no per-entity
+	// results. Entities then lists only the skipped and failed entities,
+	// parsed best-effort from the text of Warnings and Errors: created,
+	// updated, and renamed entities are counted in Progress but not listed,
+	// and entities named in messages of another form are missing.
+	Entities []ImportEntityResult `json:"entities,omitempty"`
+}
+
+// ImportAction is the action an import took for an entity.
+type ImportAction string
+
+// Actions reported by ImportEntityResult.Action.
+const (
+	ImportCreated ImportAction = "created"
+	ImportUpdated ImportAction = "updated"
+	ImportRenamed ImportAction = "renamed"
+	ImportSkipped ImportAction = "skipped"
+	ImportFailed  ImportAction = "failed"
+)
+
+// ImportEntityResult reports what an import did, or would do in a dry run,
+// with one entity of the imported document.
+type ImportEntityResult struct {
+	// EntityType is the type of the entity as named by the server, such
+	// as "tool" or "gateway".
+	EntityType string `json:"entity_type"`
+
+	// Name is the name of the entity in the imported document.
+	Name string `json:"name"`
+
+	// ID is the ID of the entity on the target gateway, if known.
+	ID string `json:"id,omitempty"`
+
+	Action ImportAction `json:"action"`
+
+	// NewName is the name the entity was imported under, for ImportRenamed.
+	NewName string `json:"new_name,omitempty"`
+
+	// Error explains why the entity was skipped or failed to import.
+	Error string `json:"error,omitempty"`
+}
+
+// ImportProgress counts the entities processed by an import.
+type ImportProgress struct {
+	Total     int `json:"total"`
+	Processed int `json:"processed"`
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Skipped   int `json:"skipped"`
+	Failed    int `json:"failed"`
+}
+
 // Team represents a ContextForge team.
 type Team struct {
 	ID          string     `json:"id"`
@@ -1047,6 +1610,10 @@ type TeamListOptions struct {
 
 	// Limit specifies the maximum number of items to return (max: 100, default: 50)
 	Limit int `url:"limit,omitempty"`
+
+	// MaxItems caps the total number of items yielded by the iterator
+	// methods. Zero means no cap. It is not sent to the API.
+	MaxItems int `url:"-"`
 }
 
 // TeamMember represents a member of a team.
@@ -1103,6 +1670,10 @@ type TeamDiscoverOptions struct {
 
 	// Limit specifies the maximum number of items to return (max: 100, default: 50)
 	Limit int `url:"limit,omitempty"`
+
+	// MaxItems caps the total number of items yielded by the iterator
+	// methods. Zero means no cap. It is not sent to the API.
+	MaxItems int `url:"-"`
 }
 
 // TeamJoinRequest represents the request body for joining a team.
@@ -1166,3 +1737,244 @@ type PromptMessageContent struct {
 	// JSON/Image content fields (data can be string for images or any for JSON)
 	Data any `json:"data,omitempty"`
 }
+
+// LoginRequest represents the request body for email/password login.
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// LoginResponse represents the result of a successful login.
+type LoginResponse struct {
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type"`
+	ExpiresIn   int       `json:"expires_in"`
+	User        *AuthUser `json:"user,omitempty"`
+}
+
+// AuthUser represents an authenticated ContextForge user.
+type AuthUser struct {
+	Email                  string     `json:"email"`
+	FullName               *string    `json:"full_name,omitempty"`
+	IsAdmin                bool       `json:"is_admin"`
+	IsActive               bool       `json:"is_active"`
+	AuthProvider           string     `json:"auth_provider"`
+	EmailVerified          bool       `json:"email_verified"`
+	PasswordChangeRequired bool       `json:"password_change_required"`
+	CreatedAt              *Timestamp `json:"created_at,omitempty"`
+	LastLogin              *Timestamp `json:"last_login,omitempty"`
+}
+
+// APITokenScope restricts what an API token may be used for.
+type APITokenScope struct {
+	ServerID         *string        `json:"server_id,omitempty"`
+	Permissions      []string       `json:"permissions,omitempty"`
+	IPRestrictions   []string       `json:"ip_restrictions,omitempty"`
+	TimeRestrictions map[string]any `json:"time_restrictions,omitempty"`
+	UsageLimits      map[string]any `json:"usage_limits,omitempty"`
+}
+
+// APITokenCreate represents the request body for creating an API token.
+type APITokenCreate struct {
+	Name          string         `json:"name"`
+	Description   *string        `json:"description,omitempty"`
+	ExpiresInDays *int           `json:"expires_in_days,omitempty"`
+	Scope         *APITokenScope `json:"scope,omitempty"`
+	Tags          []string       `json:"tags,omitempty"`
+
+	// TeamID scopes the token to a team. It selects the team token endpoint
+	// and is not sent in the request body.
+	TeamID *string `json:"-"`
+}
+
+// APIToken represents an API token. The token secret is never included.
+type APIToken struct {
+	ID               string         `json:"id"`
+	Name             string         `json:"name"`
+	Description      *string        `json:"description,omitempty"`
+	UserEmail        string         `json:"user_email"`
+	TeamID           *string        `json:"team_id,omitempty"`
+	ServerID         *string        `json:"server_id,omitempty"`
+	ResourceScopes   []string       `json:"resource_scopes,omitempty"`
+	IPRestrictions   []string       `json:"ip_restrictions,omitempty"`
+	TimeRestrictions map[string]any `json:"time_restrictions,omitempty"`
+	UsageLimits      map[string]any `json:"usage_limits,omitempty"`
+	Tags             []string       `json:"tags,omitempty"`
+	CreatedAt        *Timestamp     `json:"created_at,omitempty"`
+	ExpiresAt        *Timestamp     `json:"expires_at,omitempty"`
+	LastUsed         *Timestamp     `json:"last_used,omitempty"`
+	IsActive         bool           `json:"is_active"`
+	IsRevoked        bool           `json:"is_revoked"`
+	RevokedAt        *Timestamp     `json:"revoked_at,omitempty"`
+	RevokedBy        *string        `json:"revoked_by,omitempty"`
+	RevocationReason *string        `json:"revocation_reason,omitempty"`
+}
+
+// APITokenCreateResponse represents the result of creating an API token.
+// AccessToken holds the token secret, which is not retrievable later.
+type APITokenCreateResponse struct {
+	Token       *APIToken `json:"token"`
+	AccessToken string    `json:"access_token"`
+}
+
+// APITokenListOptions specifies the optional parameters for listing API tokens.
+type APITokenListOptions struct {
+	// IncludeInactive includes expired and revoked tokens
+	IncludeInactive bool `url:"include_inactive,omitempty"`
+
+	// Limit specifies the maximum number of tokens to return
+	Limit int `url:"limit,omitempty"`
+
+	// Offset specifies the number of tokens to skip
+	Offset int `url:"offset,omitempty"`
+}
+
+// APITokenListResponse represents the response from listing API tokens.
+type APITokenListResponse struct {
+	Tokens []*APIToken `json:"tokens"`
+	Total  int         `json:"total"`
+	Limit  int         `json:"limit"`
+	Offset int         `json:"offset"`
+}
+
+// APITokenRevokeRequest represents the optional request body for revoking an API token.
+type APITokenRevokeRequest struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
+// MCPSessionOptions specifies the optional parameters for opening an MCP
+// session with ServersService.Connect.
+type MCPSessionOptions struct {
+	// Transport selects how the session talks to the server: either
+	// MCPTransportStreamableHTTP (the default) or MCPTransportSSE for
+	// servers that only expose the legacy SSE endpoint.
+	Transport string
+
+	// ProtocolVersion is the MCP protocol version requested during
+	// initialization. Defaults to DefaultMCPProtocolVersion.
+	ProtocolVersion string
+
+	// ClientInfo identifies the client to the server. Defaults to the SDK
+	// name and version.
+	ClientInfo *MCPImplementation
+}
+
+// MCPImplementation describes an MCP client or server implementation.
+type MCPImplementation struct {
+	Name    string  `json:"name"`
+	Title   *string `json:"title,omitempty"`
+	Version string  `json:"version"`
+}
+
+// MCPServerCapabilities describes the features an MCP server supports.
+// A non-nil field means the capability is present; its map holds the
+// capability's sub-options, such as "listChanged".
+type MCPServerCapabilities struct {
+	Tools       map[string]any `json:"tools,omitempty"`
+	Resources   map[string]any `json:"resources,omitempty"`
+	Prompts     map[string]any `json:"prompts,omitempty"`
+	Logging     map[string]any `json:"logging,omitempty"`
+	Completions map[string]any `json:"completions,omitempty"`
+}
+
+// MCPInitializeResult represents the server's reply to the MCP initialize
+// handshake.
+type MCPInitializeResult struct {
+	ProtocolVersion string                 `json:"protocolVersion"`
+	Capabilities    *MCPServerCapabilities `json:"capabilities,omitempty"`
+	ServerInfo      *MCPImplementation     `json:"serverInfo,omitempty"`
+	Instructions    *string                `json:"instructions,omitempty"`
+}
+
+// MCPTool represents a tool as advertised by an MCP server's tools/list.
+type MCPTool struct {
+	Name         string         `json:"name"`
+	Title        *string        `json:"title,omitempty"`
+	Description  *string        `json:"description,omitempty"`
+	InputSchema  map[string]any `json:"inputSchema,omitempty"`
+	OutputSchema map[string]any `json:"outputSchema,omitempty"`
+	Annotations  map[string]any `json:"annotations,omitempty"`
+}
+
+// MCPListToolsResult represents one page of an MCP tools/list response.
+type MCPListToolsResult struct {
+	Tools      []*MCPTool `json:"tools"`
+	NextCursor string     `json:"nextCursor,omitempty"`
+}
+
+// MCPContent represents one content block in an MCP tool result or prompt
+// message. Type selects which fields are populated:
+//   - "text": Text
+//   - "image" and "audio": Data (base64-encoded) and MimeType
+//   - "resource": Resource, an embedded resource
+//   - "resource_link": URI, Name, and optionally MimeType
+type MCPContent struct {
+	Type        string               `json:"type"`
+	Text        string               `json:"text,omitempty"`
+	Data        string               `json:"data,omitempty"`
+	MimeType    *string              `json:"mimeType,omitempty"`
+	Resource    *MCPResourceContents `json:"resource,omitempty"`
+	URI         *string              `json:"uri,omitempty"`
+	Name        *string              `json:"name,omitempty"`
+	Annotations map[string]any       `json:"annotations,omitempty"`
+}
+
+// MCPResourceContents represents the contents of a resource. Exactly one of
+// Text or Blob (base64-encoded) is set.
+type MCPResourceContents struct {
+	URI      string  `json:"uri"`
+	MimeType *string `json:"mimeType,omitempty"`
+	Text     *string `json:"text,omitempty"`
+	Blob     *string `json:"blob,omitempty"`
+}
+
+// ToolCallResult represents the result of calling a tool over MCP.
+// IsError reports a failure inside the tool itself, as opposed to a
+// protocol error, which is returned as an *MCPError.
+type ToolCallResult struct {
+	Content           []*MCPContent `json:"content"`
+	StructuredContent any           `json:"structuredContent,omitempty"`
+	IsError           bool          `json:"isError,omitempty"`
+}
+
+// Text returns the concatenated content of the result's text blocks.
+func (r *ToolCallResult) Text() string {
+	if r == nil {
+		return ""
+	}
+	var text string
+	for _, c := range r.Content {
+		if c != nil && c.Type == "text" {
+			text += c.Text
+		}
+	}
+	return text
+}
+
+// MCPReadResourceResult represents the result of an MCP resources/read.
+type MCPReadResourceResult struct {
+	Contents []*MCPResourceContents `json:"contents"`
+}
+
+// MCPPromptMessage represents a message in an MCP prompts/get result.
+type MCPPromptMessage struct {
+	Role    string      `json:"role"` // "user" or "assistant"
+	Content *MCPContent `json:"content"`
+}
+
+// MCPGetPromptResult represents the result of an MCP prompts/get.
+type MCPGetPromptResult struct {
+	Description *string             `json:"description,omitempty"`
+	Messages    []*MCPPromptMessage `json:"messages"`
+}
+
+// MCPError represents a JSON-RPC error returned by an MCP server.
+type MCPError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+func (e *MCPError) Error() string {
+	return fmt.Sprintf("mcp error %d: %s", e.Code, e.Message)
+}
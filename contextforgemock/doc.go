@@ -0,0 +1,28 @@
+// Package contextforgemock provides mock implementations of the service
+// interfaces of the contextforge package, for unit tests of code that
+// accepts a contextforge.ToolsAPI, contextforge.TeamsAPI, and so on.
+//
+// Each mock has the name of the interface it implements and a <Method>Func
+// field for every method. A method calls its Func field if it is set;
+// otherwise it returns the canned results set with Return, or zero values;
+// All and the other methods returning an iterator return an empty one.
+// Every call is recorded, without its context, for inspection with Calls
+// and CallsTo:
+//
+//	tools := &contextforgemock.ToolsAPI{}
+//	tools.Return("Get", &contextforge.Tool{ID: "t1", Name: "search"}, &contextforge.Response{}, nil)
+//	tools.DeleteFunc = func(ctx context.Context, toolID string) (*contextforge.Response, error) {
+//		return nil, errors.New("forbidden")
+//	}
+//
+//	err := prune(ctx, tools) // the code under test
+//
+//	for _, call := range tools.CallsTo("Delete") {
+//		fmt.Println(call.Args[0]) // the tool ID
+//	}
+//
+// The mocks are generated from the interfaces by internal/mockgen; run
+// go generate in this directory after changing them.
+package contextforgemock
+
+//go:generate go run ../internal/mockgen -o mocks.go ../contextforge/interfaces.go
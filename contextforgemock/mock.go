@@ -0,0 +1,100 @@
+package contextforgemock
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// Call is a recorded call to a mock method.
+type Call struct {
+	// Method is the name of the method, e.g. "List".
+	Method string
+
+	// Args are the arguments of the call, except its context.
+	Args []any
+}
+
+// Mock records the calls made to a mock and holds its canned responses.
+// It is embedded in every mock of the package. Its methods are safe for
+// concurrent use.
+type Mock struct {
+	mu      sync.Mutex
+	calls   []Call
+	returns map[string][]any
+}
+
+// Return sets the results the named method returns when its Func field is
+// not set. The results are given in the order the method declares them; nil
+// leaves a result at its zero value. Return panics if a result does not
+// match the type of the method's result when the method is called.
+//
+//	m.Return("Get", &contextforge.Tool{ID: "t1"}, &contextforge.Response{}, nil)
+func (m *Mock) Return(method string, results ...any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.returns == nil {
+		m.returns = make(map[string][]any)
+	}
+	m.returns[method] = results
+}
+
+// Calls returns the calls made so far, in order.
+func (m *Mock) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.calls)
+}
+
+// CallsTo returns the calls made so far to the named method, in order.
+func (m *Mock) CallsTo(method string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var calls []Call
+	for _, c := range m.calls {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Reset forgets the recorded calls and the results set with Return.
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+	m.returns = nil
+}
+
+func (m *Mock) record(method string, args ...any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+}
+
+// results stores the results set with Return for method into the values
+// pointed to by ptrs, if any were set.
+func (m *Mock) results(method string, ptrs ...any) {
+	m.mu.Lock()
+	results, ok := m.returns[method]
+	m.mu.Unlock()
+	if !ok {
+		return
+	}
+	if len(results) != len(ptrs) {
+		panic(fmt.Sprintf("contextforgemock: Return(%q) set %d results, want %d", method, len(results), len(ptrs)))
+	}
+	for i, result := range results {
+		if result == nil {
+			continue
+		}
+		dst := reflect.ValueOf(ptrs[i]).Elem()
+		v := reflect.ValueOf(result)
+		if !v.Type().AssignableTo(dst.Type()) {
+			panic(fmt.Sprintf("contextforgemock: Return(%q) result %d is %T, want %s", method, i, result, dst.Type()))
+		}
+		dst.Set(v)
+	}
+}
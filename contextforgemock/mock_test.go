@@ -0,0 +1,196 @@
+package contextforgemock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+func TestMock_Return(t *testing.T) {
+	ctx := context.Background()
+	tools := &ToolsAPI{}
+
+	// Without a canned response, results are zero values.
+	tool, resp, err := tools.Get(ctx, "t1")
+	if tool != nil || resp != nil || err != nil {
+		t.Errorf("Get = %v, %v, %v; want zero values", tool, resp, err)
+	}
+
+	want := &contextforge.Tool{ID: "t1", Name: "search"}
+	tools.Return("Get", want, nil, nil)
+	tool, resp, err = tools.Get(ctx, "t1")
+	if tool != want || resp != nil || err != nil {
+		t.Errorf("Get = %v, %v, %v; want the canned tool", tool, resp, err)
+	}
+
+	wantErr := errors.New("forbidden")
+	tools.Return("Delete", nil, wantErr)
+	if _, err := tools.Delete(ctx, "t1"); err != wantErr {
+		t.Errorf("Delete error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestMock_Func(t *testing.T) {
+	ctx := context.Background()
+	teams := &TeamsAPI{}
+	teams.Return("Get", &contextforge.Team{ID: "ignored"}, nil, nil)
+	teams.GetFunc = func(ctx context.Context, teamID string) (*contextforge.Team, *contextforge.Response, error) {
+		return &contextforge.Team{ID: teamID}, nil, nil
+	}
+
+	team, _, err := teams.Get(ctx, "tm1")
+	if err != nil || team.ID != "tm1" {
+		t.Errorf("Get = %v, %v; want the result of GetFunc", team, err)
+	}
+}
+
+func TestMock_Calls(t *testing.T) {
+	ctx := context.Background()
+	servers := &ServersAPI{}
+	opts := &contextforge.ServerListOptions{IncludeInactive: true}
+
+	servers.List(ctx, opts)
+	servers.SetState(ctx, "s1", false)
+	servers.List(ctx, nil)
+
+	want := []Call{
+		{Method: "List", Args: []any{opts}},
+		{Method: "SetState", Args: []any{"s1", false}},
+		{Method: "List", Args: []any{(*contextforge.ServerListOptions)(nil)}},
+	}
+	if got := servers.Calls(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Calls() = %#v, want %#v", got, want)
+	}
+	if got := servers.CallsTo("List"); len(got) != 2 {
+		t.Errorf("CallsTo(List) returned %d calls, want 2", len(got))
+	}
+
+	servers.Reset()
+	if got := servers.Calls(); len(got) != 0 {
+		t.Errorf("Calls() after Reset = %v, want none", got)
+	}
+}
+
+func TestMock_ReturnMismatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []any
+		want    string
+	}{
+		{"wrong count", []any{&contextforge.Prompt{}}, "set 1 results, want 3"},
+		{"wrong type", []any{&contextforge.Tool{}, nil, nil}, "result 0 is *contextforge.Tool, want *contextforge.Prompt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompts := &PromptsAPI{}
+			prompts.Return("Get", tt.results...)
+			defer func() {
+				msg, _ := recover().(string)
+				if !strings.Contains(msg, tt.want) {
+					t.Errorf("panic = %q, want it to contain %q", msg, tt.want)
+				}
+			}()
+			prompts.Get(context.Background(), "p1", nil)
+		})
+	}
+}
+
+// prune deletes every inactive tool; it stands in for code under test that
+// accepts the interface.
+func prune(ctx context.Context, tools contextforge.ToolsAPI) error {
+	list, _, err := tools.List(ctx, &contextforge.ToolListOptions{IncludeInactive: true})
+	if err != nil {
+		return err
+	}
+	for _, tool := range list {
+		if !tool.Enabled {
+			if _, err := tools.Delete(ctx, tool.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func TestToolsAPI_Substitute(t *testing.T) {
+	tools := &ToolsAPI{}
+	tools.Return("List", []*contextforge.Tool{{ID: "t1", Enabled: true}, {ID: "t2"}}, nil, nil)
+
+	if err := prune(context.Background(), tools); err != nil {
+		t.Fatalf("prune returned error: %v", err)
+	}
+	deletes := tools.CallsTo("Delete")
+	if len(deletes) != 1 || deletes[0].Args[0] != "t2" {
+		t.Errorf("Delete calls = %v, want one for t2", deletes)
+	}
+}
+
+func TestMock_Streams(t *testing.T) {
+	ctx := context.Background()
+
+	agents := &AgentsAPI{}
+	event := &contextforge.AgentStreamEvent{ID: "1"}
+	agents.Return("InvokeStream", contextforge.NewAgentStream("req-1", func(yield func(*contextforge.AgentStreamEvent, error) bool) {
+		yield(event, nil)
+	}), nil, nil)
+	stream, _, err := agents.InvokeStream(ctx, "agent", nil)
+	if err != nil {
+		t.Fatalf("InvokeStream returned error: %v", err)
+	}
+	var got []*contextforge.AgentStreamEvent
+	for ev, err := range stream.Events() {
+		if err != nil {
+			t.Fatalf("Events yielded error: %v", err)
+		}
+		got = append(got, ev)
+	}
+	if len(got) != 1 || got[0] != event {
+		t.Errorf("Events yielded %v, want the canned event", got)
+	}
+
+	servers := &ServersAPI{}
+	servers.ConnectFunc = func(ctx context.Context, serverID string, opts *contextforge.MCPSessionOptions) (*contextforge.MCPSession, error) {
+		return contextforge.NewMCPSession(&contextforge.MCPInitializeResult{}, func(ctx context.Context, method string, params json.RawMessage) (any, error) {
+			return map[string]any{"content": []any{map[string]any{"type": "text", "text": serverID}}}, nil
+		}), nil
+	}
+	session, err := servers.Connect(ctx, "s1", nil)
+	if err != nil {
+		t.Fatalf("Connect returned error: %v", err)
+	}
+	result, err := session.CallTool(ctx, "echo", nil)
+	if err != nil {
+		t.Fatalf("CallTool returned error: %v", err)
+	}
+	if len(result.Content) != 1 || result.Content[0].Text != "s1" {
+		t.Errorf("CallTool content = %+v, want %q", result.Content, "s1")
+	}
+}
+
+func TestMock_EmptyIterators(t *testing.T) {
+	ctx := context.Background()
+	agents := &AgentsAPI{}
+	teams := &TeamsAPI{}
+
+	for item, err := range (&ToolsAPI{}).All(ctx, nil) {
+		t.Errorf("ToolsAPI.All yielded %v, %v; want nothing", item, err)
+	}
+	for item, err := range agents.AllBySkip(ctx, nil) {
+		t.Errorf("AgentsAPI.AllBySkip yielded %v, %v; want nothing", item, err)
+	}
+	for item, err := range teams.DiscoverAll(ctx, nil) {
+		t.Errorf("TeamsAPI.DiscoverAll yielded %v, %v; want nothing", item, err)
+	}
+
+	// Return(..., nil) leaves the result at its default.
+	teams.Return("All", nil)
+	for item, err := range teams.All(ctx, nil) {
+		t.Errorf("TeamsAPI.All yielded %v, %v; want nothing", item, err)
+	}
+}
@@ -0,0 +1,1374 @@
+// Code generated by mockgen from interfaces.go; DO NOT EDIT.
+
+package contextforgemock
+
+import (
+	"context"
+	"iter"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+var (
+	_ contextforge.ToolsAPI        = (*ToolsAPI)(nil)
+	_ contextforge.GatewaysAPI     = (*GatewaysAPI)(nil)
+	_ contextforge.ServersAPI      = (*ServersAPI)(nil)
+	_ contextforge.PromptsAPI      = (*PromptsAPI)(nil)
+	_ contextforge.ResourcesAPI    = (*ResourcesAPI)(nil)
+	_ contextforge.AgentsAPI       = (*AgentsAPI)(nil)
+	_ contextforge.TeamsAPI        = (*TeamsAPI)(nil)
+	_ contextforge.CancellationAPI = (*CancellationAPI)(nil)
+	_ contextforge.AuthAPI         = (*AuthAPI)(nil)
+	_ contextforge.ExportAPI       = (*ExportAPI)(nil)
+)
+
+// ToolsAPI is a mock of contextforge.ToolsAPI.
+type ToolsAPI struct {
+	Mock
+
+	// ListFunc, if set, implements List.
+	ListFunc func(ctx context.Context, opts *contextforge.ToolListOptions) ([]*contextforge.Tool, *contextforge.Response, error)
+
+	// AllFunc, if set, implements All.
+	AllFunc func(ctx context.Context, opts *contextforge.ToolListOptions) iter.Seq2[*contextforge.Tool, error]
+
+	// GetFunc, if set, implements Get.
+	GetFunc func(ctx context.Context, toolID string) (*contextforge.Tool, *contextforge.Response, error)
+
+	// CreateFunc, if set, implements Create.
+	CreateFunc func(ctx context.Context, tool *contextforge.Tool, opts *contextforge.ToolCreateOptions) (*contextforge.Tool, *contextforge.Response, error)
+
+	// UpdateFunc, if set, implements Update.
+	UpdateFunc func(ctx context.Context, toolID string, tool *contextforge.Tool) (*contextforge.Tool, *contextforge.Response, error)
+
+	// DeleteFunc, if set, implements Delete.
+	DeleteFunc func(ctx context.Context, toolID string) (*contextforge.Response, error)
+
+	// SetStateFunc, if set, implements SetState.
+	SetStateFunc func(ctx context.Context, toolID string, activate bool) (*contextforge.Tool, *contextforge.Response, error)
+
+	// ToggleFunc, if set, implements Toggle.
+	ToggleFunc func(ctx context.Context, toolID string, activate bool) (*contextforge.Tool, *contextforge.Response, error)
+
+	// InvokeFunc, if set, implements Invoke.
+	InvokeFunc func(ctx context.Context, name string, args map[string]any, opts *contextforge.ToolInvokeOptions) (*contextforge.ToolCallResult, *contextforge.Response, error)
+}
+
+// List records the call and returns the results of ListFunc, if set, or
+// the results set with Return("List").
+func (m *ToolsAPI) List(ctx context.Context, opts *contextforge.ToolListOptions) (r0 []*contextforge.Tool, r1 *contextforge.Response, r2 error) {
+	m.record("List", opts)
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, opts)
+	}
+	m.results("List", &r0, &r1, &r2)
+	return
+}
+
+// All records the call and returns the results of AllFunc, if set, or
+// the results set with Return("All").
+func (m *ToolsAPI) All(ctx context.Context, opts *contextforge.ToolListOptions) (r0 iter.Seq2[*contextforge.Tool, error]) {
+	m.record("All", opts)
+	if m.AllFunc != nil {
+		return m.AllFunc(ctx, opts)
+	}
+	m.results("All", &r0)
+	if r0 == nil {
+		r0 = func(func(*contextforge.Tool, error) bool) {}
+	}
+	return
+}
+
+// Get records the call and returns the results of GetFunc, if set, or
+// the results set with Return("Get").
+func (m *ToolsAPI) Get(ctx context.Context, toolID string) (r0 *contextforge.Tool, r1 *contextforge.Response, r2 error) {
+	m.record("Get", toolID)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, toolID)
+	}
+	m.results("Get", &r0, &r1, &r2)
+	return
+}
+
+// Create records the call and returns the results of CreateFunc, if set, or
+// the results set with Return("Create").
+func (m *ToolsAPI) Create(ctx context.Context, tool *contextforge.Tool, opts *contextforge.ToolCreateOptions) (r0 *contextforge.Tool, r1 *contextforge.Response, r2 error) {
+	m.record("Create", tool, opts)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, tool, opts)
+	}
+	m.results("Create", &r0, &r1, &r2)
+	return
+}
+
+// Update records the call and returns the results of UpdateFunc, if set, or
+// the results set with Return("Update").
+func (m *ToolsAPI) Update(ctx context.Context, toolID string, tool *contextforge.Tool) (r0 *contextforge.Tool, r1 *contextforge.Response, r2 error) {
+	m.record("Update", toolID, tool)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, toolID, tool)
+	}
+	m.results("Update", &r0, &r1, &r2)
+	return
+}
+
+// Delete records the call and returns the results of DeleteFunc, if set, or
+// the results set with Return("Delete").
+func (m *ToolsAPI) Delete(ctx context.Context, toolID string) (r0 *contextforge.Response, r1 error) {
+	m.record("Delete", toolID)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, toolID)
+	}
+	m.results("Delete", &r0, &r1)
+	return
+}
+
+// SetState records the call and returns the results of SetStateFunc, if set, or
+// the results set with Return("SetState").
+func (m *ToolsAPI) SetState(ctx context.Context, toolID string, activate bool) (r0 *contextforge.Tool, r1 *contextforge.Response, r2 error) {
+	m.record("SetState", toolID, activate)
+	if m.SetStateFunc != nil {
+		return m.SetStateFunc(ctx, toolID, activate)
+	}
+	m.results("SetState", &r0, &r1, &r2)
+	return
+}
+
+// Toggle records the call and returns the results of ToggleFunc, if set, or
+// the results set with Return("Toggle").
+func (m *ToolsAPI) Toggle(ctx context.Context, toolID string, activate bool) (r0 *contextforge.Tool, r1 *contextforge.Response, r2 error) {
+	m.record("Toggle", toolID, activate)
+	if m.ToggleFunc != nil {
+		return m.ToggleFunc(ctx, toolID, activate)
+	}
+	m.results("Toggle", &r0, &r1, &r2)
+	return
+}
+
+// Invoke records the call and returns the results of InvokeFunc, if set, or
+// the results set with Return("Invoke").
+func (m *ToolsAPI) Invoke(ctx context.Context, name string, args map[string]any, opts *contextforge.ToolInvokeOptions) (r0 *contextforge.ToolCallResult, r1 *contextforge.Response, r2 error) {
+	m.record("Invoke", name, args, opts)
+	if m.InvokeFunc != nil {
+		return m.InvokeFunc(ctx, name, args, opts)
+	}
+	m.results("Invoke", &r0, &r1, &r2)
+	return
+}
+
+// GatewaysAPI is a mock of contextforge.GatewaysAPI.
+type GatewaysAPI struct {
+	Mock
+
+	// ListFunc, if set, implements List.
+	ListFunc func(ctx context.Context, opts *contextforge.GatewayListOptions) ([]*contextforge.Gateway, *contextforge.Response, error)
+
+	// AllFunc, if set, implements All.
+	AllFunc func(ctx context.Context, opts *contextforge.GatewayListOptions) iter.Seq2[*contextforge.Gateway, error]
+
+	// GetFunc, if set, implements Get.
+	GetFunc func(ctx context.Context, gatewayID string) (*contextforge.Gateway, *contextforge.Response, error)
+
+	// CreateFunc, if set, implements Create.
+	CreateFunc func(ctx context.Context, gateway *contextforge.Gateway, opts *contextforge.GatewayCreateOptions) (*contextforge.Gateway, *contextforge.Response, error)
+
+	// UpdateFunc, if set, implements Update.
+	UpdateFunc func(ctx context.Context, gatewayID string, gateway *contextforge.Gateway) (*contextforge.Gateway, *contextforge.Response, error)
+
+	// DeleteFunc, if set, implements Delete.
+	DeleteFunc func(ctx context.Context, gatewayID string) (*contextforge.Response, error)
+
+	// SetStateFunc, if set, implements SetState.
+	SetStateFunc func(ctx context.Context, gatewayID string, activate bool) (*contextforge.Gateway, *contextforge.Response, error)
+
+	// ToggleFunc, if set, implements Toggle.
+	ToggleFunc func(ctx context.Context, gatewayID string, activate bool) (*contextforge.Gateway, *contextforge.Response, error)
+
+	// RefreshToolsFunc, if set, implements RefreshTools.
+	RefreshToolsFunc func(ctx context.Context, gatewayID string, opts *contextforge.GatewayRefreshOptions) (*contextforge.GatewayRefreshResponse, *contextforge.Response, error)
+}
+
+// List records the call and returns the results of ListFunc, if set, or
+// the results set with Return("List").
+func (m *GatewaysAPI) List(ctx context.Context, opts *contextforge.GatewayListOptions) (r0 []*contextforge.Gateway, r1 *contextforge.Response, r2 error) {
+	m.record("List", opts)
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, opts)
+	}
+	m.results("List", &r0, &r1, &r2)
+	return
+}
+
+// All records the call and returns the results of AllFunc, if set, or
+// the results set with Return("All").
+func (m *GatewaysAPI) All(ctx context.Context, opts *contextforge.GatewayListOptions) (r0 iter.Seq2[*contextforge.Gateway, error]) {
+	m.record("All", opts)
+	if m.AllFunc != nil {
+		return m.AllFunc(ctx, opts)
+	}
+	m.results("All", &r0)
+	if r0 == nil {
+		r0 = func(func(*contextforge.Gateway, error) bool) {}
+	}
+	return
+}
+
+// Get records the call and returns the results of GetFunc, if set, or
+// the results set with Return("Get").
+func (m *GatewaysAPI) Get(ctx context.Context, gatewayID string) (r0 *contextforge.Gateway, r1 *contextforge.Response, r2 error) {
+	m.record("Get", gatewayID)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, gatewayID)
+	}
+	m.results("Get", &r0, &r1, &r2)
+	return
+}
+
+// Create records the call and returns the results of CreateFunc, if set, or
+// the results set with Return("Create").
+func (m *GatewaysAPI) Create(ctx context.Context, gateway *contextforge.Gateway, opts *contextforge.GatewayCreateOptions) (r0 *contextforge.Gateway, r1 *contextforge.Response, r2 error) {
+	m.record("Create", gateway, opts)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, gateway, opts)
+	}
+	m.results("Create", &r0, &r1, &r2)
+	return
+}
+
+// Update records the call and returns the results of UpdateFunc, if set, or
+// the results set with Return("Update").
+func (m *GatewaysAPI) Update(ctx context.Context, gatewayID string, gateway *contextforge.Gateway) (r0 *contextforge.Gateway, r1 *contextforge.Response, r2 error) {
+	m.record("Update", gatewayID, gateway)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, gatewayID, gateway)
+	}
+	m.results("Update", &r0, &r1, &r2)
+	return
+}
+
+// Delete records the call and returns the results of DeleteFunc, if set, or
+// the results set with Return("Delete").
+func (m *GatewaysAPI) Delete(ctx context.Context, gatewayID string) (r0 *contextforge.Response, r1 error) {
+	m.record("Delete", gatewayID)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, gatewayID)
+	}
+	m.results("Delete", &r0, &r1)
+	return
+}
+
+// SetState records the call and returns the results of SetStateFunc, if set, or
+// the results set with Return("SetState").
+func (m *GatewaysAPI) SetState(ctx context.Context, gatewayID string, activate bool) (r0 *contextforge.Gateway, r1 *contextforge.Response, r2 error) {
+	m.record("SetState", gatewayID, activate)
+	if m.SetStateFunc != nil {
+		return m.SetStateFunc(ctx, gatewayID, activate)
+	}
+	m.results("SetState", &r0, &r1, &r2)
+	return
+}
+
+// Toggle records the call and returns the results of ToggleFunc, if set, or
+// the results set with Return("Toggle").
+func (m *GatewaysAPI) Toggle(ctx context.Context, gatewayID string, activate bool) (r0 *contextforge.Gateway, r1 *contextforge.Response, r2 error) {
+	m.record("Toggle", gatewayID, activate)
+	if m.ToggleFunc != nil {
+		return m.ToggleFunc(ctx, gatewayID, activate)
+	}
+	m.results("Toggle", &r0, &r1, &r2)
+	return
+}
+
+// RefreshTools records the call and returns the results of RefreshToolsFunc, if set, or
+// the results set with Return("RefreshTools").
+func (m *GatewaysAPI) RefreshTools(ctx context.Context, gatewayID string, opts *contextforge.GatewayRefreshOptions) (r0 *contextforge.GatewayRefreshResponse, r1 *contextforge.Response, r2 error) {
+	m.record("RefreshTools", gatewayID, opts)
+	if m.RefreshToolsFunc != nil {
+		return m.RefreshToolsFunc(ctx, gatewayID, opts)
+	}
+	m.results("RefreshTools", &r0, &r1, &r2)
+	return
+}
+
+// ServersAPI is a mock of contextforge.ServersAPI.
+type ServersAPI struct {
+	Mock
+
+	// ListFunc, if set, implements List.
+	ListFunc func(ctx context.Context, opts *contextforge.ServerListOptions) ([]*contextforge.Server, *contextforge.Response, error)
+
+	// AllFunc, if set, implements All.
+	AllFunc func(ctx context.Context, opts *contextforge.ServerListOptions) iter.Seq2[*contextforge.Server, error]
+
+	// GetFunc, if set, implements Get.
+	GetFunc func(ctx context.Context, serverID string) (*contextforge.Server, *contextforge.Response, error)
+
+	// CreateFunc, if set, implements Create.
+	CreateFunc func(ctx context.Context, server *contextforge.ServerCreate, opts *contextforge.ServerCreateOptions) (*contextforge.Server, *contextforge.Response, error)
+
+	// UpdateFunc, if set, implements Update.
+	UpdateFunc func(ctx context.Context, serverID string, server *contextforge.ServerUpdate) (*contextforge.Server, *contextforge.Response, error)
+
+	// DeleteFunc, if set, implements Delete.
+	DeleteFunc func(ctx context.Context, serverID string) (*contextforge.Response, error)
+
+	// SetStateFunc, if set, implements SetState.
+	SetStateFunc func(ctx context.Context, serverID string, activate bool) (*contextforge.Server, *contextforge.Response, error)
+
+	// ToggleFunc, if set, implements Toggle.
+	ToggleFunc func(ctx context.Context, serverID string, activate bool) (*contextforge.Server, *contextforge.Response, error)
+
+	// ListToolsFunc, if set, implements ListTools.
+	ListToolsFunc func(ctx context.Context, serverID string, opts *contextforge.ServerAssociationOptions) ([]*contextforge.Tool, *contextforge.Response, error)
+
+	// ListResourcesFunc, if set, implements ListResources.
+	ListResourcesFunc func(ctx context.Context, serverID string, opts *contextforge.ServerAssociationOptions) ([]*contextforge.Resource, *contextforge.Response, error)
+
+	// ListPromptsFunc, if set, implements ListPrompts.
+	ListPromptsFunc func(ctx context.Context, serverID string, opts *contextforge.ServerAssociationOptions) ([]*contextforge.Prompt, *contextforge.Response, error)
+
+	// ConnectFunc, if set, implements Connect.
+	ConnectFunc func(ctx context.Context, serverID string, opts *contextforge.MCPSessionOptions) (*contextforge.MCPSession, error)
+}
+
+// List records the call and returns the results of ListFunc, if set, or
+// the results set with Return("List").
+func (m *ServersAPI) List(ctx context.Context, opts *contextforge.ServerListOptions) (r0 []*contextforge.Server, r1 *contextforge.Response, r2 error) {
+	m.record("List", opts)
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, opts)
+	}
+	m.results("List", &r0, &r1, &r2)
+	return
+}
+
+// All records the call and returns the results of AllFunc, if set, or
+// the results set with Return("All").
+func (m *ServersAPI) All(ctx context.Context, opts *contextforge.ServerListOptions) (r0 iter.Seq2[*contextforge.Server, error]) {
+	m.record("All", opts)
+	if m.AllFunc != nil {
+		return m.AllFunc(ctx, opts)
+	}
+	m.results("All", &r0)
+	if r0 == nil {
+		r0 = func(func(*contextforge.Server, error) bool) {}
+	}
+	return
+}
+
+// Get records the call and returns the results of GetFunc, if set, or
+// the results set with Return("Get").
+func (m *ServersAPI) Get(ctx context.Context, serverID string) (r0 *contextforge.Server, r1 *contextforge.Response, r2 error) {
+	m.record("Get", serverID)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, serverID)
+	}
+	m.results("Get", &r0, &r1, &r2)
+	return
+}
+
+// Create records the call and returns the results of CreateFunc, if set, or
+// the results set with Return("Create").
+func (m *ServersAPI) Create(ctx context.Context, server *contextforge.ServerCreate, opts *contextforge.ServerCreateOptions) (r0 *contextforge.Server, r1 *contextforge.Response, r2 error) {
+	m.record("Create", server, opts)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, server, opts)
+	}
+	m.results("Create", &r0, &r1, &r2)
+	return
+}
+
+// Update records the call and returns the results of UpdateFunc, if set, or
+// the results set with Return("Update").
+func (m *ServersAPI) Update(ctx context.Context, serverID string, server *contextforge.ServerUpdate) (r0 *contextforge.Server, r1 *contextforge.Response, r2 error) {
+	m.record("Update", serverID, server)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, serverID, server)
+	}
+	m.results("Update", &r0, &r1, &r2)
+	return
+}
+
+// Delete records the call and returns the results of DeleteFunc, if set, or
+// the results set with Return("Delete").
+func (m *ServersAPI) Delete(ctx context.Context, serverID string) (r0 *contextforge.Response, r1 error) {
+	m.record("Delete", serverID)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, serverID)
+	}
+	m.results("Delete", &r0, &r1)
+	return
+}
+
+// SetState records the call and returns the results of SetStateFunc, if set, or
+// the results set with Return("SetState").
+func (m *ServersAPI) SetState(ctx context.Context, serverID string, activate bool) (r0 *contextforge.Server, r1 *contextforge.Response, r2 error) {
+	m.record("SetState", serverID, activate)
+	if m.SetStateFunc != nil {
+		return m.SetStateFunc(ctx, serverID, activate)
+	}
+	m.results("SetState", &r0, &r1, &r2)
+	return
+}
+
+// Toggle records the call and returns the results of ToggleFunc, if set, or
+// the results set with Return("Toggle").
+func (m *ServersAPI) Toggle(ctx context.Context, serverID string, activate bool) (r0 *contextforge.Server, r1 *contextforge.Response, r2 error) {
+	m.record("Toggle", serverID, activate)
+	if m.ToggleFunc != nil {
+		return m.ToggleFunc(ctx, serverID, activate)
+	}
+	m.results("Toggle", &r0, &r1, &r2)
+	return
+}
+
+// ListTools records the call and returns the results of ListToolsFunc, if set, or
+// the results set with Return("ListTools").
+func (m *ServersAPI) ListTools(ctx context.Context, serverID string, opts *contextforge.ServerAssociationOptions) (r0 []*contextforge.Tool, r1 *contextforge.Response, r2 error) {
+	m.record("ListTools", serverID, opts)
+	if m.ListToolsFunc != nil {
+		return m.ListToolsFunc(ctx, serverID, opts)
+	}
+	m.results("ListTools", &r0, &r1, &r2)
+	return
+}
+
+// ListResources records the call and returns the results of ListResourcesFunc, if set, or
+// the results set with Return("ListResources").
+func (m *ServersAPI) ListResources(ctx context.Context, serverID string, opts *contextforge.ServerAssociationOptions) (r0 []*contextforge.Resource, r1 *contextforge.Response, r2 error) {
+	m.record("ListResources", serverID, opts)
+	if m.ListResourcesFunc != nil {
+		return m.ListResourcesFunc(ctx, serverID, opts)
+	}
+	m.results("ListResources", &r0, &r1, &r2)
+	return
+}
+
+// ListPrompts records the call and returns the results of ListPromptsFunc, if set, or
+// the results set with Return("ListPrompts").
+func (m *ServersAPI) ListPrompts(ctx context.Context, serverID string, opts *contextforge.ServerAssociationOptions) (r0 []*contextforge.Prompt, r1 *contextforge.Response, r2 error) {
+	m.record("ListPrompts", serverID, opts)
+	if m.ListPromptsFunc != nil {
+		return m.ListPromptsFunc(ctx, serverID, opts)
+	}
+	m.results("ListPrompts", &r0, &r1, &r2)
+	return
+}
+
+// Connect records the call and returns the results of ConnectFunc, if set, or
+// the results set with Return("Connect").
+func (m *ServersAPI) Connect(ctx context.Context, serverID string, opts *contextforge.MCPSessionOptions) (r0 *contextforge.MCPSession, r1 error) {
+	m.record("Connect", serverID, opts)
+	if m.ConnectFunc != nil {
+		return m.ConnectFunc(ctx, serverID, opts)
+	}
+	m.results("Connect", &r0, &r1)
+	return
+}
+
+// PromptsAPI is a mock of contextforge.PromptsAPI.
+type PromptsAPI struct {
+	Mock
+
+	// ListFunc, if set, implements List.
+	ListFunc func(ctx context.Context, opts *contextforge.PromptListOptions) ([]*contextforge.Prompt, *contextforge.Response, error)
+
+	// AllFunc, if set, implements All.
+	AllFunc func(ctx context.Context, opts *contextforge.PromptListOptions) iter.Seq2[*contextforge.Prompt, error]
+
+	// GetFunc, if set, implements Get.
+	GetFunc func(ctx context.Context, promptID string, args map[string]string) (*contextforge.PromptResult, *contextforge.Response, error)
+
+	// GetNoArgsFunc, if set, implements GetNoArgs.
+	GetNoArgsFunc func(ctx context.Context, promptID string) (*contextforge.PromptResult, *contextforge.Response, error)
+
+	// CreateFunc, if set, implements Create.
+	CreateFunc func(ctx context.Context, prompt *contextforge.PromptCreate, opts *contextforge.PromptCreateOptions) (*contextforge.Prompt, *contextforge.Response, error)
+
+	// UpdateFunc, if set, implements Update.
+	UpdateFunc func(ctx context.Context, promptID string, prompt *contextforge.PromptUpdate) (*contextforge.Prompt, *contextforge.Response, error)
+
+	// DeleteFunc, if set, implements Delete.
+	DeleteFunc func(ctx context.Context, promptID string) (*contextforge.Response, error)
+
+	// SetStateFunc, if set, implements SetState.
+	SetStateFunc func(ctx context.Context, promptID string, activate bool) (*contextforge.Prompt, *contextforge.Response, error)
+
+	// ToggleFunc, if set, implements Toggle.
+	ToggleFunc func(ctx context.Context, promptID string, activate bool) (*contextforge.Prompt, *contextforge.Response, error)
+}
+
+// List records the call and returns the results of ListFunc, if set, or
+// the results set with Return("List").
+func (m *PromptsAPI) List(ctx context.Context, opts *contextforge.PromptListOptions) (r0 []*contextforge.Prompt, r1 *contextforge.Response, r2 error) {
+	m.record("List", opts)
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, opts)
+	}
+	m.results("List", &r0, &r1, &r2)
+	return
+}
+
+// All records the call and returns the results of AllFunc, if set, or
+// the results set with Return("All").
+func (m *PromptsAPI) All(ctx context.Context, opts *contextforge.PromptListOptions) (r0 iter.Seq2[*contextforge.Prompt, error]) {
+	m.record("All", opts)
+	if m.AllFunc != nil {
+		return m.AllFunc(ctx, opts)
+	}
+	m.results("All", &r0)
+	if r0 == nil {
+		r0 = func(func(*contextforge.Prompt, error) bool) {}
+	}
+	return
+}
+
+// Get records the call and returns the results of GetFunc, if set, or
+// the results set with Return("Get").
+func (m *PromptsAPI) Get(ctx context.Context, promptID string, args map[string]string) (r0 *contextforge.PromptResult, r1 *contextforge.Response, r2 error) {
+	m.record("Get", promptID, args)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, promptID, args)
+	}
+	m.results("Get", &r0, &r1, &r2)
+	return
+}
+
+// GetNoArgs records the call and returns the results of GetNoArgsFunc, if set, or
+// the results set with Return("GetNoArgs").
+func (m *PromptsAPI) GetNoArgs(ctx context.Context, promptID string) (r0 *contextforge.PromptResult, r1 *contextforge.Response, r2 error) {
+	m.record("GetNoArgs", promptID)
+	if m.GetNoArgsFunc != nil {
+		return m.GetNoArgsFunc(ctx, promptID)
+	}
+	m.results("GetNoArgs", &r0, &r1, &r2)
+	return
+}
+
+// Create records the call and returns the results of CreateFunc, if set, or
+// the results set with Return("Create").
+func (m *PromptsAPI) Create(ctx context.Context, prompt *contextforge.PromptCreate, opts *contextforge.PromptCreateOptions) (r0 *contextforge.Prompt, r1 *contextforge.Response, r2 error) {
+	m.record("Create", prompt, opts)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, prompt, opts)
+	}
+	m.results("Create", &r0, &r1, &r2)
+	return
+}
+
+// Update records the call and returns the results of UpdateFunc, if set, or
+// the results set with Return("Update").
+func (m *PromptsAPI) Update(ctx context.Context, promptID string, prompt *contextforge.PromptUpdate) (r0 *contextforge.Prompt, r1 *contextforge.Response, r2 error) {
+	m.record("Update", promptID, prompt)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, promptID, prompt)
+	}
+	m.results("Update", &r0, &r1, &r2)
+	return
+}
+
+// Delete records the call and returns the results of DeleteFunc, if set, or
+// the results set with Return("Delete").
+func (m *PromptsAPI) Delete(ctx context.Context, promptID string) (r0 *contextforge.Response, r1 error) {
+	m.record("Delete", promptID)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, promptID)
+	}
+	m.results("Delete", &r0, &r1)
+	return
+}
+
+// SetState records the call and returns the results of SetStateFunc, if set, or
+// the results set with Return("SetState").
+func (m *PromptsAPI) SetState(ctx context.Context, promptID string, activate bool) (r0 *contextforge.Prompt, r1 *contextforge.Response, r2 error) {
+	m.record("SetState", promptID, activate)
+	if m.SetStateFunc != nil {
+		return m.SetStateFunc(ctx, promptID, activate)
+	}
+	m.results("SetState", &r0, &r1, &r2)
+	return
+}
+
+// Toggle records the call and returns the results of ToggleFunc, if set, or
+// the results set with Return("Toggle").
+func (m *PromptsAPI) Toggle(ctx context.Context, promptID string, activate bool) (r0 *contextforge.Prompt, r1 *contextforge.Response, r2 error) {
+	m.record("Toggle", promptID, activate)
+	if m.ToggleFunc != nil {
+		return m.ToggleFunc(ctx, promptID, activate)
+	}
+	m.results("Toggle", &r0, &r1, &r2)
+	return
+}
+
+// ResourcesAPI is a mock of contextforge.ResourcesAPI.
+type ResourcesAPI struct {
+	Mock
+
+	// ListFunc, if set, implements List.
+	ListFunc func(ctx context.Context, opts *contextforge.ResourceListOptions) ([]*contextforge.Resource, *contextforge.Response, error)
+
+	// AllFunc, if set, implements All.
+	AllFunc func(ctx context.Context, opts *contextforge.ResourceListOptions) iter.Seq2[*contextforge.Resource, error]
+
+	// GetFunc, if set, implements Get.
+	GetFunc func(ctx context.Context, resourceID string) (*contextforge.ResourceContent, *contextforge.Response, error)
+
+	// GetInfoFunc, if set, implements GetInfo.
+	GetInfoFunc func(ctx context.Context, resourceID string, opts *contextforge.ResourceInfoOptions) (*contextforge.Resource, *contextforge.Response, error)
+
+	// CreateFunc, if set, implements Create.
+	CreateFunc func(ctx context.Context, resource *contextforge.ResourceCreate, opts *contextforge.ResourceCreateOptions) (*contextforge.Resource, *contextforge.Response, error)
+
+	// UpdateFunc, if set, implements Update.
+	UpdateFunc func(ctx context.Context, resourceID string, resource *contextforge.ResourceUpdate) (*contextforge.Resource, *contextforge.Response, error)
+
+	// DeleteFunc, if set, implements Delete.
+	DeleteFunc func(ctx context.Context, resourceID string) (*contextforge.Response, error)
+
+	// SetStateFunc, if set, implements SetState.
+	SetStateFunc func(ctx context.Context, resourceID string, activate bool) (*contextforge.Resource, *contextforge.Response, error)
+
+	// ToggleFunc, if set, implements Toggle.
+	ToggleFunc func(ctx context.Context, resourceID string, activate bool) (*contextforge.Resource, *contextforge.Response, error)
+
+	// ListTemplatesFunc, if set, implements ListTemplates.
+	ListTemplatesFunc func(ctx context.Context) (*contextforge.ListResourceTemplatesResult, *contextforge.Response, error)
+}
+
+// List records the call and returns the results of ListFunc, if set, or
+// the results set with Return("List").
+func (m *ResourcesAPI) List(ctx context.Context, opts *contextforge.ResourceListOptions) (r0 []*contextforge.Resource, r1 *contextforge.Response, r2 error) {
+	m.record("List", opts)
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, opts)
+	}
+	m.results("List", &r0, &r1, &r2)
+	return
+}
+
+// All records the call and returns the results of AllFunc, if set, or
+// the results set with Return("All").
+func (m *ResourcesAPI) All(ctx context.Context, opts *contextforge.ResourceListOptions) (r0 iter.Seq2[*contextforge.Resource, error]) {
+	m.record("All", opts)
+	if m.AllFunc != nil {
+		return m.AllFunc(ctx, opts)
+	}
+	m.results("All", &r0)
+	if r0 == nil {
+		r0 = func(func(*contextforge.Resource, error) bool) {}
+	}
+	return
+}
+
+// Get records the call and returns the results of GetFunc, if set, or
+// the results set with Return("Get").
+func (m *ResourcesAPI) Get(ctx context.Context, resourceID string) (r0 *contextforge.ResourceContent, r1 *contextforge.Response, r2 error) {
+	m.record("Get", resourceID)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, resourceID)
+	}
+	m.results("Get", &r0, &r1, &r2)
+	return
+}
+
+// GetInfo records the call and returns the results of GetInfoFunc, if set, or
+// the results set with Return("GetInfo").
+func (m *ResourcesAPI) GetInfo(ctx context.Context, resourceID string, opts *contextforge.ResourceInfoOptions) (r0 *contextforge.Resource, r1 *contextforge.Response, r2 error) {
+	m.record("GetInfo", resourceID, opts)
+	if m.GetInfoFunc != nil {
+		return m.GetInfoFunc(ctx, resourceID, opts)
+	}
+	m.results("GetInfo", &r0, &r1, &r2)
+	return
+}
+
+// Create records the call and returns the results of CreateFunc, if set, or
+// the results set with Return("Create").
+func (m *ResourcesAPI) Create(ctx context.Context, resource *contextforge.ResourceCreate, opts *contextforge.ResourceCreateOptions) (r0 *contextforge.Resource, r1 *contextforge.Response, r2 error) {
+	m.record("Create", resource, opts)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, resource, opts)
+	}
+	m.results("Create", &r0, &r1, &r2)
+	return
+}
+
+// Update records the call and returns the results of UpdateFunc, if set, or
+// the results set with Return("Update").
+func (m *ResourcesAPI) Update(ctx context.Context, resourceID string, resource *contextforge.ResourceUpdate) (r0 *contextforge.Resource, r1 *contextforge.Response, r2 error) {
+	m.record("Update", resourceID, resource)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, resourceID, resource)
+	}
+	m.results("Update", &r0, &r1, &r2)
+	return
+}
+
+// Delete records the call and returns the results of DeleteFunc, if set, or
+// the results set with Return("Delete").
+func (m *ResourcesAPI) Delete(ctx context.Context, resourceID string) (r0 *contextforge.Response, r1 error) {
+	m.record("Delete", resourceID)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, resourceID)
+	}
+	m.results("Delete", &r0, &r1)
+	return
+}
+
+// SetState records the call and returns the results of SetStateFunc, if set, or
+// the results set with Return("SetState").
+func (m *ResourcesAPI) SetState(ctx context.Context, resourceID string, activate bool) (r0 *contextforge.Resource, r1 *contextforge.Response, r2 error) {
+	m.record("SetState", resourceID, activate)
+	if m.SetStateFunc != nil {
+		return m.SetStateFunc(ctx, resourceID, activate)
+	}
+	m.results("SetState", &r0, &r1, &r2)
+	return
+}
+
+// Toggle records the call and returns the results of ToggleFunc, if set, or
+// the results set with Return("Toggle").
+func (m *ResourcesAPI) Toggle(ctx context.Context, resourceID string, activate bool) (r0 *contextforge.Resource, r1 *contextforge.Response, r2 error) {
+	m.record("Toggle", resourceID, activate)
+	if m.ToggleFunc != nil {
+		return m.ToggleFunc(ctx, resourceID, activate)
+	}
+	m.results("Toggle", &r0, &r1, &r2)
+	return
+}
+
+// ListTemplates records the call and returns the results of ListTemplatesFunc, if set, or
+// the results set with Return("ListTemplates").
+func (m *ResourcesAPI) ListTemplates(ctx context.Context) (r0 *contextforge.ListResourceTemplatesResult, r1 *contextforge.Response, r2 error) {
+	m.record("ListTemplates")
+	if m.ListTemplatesFunc != nil {
+		return m.ListTemplatesFunc(ctx)
+	}
+	m.results("ListTemplates", &r0, &r1, &r2)
+	return
+}
+
+// AgentsAPI is a mock of contextforge.AgentsAPI.
+type AgentsAPI struct {
+	Mock
+
+	// ListFunc, if set, implements List.
+	ListFunc func(ctx context.Context, opts *contextforge.AgentListOptions) ([]*contextforge.Agent, *contextforge.Response, error)
+
+	// AllFunc, if set, implements All.
+	AllFunc func(ctx context.Context, opts *contextforge.AgentListOptions) iter.Seq2[*contextforge.Agent, error]
+
+	// AllBySkipFunc, if set, implements AllBySkip.
+	AllBySkipFunc func(ctx context.Context, opts *contextforge.AgentListOptions) iter.Seq2[*contextforge.Agent, error]
+
+	// GetFunc, if set, implements Get.
+	GetFunc func(ctx context.Context, agentID string) (*contextforge.Agent, *contextforge.Response, error)
+
+	// CreateFunc, if set, implements Create.
+	CreateFunc func(ctx context.Context, agent *contextforge.AgentCreate, opts *contextforge.AgentCreateOptions) (*contextforge.Agent, *contextforge.Response, error)
+
+	// UpdateFunc, if set, implements Update.
+	UpdateFunc func(ctx context.Context, agentID string, agent *contextforge.AgentUpdate) (*contextforge.Agent, *contextforge.Response, error)
+
+	// DeleteFunc, if set, implements Delete.
+	DeleteFunc func(ctx context.Context, agentID string) (*contextforge.Response, error)
+
+	// SetStateFunc, if set, implements SetState.
+	SetStateFunc func(ctx context.Context, agentID string, activate bool) (*contextforge.Agent, *contextforge.Response, error)
+
+	// ToggleFunc, if set, implements Toggle.
+	ToggleFunc func(ctx context.Context, agentID string, activate bool) (*contextforge.Agent, *contextforge.Response, error)
+
+	// InvokeFunc, if set, implements Invoke.
+	InvokeFunc func(ctx context.Context, agentName string, req *contextforge.AgentInvokeRequest) (*contextforge.AgentInvokeResult, *contextforge.Response, error)
+
+	// InvokeStreamFunc, if set, implements InvokeStream.
+	InvokeStreamFunc func(ctx context.Context, agentName string, req *contextforge.AgentInvokeRequest) (*contextforge.AgentStream, *contextforge.Response, error)
+}
+
+// List records the call and returns the results of ListFunc, if set, or
+// the results set with Return("List").
+func (m *AgentsAPI) List(ctx context.Context, opts *contextforge.AgentListOptions) (r0 []*contextforge.Agent, r1 *contextforge.Response, r2 error) {
+	m.record("List", opts)
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, opts)
+	}
+	m.results("List", &r0, &r1, &r2)
+	return
+}
+
+// All records the call and returns the results of AllFunc, if set, or
+// the results set with Return("All").
+func (m *AgentsAPI) All(ctx context.Context, opts *contextforge.AgentListOptions) (r0 iter.Seq2[*contextforge.Agent, error]) {
+	m.record("All", opts)
+	if m.AllFunc != nil {
+		return m.AllFunc(ctx, opts)
+	}
+	m.results("All", &r0)
+	if r0 == nil {
+		r0 = func(func(*contextforge.Agent, error) bool) {}
+	}
+	return
+}
+
+// AllBySkip records the call and returns the results of AllBySkipFunc, if set, or
+// the results set with Return("AllBySkip").
+func (m *AgentsAPI) AllBySkip(ctx context.Context, opts *contextforge.AgentListOptions) (r0 iter.Seq2[*contextforge.Agent, error]) {
+	m.record("AllBySkip", opts)
+	if m.AllBySkipFunc != nil {
+		return m.AllBySkipFunc(ctx, opts)
+	}
+	m.results("AllBySkip", &r0)
+	if r0 == nil {
+		r0 = func(func(*contextforge.Agent, error) bool) {}
+	}
+	return
+}
+
+// Get records the call and returns the results of GetFunc, if set, or
+// the results set with Return("Get").
+func (m *AgentsAPI) Get(ctx context.Context, agentID string) (r0 *contextforge.Agent, r1 *contextforge.Response, r2 error) {
+	m.record("Get", agentID)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, agentID)
+	}
+	m.results("Get", &r0, &r1, &r2)
+	return
+}
+
+// Create records the call and returns the results of CreateFunc, if set, or
+// the results set with Return("Create").
+func (m *AgentsAPI) Create(ctx context.Context, agent *contextforge.AgentCreate, opts *contextforge.AgentCreateOptions) (r0 *contextforge.Agent, r1 *contextforge.Response, r2 error) {
+	m.record("Create", agent, opts)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, agent, opts)
+	}
+	m.results("Create", &r0, &r1, &r2)
+	return
+}
+
+// Update records the call and returns the results of UpdateFunc, if set, or
+// the results set with Return("Update").
+func (m *AgentsAPI) Update(ctx context.Context, agentID string, agent *contextforge.AgentUpdate) (r0 *contextforge.Agent, r1 *contextforge.Response, r2 error) {
+	m.record("Update", agentID, agent)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, agentID, agent)
+	}
+	m.results("Update", &r0, &r1, &r2)
+	return
+}
+
+// Delete records the call and returns the results of DeleteFunc, if set, or
+// the results set with Return("Delete").
+func (m *AgentsAPI) Delete(ctx context.Context, agentID string) (r0 *contextforge.Response, r1 error) {
+	m.record("Delete", agentID)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, agentID)
+	}
+	m.results("Delete", &r0, &r1)
+	return
+}
+
+// SetState records the call and returns the results of SetStateFunc, if set, or
+// the results set with Return("SetState").
+func (m *AgentsAPI) SetState(ctx context.Context, agentID string, activate bool) (r0 *contextforge.Agent, r1 *contextforge.Response, r2 error) {
+	m.record("SetState", agentID, activate)
+	if m.SetStateFunc != nil {
+		return m.SetStateFunc(ctx, agentID, activate)
+	}
+	m.results("SetState", &r0, &r1, &r2)
+	return
+}
+
+// Toggle records the call and returns the results of ToggleFunc, if set, or
+// the results set with Return("Toggle").
+func (m *AgentsAPI) Toggle(ctx context.Context, agentID string, activate bool) (r0 *contextforge.Agent, r1 *contextforge.Response, r2 error) {
+	m.record("Toggle", agentID, activate)
+	if m.ToggleFunc != nil {
+		return m.ToggleFunc(ctx, agentID, activate)
+	}
+	m.results("Toggle", &r0, &r1, &r2)
+	return
+}
+
+// Invoke records the call and returns the results of InvokeFunc, if set, or
+// the results set with Return("Invoke").
+func (m *AgentsAPI) Invoke(ctx context.Context, agentName string, req *contextforge.AgentInvokeRequest) (r0 *contextforge.AgentInvokeResult, r1 *contextforge.Response, r2 error) {
+	m.record("Invoke", agentName, req)
+	if m.InvokeFunc != nil {
+		return m.InvokeFunc(ctx, agentName, req)
+	}
+	m.results("Invoke", &r0, &r1, &r2)
+	return
+}
+
+// InvokeStream records the call and returns the results of InvokeStreamFunc, if set, or
+// the results set with Return("InvokeStream").
+func (m *AgentsAPI) InvokeStream(ctx context.Context, agentName string, req *contextforge.AgentInvokeRequest) (r0 *contextforge.AgentStream, r1 *contextforge.Response, r2 error) {
+	m.record("InvokeStream", agentName, req)
+	if m.InvokeStreamFunc != nil {
+		return m.InvokeStreamFunc(ctx, agentName, req)
+	}
+	m.results("InvokeStream", &r0, &r1, &r2)
+	return
+}
+
+// TeamsAPI is a mock of contextforge.TeamsAPI.
+type TeamsAPI struct {
+	Mock
+
+	// ListFunc, if set, implements List.
+	ListFunc func(ctx context.Context, opts *contextforge.TeamListOptions) ([]*contextforge.Team, *contextforge.Response, error)
+
+	// AllFunc, if set, implements All.
+	AllFunc func(ctx context.Context, opts *contextforge.TeamListOptions) iter.Seq2[*contextforge.Team, error]
+
+	// GetFunc, if set, implements Get.
+	GetFunc func(ctx context.Context, teamID string) (*contextforge.Team, *contextforge.Response, error)
+
+	// CreateFunc, if set, implements Create.
+	CreateFunc func(ctx context.Context, team *contextforge.TeamCreate) (*contextforge.Team, *contextforge.Response, error)
+
+	// UpdateFunc, if set, implements Update.
+	UpdateFunc func(ctx context.Context, teamID string, team *contextforge.TeamUpdate) (*contextforge.Team, *contextforge.Response, error)
+
+	// DeleteFunc, if set, implements Delete.
+	DeleteFunc func(ctx context.Context, teamID string) (*contextforge.Response, error)
+
+	// ListMembersFunc, if set, implements ListMembers.
+	ListMembersFunc func(ctx context.Context, teamID string) ([]*contextforge.TeamMember, *contextforge.Response, error)
+
+	// UpdateMemberFunc, if set, implements UpdateMember.
+	UpdateMemberFunc func(ctx context.Context, teamID string, userEmail string, update *contextforge.TeamMemberUpdate) (*contextforge.TeamMember, *contextforge.Response, error)
+
+	// RemoveMemberFunc, if set, implements RemoveMember.
+	RemoveMemberFunc func(ctx context.Context, teamID string, userEmail string) (*contextforge.Response, error)
+
+	// InviteMemberFunc, if set, implements InviteMember.
+	InviteMemberFunc func(ctx context.Context, teamID string, invite *contextforge.TeamInvite) (*contextforge.TeamInvitation, *contextforge.Response, error)
+
+	// ListInvitationsFunc, if set, implements ListInvitations.
+	ListInvitationsFunc func(ctx context.Context, teamID string) ([]*contextforge.TeamInvitation, *contextforge.Response, error)
+
+	// AcceptInvitationFunc, if set, implements AcceptInvitation.
+	AcceptInvitationFunc func(ctx context.Context, token string) (*contextforge.TeamMember, *contextforge.Response, error)
+
+	// CancelInvitationFunc, if set, implements CancelInvitation.
+	CancelInvitationFunc func(ctx context.Context, invitationID string) (*contextforge.Response, error)
+
+	// DiscoverFunc, if set, implements Discover.
+	DiscoverFunc func(ctx context.Context, opts *contextforge.TeamDiscoverOptions) ([]*contextforge.TeamDiscovery, *contextforge.Response, error)
+
+	// DiscoverAllFunc, if set, implements DiscoverAll.
+	DiscoverAllFunc func(ctx context.Context, opts *contextforge.TeamDiscoverOptions) iter.Seq2[*contextforge.TeamDiscovery, error]
+
+	// JoinFunc, if set, implements Join.
+	JoinFunc func(ctx context.Context, teamID string, request *contextforge.TeamJoinRequest) (*contextforge.TeamJoinRequestResponse, *contextforge.Response, error)
+
+	// LeaveFunc, if set, implements Leave.
+	LeaveFunc func(ctx context.Context, teamID string) (*contextforge.Response, error)
+
+	// ListJoinRequestsFunc, if set, implements ListJoinRequests.
+	ListJoinRequestsFunc func(ctx context.Context, teamID string) ([]*contextforge.TeamJoinRequestResponse, *contextforge.Response, error)
+
+	// ApproveJoinRequestFunc, if set, implements ApproveJoinRequest.
+	ApproveJoinRequestFunc func(ctx context.Context, teamID string, requestID string) (*contextforge.TeamMember, *contextforge.Response, error)
+
+	// RejectJoinRequestFunc, if set, implements RejectJoinRequest.
+	RejectJoinRequestFunc func(ctx context.Context, teamID string, requestID string) (*contextforge.Response, error)
+}
+
+// List records the call and returns the results of ListFunc, if set, or
+// the results set with Return("List").
+func (m *TeamsAPI) List(ctx context.Context, opts *contextforge.TeamListOptions) (r0 []*contextforge.Team, r1 *contextforge.Response, r2 error) {
+	m.record("List", opts)
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, opts)
+	}
+	m.results("List", &r0, &r1, &r2)
+	return
+}
+
+// All records the call and returns the results of AllFunc, if set, or
+// the results set with Return("All").
+func (m *TeamsAPI) All(ctx context.Context, opts *contextforge.TeamListOptions) (r0 iter.Seq2[*contextforge.Team, error]) {
+	m.record("All", opts)
+	if m.AllFunc != nil {
+		return m.AllFunc(ctx, opts)
+	}
+	m.results("All", &r0)
+	if r0 == nil {
+		r0 = func(func(*contextforge.Team, error) bool) {}
+	}
+	return
+}
+
+// Get records the call and returns the results of GetFunc, if set, or
+// the results set with Return("Get").
+func (m *TeamsAPI) Get(ctx context.Context, teamID string) (r0 *contextforge.Team, r1 *contextforge.Response, r2 error) {
+	m.record("Get", teamID)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, teamID)
+	}
+	m.results("Get", &r0, &r1, &r2)
+	return
+}
+
+// Create records the call and returns the results of CreateFunc, if set, or
+// the results set with Return("Create").
+func (m *TeamsAPI) Create(ctx context.Context, team *contextforge.TeamCreate) (r0 *contextforge.Team, r1 *contextforge.Response, r2 error) {
+	m.record("Create", team)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, team)
+	}
+	m.results("Create", &r0, &r1, &r2)
+	return
+}
+
+// Update records the call and returns the results of UpdateFunc, if set, or
+// the results set with Return("Update").
+func (m *TeamsAPI) Update(ctx context.Context, teamID string, team *contextforge.TeamUpdate) (r0 *contextforge.Team, r1 *contextforge.Response, r2 error) {
+	m.record("Update", teamID, team)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, teamID, team)
+	}
+	m.results("Update", &r0, &r1, &r2)
+	return
+}
+
+// Delete records the call and returns the results of DeleteFunc, if set, or
+// the results set with Return("Delete").
+func (m *TeamsAPI) Delete(ctx context.Context, teamID string) (r0 *contextforge.Response, r1 error) {
+	m.record("Delete", teamID)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, teamID)
+	}
+	m.results("Delete", &r0, &r1)
+	return
+}
+
+// ListMembers records the call and returns the results of ListMembersFunc, if set, or
+// the results set with Return("ListMembers").
+func (m *TeamsAPI) ListMembers(ctx context.Context, teamID string) (r0 []*contextforge.TeamMember, r1 *contextforge.Response, r2 error) {
+	m.record("ListMembers", teamID)
+	if m.ListMembersFunc != nil {
+		return m.ListMembersFunc(ctx, teamID)
+	}
+	m.results("ListMembers", &r0, &r1, &r2)
+	return
+}
+
+// UpdateMember records the call and returns the results of UpdateMemberFunc, if set, or
+// the results set with Return("UpdateMember").
+func (m *TeamsAPI) UpdateMember(ctx context.Context, teamID string, userEmail string, update *contextforge.TeamMemberUpdate) (r0 *contextforge.TeamMember, r1 *contextforge.Response, r2 error) {
+	m.record("UpdateMember", teamID, userEmail, update)
+	if m.UpdateMemberFunc != nil {
+		return m.UpdateMemberFunc(ctx, teamID, userEmail, update)
+	}
+	m.results("UpdateMember", &r0, &r1, &r2)
+	return
+}
+
+// RemoveMember records the call and returns the results of RemoveMemberFunc, if set, or
+// the results set with Return("RemoveMember").
+func (m *TeamsAPI) RemoveMember(ctx context.Context, teamID string, userEmail string) (r0 *contextforge.Response, r1 error) {
+	m.record("RemoveMember", teamID, userEmail)
+	if m.RemoveMemberFunc != nil {
+		return m.RemoveMemberFunc(ctx, teamID, userEmail)
+	}
+	m.results("RemoveMember", &r0, &r1)
+	return
+}
+
+// InviteMember records the call and returns the results of InviteMemberFunc, if set, or
+// the results set with Return("InviteMember").
+func (m *TeamsAPI) InviteMember(ctx context.Context, teamID string, invite *contextforge.TeamInvite) (r0 *contextforge.TeamInvitation, r1 *contextforge.Response, r2 error) {
+	m.record("InviteMember", teamID, invite)
+	if m.InviteMemberFunc != nil {
+		return m.InviteMemberFunc(ctx, teamID, invite)
+	}
+	m.results("InviteMember", &r0, &r1, &r2)
+	return
+}
+
+// ListInvitations records the call and returns the results of ListInvitationsFunc, if set, or
+// the results set with Return("ListInvitations").
+func (m *TeamsAPI) ListInvitations(ctx context.Context, teamID string) (r0 []*contextforge.TeamInvitation, r1 *contextforge.Response, r2 error) {
+	m.record("ListInvitations", teamID)
+	if m.ListInvitationsFunc != nil {
+		return m.ListInvitationsFunc(ctx, teamID)
+	}
+	m.results("ListInvitations", &r0, &r1, &r2)
+	return
+}
+
+// AcceptInvitation records the call and returns the results of AcceptInvitationFunc, if set, or
+// the results set with Return("AcceptInvitation").
+func (m *TeamsAPI) AcceptInvitation(ctx context.Context, token string) (r0 *contextforge.TeamMember, r1 *contextforge.Response, r2 error) {
+	m.record("AcceptInvitation", token)
+	if m.AcceptInvitationFunc != nil {
+		return m.AcceptInvitationFunc(ctx, token)
+	}
+	m.results("AcceptInvitation", &r0, &r1, &r2)
+	return
+}
+
+// CancelInvitation records the call and returns the results of CancelInvitationFunc, if set, or
+// the results set with Return("CancelInvitation").
+func (m *TeamsAPI) CancelInvitation(ctx context.Context, invitationID string) (r0 *contextforge.Response, r1 error) {
+	m.record("CancelInvitation", invitationID)
+	if m.CancelInvitationFunc != nil {
+		return m.CancelInvitationFunc(ctx, invitationID)
+	}
+	m.results("CancelInvitation", &r0, &r1)
+	return
+}
+
+// Discover records the call and returns the results of DiscoverFunc, if set, or
+// the results set with Return("Discover").
+func (m *TeamsAPI) Discover(ctx context.Context, opts *contextforge.TeamDiscoverOptions) (r0 []*contextforge.TeamDiscovery, r1 *contextforge.Response, r2 error) {
+	m.record("Discover", opts)
+	if m.DiscoverFunc != nil {
+		return m.DiscoverFunc(ctx, opts)
+	}
+	m.results("Discover", &r0, &r1, &r2)
+	return
+}
+
+// DiscoverAll records the call and returns the results of DiscoverAllFunc, if set, or
+// the results set with Return("DiscoverAll").
+func (m *TeamsAPI) DiscoverAll(ctx context.Context, opts *contextforge.TeamDiscoverOptions) (r0 iter.Seq2[*contextforge.TeamDiscovery, error]) {
+	m.record("DiscoverAll", opts)
+	if m.DiscoverAllFunc != nil {
+		return m.DiscoverAllFunc(ctx, opts)
+	}
+	m.results("DiscoverAll", &r0)
+	if r0 == nil {
+		r0 = func(func(*contextforge.TeamDiscovery, error) bool) {}
+	}
+	return
+}
+
+// Join records the call and returns the results of JoinFunc, if set, or
+// the results set with Return("Join").
+func (m *TeamsAPI) Join(ctx context.Context, teamID string, request *contextforge.TeamJoinRequest) (r0 *contextforge.TeamJoinRequestResponse, r1 *contextforge.Response, r2 error) {
+	m.record("Join", teamID, request)
+	if m.JoinFunc != nil {
+		return m.JoinFunc(ctx, teamID, request)
+	}
+	m.results("Join", &r0, &r1, &r2)
+	return
+}
+
+// Leave records the call and returns the results of LeaveFunc, if set, or
+// the results set with Return("Leave").
+func (m *TeamsAPI) Leave(ctx context.Context, teamID string) (r0 *contextforge.Response, r1 error) {
+	m.record("Leave", teamID)
+	if m.LeaveFunc != nil {
+		return m.LeaveFunc(ctx, teamID)
+	}
+	m.results("Leave", &r0, &r1)
+	return
+}
+
+// ListJoinRequests records the call and returns the results of ListJoinRequestsFunc, if set, or
+// the results set with Return("ListJoinRequests").
+func (m *TeamsAPI) ListJoinRequests(ctx context.Context, teamID string) (r0 []*contextforge.TeamJoinRequestResponse, r1 *contextforge.Response, r2 error) {
+	m.record("ListJoinRequests", teamID)
+	if m.ListJoinRequestsFunc != nil {
+		return m.ListJoinRequestsFunc(ctx, teamID)
+	}
+	m.results("ListJoinRequests", &r0, &r1, &r2)
+	return
+}
+
+// ApproveJoinRequest records the call and returns the results of ApproveJoinRequestFunc, if set, or
+// the results set with Return("ApproveJoinRequest").
+func (m *TeamsAPI) ApproveJoinRequest(ctx context.Context, teamID string, requestID string) (r0 *contextforge.TeamMember, r1 *contextforge.Response, r2 error) {
+	m.record("ApproveJoinRequest", teamID, requestID)
+	if m.ApproveJoinRequestFunc != nil {
+		return m.ApproveJoinRequestFunc(ctx, teamID, requestID)
+	}
+	m.results("ApproveJoinRequest", &r0, &r1, &r2)
+	return
+}
+
+// RejectJoinRequest records the call and returns the results of RejectJoinRequestFunc, if set, or
+// the results set with Return("RejectJoinRequest").
+func (m *TeamsAPI) RejectJoinRequest(ctx context.Context, teamID string, requestID string) (r0 *contextforge.Response, r1 error) {
+	m.record("RejectJoinRequest", teamID, requestID)
+	if m.RejectJoinRequestFunc != nil {
+		return m.RejectJoinRequestFunc(ctx, teamID, requestID)
+	}
+	m.results("RejectJoinRequest", &r0, &r1)
+	return
+}
+
+// CancellationAPI is a mock of contextforge.CancellationAPI.
+type CancellationAPI struct {
+	Mock
+
+	// CancelFunc, if set, implements Cancel.
+	CancelFunc func(ctx context.Context, req *contextforge.CancellationRequest) (*contextforge.CancellationResponse, *contextforge.Response, error)
+
+	// StatusFunc, if set, implements Status.
+	StatusFunc func(ctx context.Context, requestID string) (*contextforge.CancellationStatus, *contextforge.Response, error)
+}
+
+// Cancel records the call and returns the results of CancelFunc, if set, or
+// the results set with Return("Cancel").
+func (m *CancellationAPI) Cancel(ctx context.Context, req *contextforge.CancellationRequest) (r0 *contextforge.CancellationResponse, r1 *contextforge.Response, r2 error) {
+	m.record("Cancel", req)
+	if m.CancelFunc != nil {
+		return m.CancelFunc(ctx, req)
+	}
+	m.results("Cancel", &r0, &r1, &r2)
+	return
+}
+
+// Status records the call and returns the results of StatusFunc, if set, or
+// the results set with Return("Status").
+func (m *CancellationAPI) Status(ctx context.Context, requestID string) (r0 *contextforge.CancellationStatus, r1 *contextforge.Response, r2 error) {
+	m.record("Status", requestID)
+	if m.StatusFunc != nil {
+		return m.StatusFunc(ctx, requestID)
+	}
+	m.results("Status", &r0, &r1, &r2)
+	return
+}
+
+// AuthAPI is a mock of contextforge.AuthAPI.
+type AuthAPI struct {
+	Mock
+
+	// LoginFunc, if set, implements Login.
+	LoginFunc func(ctx context.Context, login *contextforge.LoginRequest) (*contextforge.LoginResponse, *contextforge.Response, error)
+
+	// MeFunc, if set, implements Me.
+	MeFunc func(ctx context.Context) (*contextforge.AuthUser, *contextforge.Response, error)
+
+	// ListTokensFunc, if set, implements ListTokens.
+	ListTokensFunc func(ctx context.Context, opts *contextforge.APITokenListOptions) ([]*contextforge.APIToken, *contextforge.Response, error)
+
+	// GetTokenFunc, if set, implements GetToken.
+	GetTokenFunc func(ctx context.Context, tokenID string) (*contextforge.APIToken, *contextforge.Response, error)
+
+	// CreateTokenFunc, if set, implements CreateToken.
+	CreateTokenFunc func(ctx context.Context, token *contextforge.APITokenCreate) (*contextforge.APITokenCreateResponse, *contextforge.Response, error)
+
+	// RevokeTokenFunc, if set, implements RevokeToken.
+	RevokeTokenFunc func(ctx context.Context, tokenID string, reason *string) (*contextforge.Response, error)
+}
+
+// Login records the call and returns the results of LoginFunc, if set, or
+// the results set with Return("Login").
+func (m *AuthAPI) Login(ctx context.Context, login *contextforge.LoginRequest) (r0 *contextforge.LoginResponse, r1 *contextforge.Response, r2 error) {
+	m.record("Login", login)
+	if m.LoginFunc != nil {
+		return m.LoginFunc(ctx, login)
+	}
+	m.results("Login", &r0, &r1, &r2)
+	return
+}
+
+// Me records the call and returns the results of MeFunc, if set, or
+// the results set with Return("Me").
+func (m *AuthAPI) Me(ctx context.Context) (r0 *contextforge.AuthUser, r1 *contextforge.Response, r2 error) {
+	m.record("Me")
+	if m.MeFunc != nil {
+		return m.MeFunc(ctx)
+	}
+	m.results("Me", &r0, &r1, &r2)
+	return
+}
+
+// ListTokens records the call and returns the results of ListTokensFunc, if set, or
+// the results set with Return("ListTokens").
+func (m *AuthAPI) ListTokens(ctx context.Context, opts *contextforge.APITokenListOptions) (r0 []*contextforge.APIToken, r1 *contextforge.Response, r2 error) {
+	m.record("ListTokens", opts)
+	if m.ListTokensFunc != nil {
+		return m.ListTokensFunc(ctx, opts)
+	}
+	m.results("ListTokens", &r0, &r1, &r2)
+	return
+}
+
+// GetToken records the call and returns the results of GetTokenFunc, if set, or
+// the results set with Return("GetToken").
+func (m *AuthAPI) GetToken(ctx context.Context, tokenID string) (r0 *contextforge.APIToken, r1 *contextforge.Response, r2 error) {
+	m.record("GetToken", tokenID)
+	if m.GetTokenFunc != nil {
+		return m.GetTokenFunc(ctx, tokenID)
+	}
+	m.results("GetToken", &r0, &r1, &r2)
+	return
+}
+
+// CreateToken records the call and returns the results of CreateTokenFunc, if set, or
+// the results set with Return("CreateToken").
+func (m *AuthAPI) CreateToken(ctx context.Context, token *contextforge.APITokenCreate) (r0 *contextforge.APITokenCreateResponse, r1 *contextforge.Response, r2 error) {
+	m.record("CreateToken", token)
+	if m.CreateTokenFunc != nil {
+		return m.CreateTokenFunc(ctx, token)
+	}
+	m.results("CreateToken", &r0, &r1, &r2)
+	return
+}
+
+// RevokeToken records the call and returns the results of RevokeTokenFunc, if set, or
+// the results set with Return("RevokeToken").
+func (m *AuthAPI) RevokeToken(ctx context.Context, tokenID string, reason *string) (r0 *contextforge.Response, r1 error) {
+	m.record("RevokeToken", tokenID, reason)
+	if m.RevokeTokenFunc != nil {
+		return m.RevokeTokenFunc(ctx, tokenID, reason)
+	}
+	m.results("RevokeToken", &r0, &r1)
+	return
+}
+
+// ExportAPI is a mock of contextforge.ExportAPI.
+type ExportAPI struct {
+	Mock
+
+	// ExportFunc, if set, implements Export.
+	ExportFunc func(ctx context.Context, opts *contextforge.ExportOptions) (*contextforge.ExportDocument, *contextforge.Response, error)
+
+	// ImportFunc, if set, implements Import.
+	ImportFunc func(ctx context.Context, doc *contextforge.ExportDocument, opts *contextforge.ImportOptions) (*contextforge.ImportResult, *contextforge.Response, error)
+
+	// ImportStatusFunc, if set, implements ImportStatus.
+	ImportStatusFunc func(ctx context.Context, importID string) (*contextforge.ImportResult, *contextforge.Response, error)
+}
+
+// Export records the call and returns the results of ExportFunc, if set, or
+// the results set with Return("Export").
+func (m *ExportAPI) Export(ctx context.Context, opts *contextforge.ExportOptions) (r0 *contextforge.ExportDocument, r1 *contextforge.Response, r2 error) {
+	m.record("Export", opts)
+	if m.ExportFunc != nil {
+		return m.ExportFunc(ctx, opts)
+	}
+	m.results("Export", &r0, &r1, &r2)
+	return
+}
+
+// Import records the call and returns the results of ImportFunc, if set, or
+// the results set with Return("Import").
+func (m *ExportAPI) Import(ctx context.Context, doc *contextforge.ExportDocument, opts *contextforge.ImportOptions) (r0 *contextforge.ImportResult, r1 *contextforge.Response, r2 error) {
+	m.record("Import", doc, opts)
+	if m.ImportFunc != nil {
+		return m.ImportFunc(ctx, doc, opts)
+	}
+	m.results("Import", &r0, &r1, &r2)
+	return
+}
+
+// ImportStatus records the call and returns the results of ImportStatusFunc, if set, or
+// the results set with Return("ImportStatus").
+func (m *ExportAPI) ImportStatus(ctx context.Context, importID string) (r0 *contextforge.ImportResult, r1 *contextforge.Response, r2 error) {
+	m.record("ImportStatus", importID)
+	if m.ImportStatusFunc != nil {
+		return m.ImportStatusFunc(ctx, importID)
+	}
+	m.results("ImportStatus", &r0, &r1, &r2)
+	return
+}
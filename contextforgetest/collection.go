@@ -0,0 +1,274 @@
+package contextforgetest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+// collection stores the entities of one kind in creation order.
+type collection[T any] struct {
+	kind      string // singular name used in messages, e.g. "Tool"
+	listKey   string // key of the items in paginated list responses, e.g. "tools"
+	nameField string // the unique field returned by name, e.g. "name"
+
+	seq   int
+	ids   []string
+	items map[string]*T
+
+	// Accessors for the fields the fake server acts on.
+	id        func(*T) string
+	setID     func(*T, string)
+	name      func(*T) string // must be unique among entities of the kind
+	active    func(*T) bool
+	setActive func(*T, bool)
+	tags      func(*T) []contextforge.Tag
+	team      func(*T) string
+	access    func(*T) string // visibility
+	touch     func(*T, time.Time, bool)
+
+	// updated, if set, is called with the body of each successful update.
+	updated func(id string, body []byte)
+}
+
+// insert stores item, assigning it an ID unless it has one. It fails with
+// 409 Conflict if an entity of the kind already has item's name or ID.
+func (c *collection[T]) insert(item *T) *apiError {
+	if existing := c.byName(c.name(item)); existing != nil {
+		return conflict("%s already exists with %s: %s", c.kind, c.nameField, c.name(item))
+	}
+	if c.items == nil {
+		c.items = make(map[string]*T)
+	}
+	id := c.id(item)
+	if id == "" {
+		c.seq++
+		id = fmt.Sprintf("%032x", c.seq)
+		c.setID(item, id)
+	}
+	if _, ok := c.items[id]; ok {
+		return conflict("%s already exists with id: %s", c.kind, id)
+	}
+	c.touch(item, now(), true)
+	c.ids = append(c.ids, id)
+	c.items[id] = item
+	return nil
+}
+
+func (c *collection[T]) get(id string) (*T, *apiError) {
+	item, ok := c.items[id]
+	if !ok {
+		return nil, notFound("%s not found: %s", c.kind, id)
+	}
+	return item, nil
+}
+
+func (c *collection[T]) byName(name string) *T {
+	for _, id := range c.ids {
+		if c.name(c.items[id]) == name {
+			return c.items[id]
+		}
+	}
+	return nil
+}
+
+func (c *collection[T]) delete(id string) *apiError {
+	if _, ok := c.items[id]; !ok {
+		return notFound("%s not found: %s", c.kind, id)
+	}
+	delete(c.items, id)
+	c.ids = slices.DeleteFunc(c.ids, func(v string) bool { return v == id })
+	return nil
+}
+
+// update applies the JSON object body to the entity with the given ID. Keys
+// in body replace the corresponding fields of the entity; other fields keep
+// their values. Renaming to the name of another entity fails with 409.
+func (c *collection[T]) update(id string, body []byte) (*T, *apiError) {
+	item, err := c.get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, invalid("body", "invalid JSON: %v", err)
+	}
+	current, _ := json.Marshal(item)
+	var merged map[string]any
+	_ = json.Unmarshal(current, &merged)
+	for k, v := range fields {
+		if k == "id" || v == nil {
+			continue
+		}
+		merged[k] = v
+	}
+
+	data, _ := json.Marshal(merged)
+	updated := new(T)
+	if err := json.Unmarshal(data, updated); err != nil {
+		return nil, invalid("body", "invalid %s: %v", strings.ToLower(c.kind), err)
+	}
+	if err := required(c.nameField, c.name(updated)); err != nil {
+		return nil, err
+	}
+	if existing := c.byName(c.name(updated)); existing != nil && c.id(existing) != id {
+		return nil, conflict("%s already exists with %s: %s", c.kind, c.nameField, c.name(updated))
+	}
+	c.setID(updated, id)
+	c.touch(updated, now(), false)
+	c.items[id] = updated
+	if c.updated != nil {
+		c.updated(id, body)
+	}
+	return updated, nil
+}
+
+func (c *collection[T]) setState(id string, activate bool) (*T, *apiError) {
+	item, err := c.get(id)
+	if err != nil {
+		return nil, err
+	}
+	c.setActive(item, activate)
+	c.touch(item, now(), false)
+	return item, nil
+}
+
+// filter returns the entities matching the list query parameters
+// include_inactive, tags, team_id, and visibility, in creation order.
+func (c *collection[T]) filter(q map[string][]string) []*T {
+	get := func(key string) string {
+		if v := q[key]; len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	includeInactive := get("include_inactive") == "true"
+	tags := splitList(get("tags"))
+	team := get("team_id")
+	visibility := get("visibility")
+
+	var items []*T
+	for _, id := range c.ids {
+		item := c.items[id]
+		if !includeInactive && !c.active(item) {
+			continue
+		}
+		if len(tags) > 0 && !hasAnyTag(c.tags(item), tags) {
+			continue
+		}
+		if team != "" && c.team(item) != team {
+			continue
+		}
+		if visibility != "" && c.access(item) != visibility {
+			continue
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func hasAnyTag(tags []contextforge.Tag, want []string) bool {
+	for _, t := range tags {
+		if slices.Contains(want, t.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// page selects one page of items according to the cursor, skip, and limit
+// query parameters, and returns it with the cursor of the next page, or ""
+// if it is the last. Limit defaults to pageSize.
+func page[T any](items []*T, q map[string][]string, pageSize int) ([]*T, string, *apiError) {
+	get := func(key string) string {
+		if v := q[key]; len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+
+	offset := 0
+	if cursor := get("cursor"); cursor != "" {
+		n, ok := decodeCursor(cursor)
+		if !ok {
+			return nil, "", invalid("cursor", "invalid cursor: %s", cursor)
+		}
+		offset = n
+	} else if skip := get("skip"); skip != "" {
+		n, err := strconv.Atoi(skip)
+		if err != nil || n < 0 {
+			return nil, "", invalid("skip", "skip must be a non-negative integer")
+		}
+		offset = n
+	}
+
+	limit := pageSize
+	if l := get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 0 {
+			return nil, "", invalid("limit", "limit must be a non-negative integer")
+		}
+		if n > 0 {
+			limit = n
+		}
+	}
+
+	if offset > len(items) {
+		offset = len(items)
+	}
+	end := len(items)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	next := ""
+	if end < len(items) {
+		next = encodeCursor(end)
+	}
+	return items[offset:end], next, nil
+}
+
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, bool) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(string(data), "offset:"))
+	if err != nil || n < 0 || !strings.HasPrefix(string(data), "offset:") {
+		return 0, false
+	}
+	return n, true
+}
+
+// clone returns a deep copy of v.
+func clone[T any](v *T) *T {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("contextforgetest: clone %T: %v", v, err))
+	}
+	c := new(T)
+	if err := json.Unmarshal(data, c); err != nil {
+		panic(fmt.Sprintf("contextforgetest: clone %T: %v", v, err))
+	}
+	return c
+}
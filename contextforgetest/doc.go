@@ -0,0 +1,48 @@
+// Package contextforgetest provides an in-memory fake of the ContextForge
+// REST API for testing code that uses the contextforge package, without a
+// running gateway.
+//
+// A Server keeps the tools, resources, gateways, servers, prompts, agents,
+// and teams created through it in memory and serves them back the way the
+// API does, so a test can exercise its code against realistic behavior
+// rather than canned responses:
+//
+//	srv := contextforgetest.NewServer()
+//	defer srv.Close()
+//
+//	client := srv.Client()
+//	tool, _, err := client.Tools.Create(ctx, &contextforge.Tool{Name: "search"}, nil)
+//
+// State can also be seeded directly with the Add methods, which accept
+// entities the API would not let a client create, such as inactive ones or
+// ones with fixed IDs:
+//
+//	srv.AddTool(&contextforge.Tool{ID: "t1", Name: "fetch", Enabled: false})
+//
+// # Behavior
+//
+// The fake implements the endpoints the contextforge services call for
+// CRUD, state changes, and listing:
+//
+//   - List endpoints page with cursors, or with skip and limit for teams.
+//     Pages hold DefaultPageSize items unless the request sets a limit; use
+//     SetPageSize to exercise pagination with few entities.
+//   - List endpoints filter by the include_inactive, tags, team_id, and
+//     visibility parameters. Inactive entities are omitted by default.
+//   - The /state and /toggle endpoints activate and deactivate entities.
+//   - Updates replace only the fields present in the request body.
+//   - Unknown IDs fail with 404 Not Found, duplicate names (or URIs, for
+//     resources) with 409 Conflict, and missing required fields with
+//     422 Unprocessable Entity.
+//   - Requests without the server's bearer token fail with 401
+//     Unauthorized. /auth/login accepts any username and password and
+//     issues the token.
+//
+// Resource content is read through GET /resources/{id}, prompts are
+// rendered by substituting their {{ variable }} placeholders, and gateway
+// tool refreshes succeed without contacting the gateway.
+//
+// Other endpoints, such as tool invocation, team discovery and join
+// requests, and the MCP protocol endpoints, are not implemented and return
+// 404 Not Found.
+package contextforgetest
@@ -0,0 +1,664 @@
+package contextforgetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+// initCollections sets up the accessors of each collection.
+func (s *Server) initCollections() {
+	s.tools = collection[contextforge.Tool]{
+		kind: "Tool", listKey: "tools", nameField: "name",
+		id:        func(t *contextforge.Tool) string { return t.ID },
+		setID:     func(t *contextforge.Tool, id string) { t.ID = id },
+		name:      func(t *contextforge.Tool) string { return t.Name },
+		active:    func(t *contextforge.Tool) bool { return t.Enabled },
+		setActive: func(t *contextforge.Tool, v bool) { t.Enabled = v },
+		tags:      func(t *contextforge.Tool) []contextforge.Tag { return t.Tags },
+		team:      func(t *contextforge.Tool) string { return deref(t.TeamID) },
+		access:    func(t *contextforge.Tool) string { return t.Visibility },
+		touch: func(t *contextforge.Tool, at time.Time, created bool) {
+			t.CreatedAt, t.UpdatedAt, t.Version = touch(t.CreatedAt, at, created, t.Version)
+		},
+	}
+	s.resources = collection[contextforge.Resource]{
+		kind: "Resource", listKey: "resources", nameField: "uri",
+		id: func(r *contextforge.Resource) string {
+			if r.ID == nil {
+				return ""
+			}
+			return r.ID.String()
+		},
+		setID: func(r *contextforge.Resource, id string) {
+			fid := contextforge.FlexibleID(id)
+			r.ID = &fid
+		},
+		name:      func(r *contextforge.Resource) string { return r.URI },
+		active:    func(r *contextforge.Resource) bool { return r.IsActive },
+		setActive: func(r *contextforge.Resource, v bool) { r.IsActive, r.Enabled = v, v },
+		tags:      func(r *contextforge.Resource) []contextforge.Tag { return r.Tags },
+		team:      func(r *contextforge.Resource) string { return deref(r.TeamID) },
+		access:    func(r *contextforge.Resource) string { return deref(r.Visibility) },
+		touch: func(r *contextforge.Resource, at time.Time, created bool) {
+			r.CreatedAt, r.UpdatedAt, r.Version = touch(r.CreatedAt, at, created, r.Version)
+		},
+		// Content is stored apart from the resource.
+		updated: func(id string, body []byte) {
+			var update struct {
+				Content any `json:"content"`
+			}
+			if json.Unmarshal(body, &update) == nil && update.Content != nil {
+				s.contents[id] = update.Content
+			}
+		},
+	}
+	s.gateways = collection[contextforge.Gateway]{
+		kind: "Gateway", listKey: "gateways", nameField: "name",
+		id:        func(g *contextforge.Gateway) string { return deref(g.ID) },
+		setID:     func(g *contextforge.Gateway, id string) { g.ID = &id },
+		name:      func(g *contextforge.Gateway) string { return g.Name },
+		active:    func(g *contextforge.Gateway) bool { return g.Enabled },
+		setActive: func(g *contextforge.Gateway, v bool) { g.Enabled = v },
+		tags:      func(g *contextforge.Gateway) []contextforge.Tag { return g.Tags },
+		team:      func(g *contextforge.Gateway) string { return deref(g.TeamID) },
+		access:    func(g *contextforge.Gateway) string { return deref(g.Visibility) },
+		touch: func(g *contextforge.Gateway, at time.Time, created bool) {
+			g.CreatedAt, g.UpdatedAt, g.Version = touch(g.CreatedAt, at, created, g.Version)
+		},
+	}
+	s.servers = collection[contextforge.Server]{
+		kind: "Server", listKey: "servers", nameField: "name",
+		id:        func(v *contextforge.Server) string { return v.ID },
+		setID:     func(v *contextforge.Server, id string) { v.ID = id },
+		name:      func(v *contextforge.Server) string { return v.Name },
+		active:    func(v *contextforge.Server) bool { return v.IsActive },
+		setActive: func(v *contextforge.Server, a bool) { v.IsActive, v.Enabled = a, a },
+		tags:      func(v *contextforge.Server) []contextforge.Tag { return v.Tags },
+		team:      func(v *contextforge.Server) string { return deref(v.TeamID) },
+		access:    func(v *contextforge.Server) string { return deref(v.Visibility) },
+		touch: func(v *contextforge.Server, at time.Time, created bool) {
+			v.CreatedAt, v.UpdatedAt, v.Version = touch(v.CreatedAt, at, created, v.Version)
+		},
+	}
+	s.prompts = collection[contextforge.Prompt]{
+		kind: "Prompt", listKey: "prompts", nameField: "name",
+		id:        func(p *contextforge.Prompt) string { return p.ID },
+		setID:     func(p *contextforge.Prompt, id string) { p.ID = id },
+		name:      func(p *contextforge.Prompt) string { return p.Name },
+		active:    func(p *contextforge.Prompt) bool { return p.IsActive },
+		setActive: func(p *contextforge.Prompt, v bool) { p.IsActive, p.Enabled = v, v },
+		tags:      func(p *contextforge.Prompt) []contextforge.Tag { return p.Tags },
+		team:      func(p *contextforge.Prompt) string { return deref(p.TeamID) },
+		access:    func(p *contextforge.Prompt) string { return deref(p.Visibility) },
+		touch: func(p *contextforge.Prompt, at time.Time, created bool) {
+			p.CreatedAt, p.UpdatedAt, p.Version = touch(p.CreatedAt, at, created, p.Version)
+		},
+	}
+	s.agents = collection[contextforge.Agent]{
+		kind: "Agent", listKey: "agents", nameField: "name",
+		id:        func(a *contextforge.Agent) string { return a.ID },
+		setID:     func(a *contextforge.Agent, id string) { a.ID = id },
+		name:      func(a *contextforge.Agent) string { return a.Name },
+		active:    func(a *contextforge.Agent) bool { return a.Enabled },
+		setActive: func(a *contextforge.Agent, v bool) { a.Enabled = v },
+		tags:      func(a *contextforge.Agent) []contextforge.Tag { return a.Tags },
+		team:      func(a *contextforge.Agent) string { return deref(a.TeamID) },
+		access:    func(a *contextforge.Agent) string { return deref(a.Visibility) },
+		touch: func(a *contextforge.Agent, at time.Time, created bool) {
+			a.CreatedAt, a.UpdatedAt, a.Version = touch(a.CreatedAt, at, created, a.Version)
+		},
+	}
+	s.teams = collection[contextforge.Team]{
+		kind: "Team", listKey: "teams", nameField: "name",
+		id:        func(t *contextforge.Team) string { return t.ID },
+		setID:     func(t *contextforge.Team, id string) { t.ID = id },
+		name:      func(t *contextforge.Team) string { return t.Name },
+		active:    func(t *contextforge.Team) bool { return t.IsActive },
+		setActive: func(t *contextforge.Team, v bool) { t.IsActive = v },
+		tags:      func(t *contextforge.Team) []contextforge.Tag { return nil },
+		team:      func(t *contextforge.Team) string { return t.ID },
+		access:    func(t *contextforge.Team) string { return deref(t.Visibility) },
+		touch: func(t *contextforge.Team, at time.Time, created bool) {
+			t.CreatedAt, t.UpdatedAt, _ = touch(t.CreatedAt, at, created, nil)
+		},
+	}
+}
+
+// touch returns the creation time, update time, and version of an entity
+// created or modified at the given time.
+func touch(createdAt *contextforge.Timestamp, at time.Time, created bool, version *int) (*contextforge.Timestamp, *contextforge.Timestamp, *int) {
+	ts := &contextforge.Timestamp{Time: at}
+	if created || createdAt == nil {
+		createdAt = ts
+	}
+	v := 1
+	if version != nil && !created {
+		v = *version + 1
+	}
+	return createdAt, ts, &v
+}
+
+// handleEntity registers the endpoints shared by every entity kind under
+// prefix: list, get, update, delete, and the /state and /toggle endpoints.
+// get is false for kinds whose GET /{id} endpoint is not the entity, and
+// stateResult builds the body of the state endpoints.
+func handleEntity[T any](s *Server, mux *http.ServeMux, prefix string, c *collection[T], get bool, stateResult func(*T) any) {
+	mux.HandleFunc("GET /"+prefix, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		items, next, err := page(c.filter(r.URL.Query()), r.URL.Query(), s.pageSize)
+		var data []byte
+		if err == nil {
+			data = listBody(r, c.listKey, items, next)
+		}
+		s.mu.Unlock()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if next != "" {
+			w.Header().Set("X-Next-Cursor", next)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	})
+	if get {
+		s.handle(mux, "GET /"+prefix+"/{id}", http.StatusOK, func(r *http.Request) (any, *apiError) {
+			return c.get(r.PathValue("id"))
+		})
+	}
+	s.handle(mux, "PUT /"+prefix+"/{id}", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		body, err := readRaw(r)
+		if err != nil {
+			return nil, err
+		}
+		return c.update(r.PathValue("id"), body)
+	})
+	s.handle(mux, "DELETE /"+prefix+"/{id}", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		id := r.PathValue("id")
+		if err := c.delete(id); err != nil {
+			return nil, err
+		}
+		return map[string]any{"status": "success", "message": fmt.Sprintf("%s %s deleted successfully", c.kind, id)}, nil
+	})
+	for _, endpoint := range []string{"state", "toggle"} {
+		s.handle(mux, "POST /"+prefix+"/{id}/"+endpoint, http.StatusOK, func(r *http.Request) (any, *apiError) {
+			activate := r.URL.Query().Get("activate") != "false"
+			item, err := c.setState(r.PathValue("id"), activate)
+			if err != nil {
+				return nil, err
+			}
+			return stateResult(item), nil
+		})
+	}
+}
+
+// listBody encodes a page of items, as a {listKey: [...], "nextCursor": ...}
+// envelope if the request asked for include_pagination, or as a plain array
+// otherwise.
+func listBody[T any](r *http.Request, key string, items []*T, next string) []byte {
+	if items == nil {
+		items = []*T{}
+	}
+	var body any = items
+	if r.URL.Query().Get("include_pagination") == "true" {
+		envelope := map[string]any{key: items}
+		if next != "" {
+			envelope["nextCursor"] = next
+		}
+		body = envelope
+	}
+	data, _ := json.Marshal(body)
+	return append(data, '\n')
+}
+
+// wrapped returns a stateResult that nests the entity under key, as the
+// state endpoints of most kinds do.
+func wrapped[T any](kind, key string) func(*T) any {
+	return func(item *T) any {
+		return map[string]any{"status": "success", "message": kind + " state updated", key: item}
+	}
+}
+
+func readRaw(r *http.Request) ([]byte, *apiError) {
+	var raw json.RawMessage
+	if err := readBody(r, &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// createRequest is the wrapper most create endpoints accept: the entity
+// under a kind-specific key, with the team and visibility next to it.
+type createRequest[T any] struct {
+	Entity     T
+	TeamID     *string
+	Visibility *string
+}
+
+func readCreate[T any](r *http.Request, key string) (*createRequest[T], *apiError) {
+	var body map[string]json.RawMessage
+	if err := readBody(r, &body); err != nil {
+		return nil, err
+	}
+	raw, ok := body[key]
+	if !ok {
+		return nil, invalid(key, "%s is required", key)
+	}
+	req := &createRequest[T]{}
+	if err := json.Unmarshal(raw, &req.Entity); err != nil {
+		return nil, invalid(key, "invalid %s: %v", key, err)
+	}
+	_ = json.Unmarshal(body["team_id"], &req.TeamID)
+	_ = json.Unmarshal(body["visibility"], &req.Visibility)
+	return req, nil
+}
+
+// visibility returns the first non-empty visibility, or "public".
+func visibility(values ...*string) *string {
+	for _, v := range values {
+		if v != nil && *v != "" {
+			return v
+		}
+	}
+	return contextforge.String("public")
+}
+
+func firstOf(values ...*string) *string {
+	for _, v := range values {
+		if v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+func (s *Server) handleTools(mux *http.ServeMux) {
+	handleEntity(s, mux, "tools", &s.tools, true, wrapped[contextforge.Tool]("Tool", "tool"))
+
+	s.handle(mux, "POST /tools", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		req, err := readCreate[contextforge.Tool](r, "tool")
+		if err != nil {
+			return nil, err
+		}
+		tool := &req.Entity
+		if err := required("name", tool.Name); err != nil {
+			return nil, err
+		}
+		tool.ID = ""
+		tool.Enabled = true
+		tool.TeamID = firstOf(req.TeamID, tool.TeamID)
+		tool.Visibility = *visibility(req.Visibility, &tool.Visibility)
+		tool.OwnerEmail = contextforge.String(s.email)
+		if tool.InputSchema == nil {
+			tool.InputSchema = map[string]any{"type": "object", "properties": map[string]any{}}
+		}
+		if err := s.tools.insert(tool); err != nil {
+			return nil, err
+		}
+		return tool, nil
+	})
+}
+
+func (s *Server) handleResources(mux *http.ServeMux) {
+	handleEntity(s, mux, "resources", &s.resources, false, func(res *contextforge.Resource) any {
+		return map[string]any{"status": "success", "message": "Resource state updated", "resource": snakeCase(res)}
+	})
+
+	s.handle(mux, "POST /resources", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		req, err := readCreate[contextforge.ResourceCreate](r, "resource")
+		if err != nil {
+			return nil, err
+		}
+		rc := &req.Entity
+		if err := required("uri", rc.URI); err != nil {
+			return nil, err
+		}
+		if err := required("name", rc.Name); err != nil {
+			return nil, err
+		}
+		if rc.Content == nil {
+			return nil, invalid("content", "content is required")
+		}
+		res := &contextforge.Resource{
+			URI:         rc.URI,
+			Name:        rc.Name,
+			Description: rc.Description,
+			MimeType:    rc.MimeType,
+			IsActive:    true,
+			Enabled:     true,
+			Tags:        contextforge.NewTags(rc.Tags),
+			TeamID:      req.TeamID,
+			OwnerEmail:  contextforge.String(s.email),
+			Visibility:  visibility(req.Visibility),
+		}
+		if text, ok := rc.Content.(string); ok {
+			res.Size = contextforge.Int(len(text))
+		}
+		if err := s.resources.insert(res); err != nil {
+			return nil, err
+		}
+		s.contents[s.resources.id(res)] = rc.Content
+		return res, nil
+	})
+
+	s.handle(mux, "GET /resources/{id}/info", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		res, err := s.resources.get(r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		if !res.IsActive && r.URL.Query().Get("include_inactive") != "true" {
+			return nil, notFound("Resource not found: %s", r.PathValue("id"))
+		}
+		return res, nil
+	})
+
+	s.handle(mux, "GET /resources/{id}", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		id := r.PathValue("id")
+		res, err := s.resources.get(id)
+		if err != nil {
+			return nil, err
+		}
+		if !res.IsActive {
+			return nil, notFound("Resource not found: %s", id)
+		}
+		content := &contextforge.ResourceContent{Type: "resource", URI: res.URI, MimeType: res.MimeType}
+		switch c := s.contents[id].(type) {
+		case string:
+			content.Text = &c
+		default:
+			data, _ := json.Marshal(c)
+			content.Text = contextforge.String(string(data))
+		}
+		return content, nil
+	})
+}
+
+func (s *Server) handleGateways(mux *http.ServeMux) {
+	handleEntity(s, mux, "gateways", &s.gateways, true, wrapped[contextforge.Gateway]("Gateway", "gateway"))
+
+	// Gateways are created from the top-level fields of the body.
+	s.handle(mux, "POST /gateways", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		var req struct {
+			contextforge.Gateway
+			TeamIDField *string `json:"team_id"`
+		}
+		if err := readBody(r, &req); err != nil {
+			return nil, err
+		}
+		gw := &req.Gateway
+		if err := required("name", gw.Name); err != nil {
+			return nil, err
+		}
+		if err := required("url", gw.URL); err != nil {
+			return nil, err
+		}
+		gw.ID = nil
+		gw.Enabled = true
+		gw.Reachable = true
+		if gw.Transport == "" {
+			gw.Transport = "SSE"
+		}
+		gw.TeamID = firstOf(req.TeamIDField, gw.TeamID)
+		gw.Visibility = visibility(gw.Visibility)
+		gw.OwnerEmail = contextforge.String(s.email)
+		gw.Slug = contextforge.String(slugify(gw.Name))
+		if err := s.gateways.insert(gw); err != nil {
+			return nil, err
+		}
+		return gw, nil
+	})
+
+	s.handle(mux, "POST /gateways/{id}/tools/refresh", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		id := r.PathValue("id")
+		if _, err := s.gateways.get(id); err != nil {
+			return nil, err
+		}
+		return &contextforge.GatewayRefreshResponse{GatewayID: id, Success: true}, nil
+	})
+}
+
+func (s *Server) handleServers(mux *http.ServeMux) {
+	handleEntity(s, mux, "servers", &s.servers, true, func(v *contextforge.Server) any { return v })
+
+	s.handle(mux, "POST /servers", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		req, err := readCreate[contextforge.ServerCreate](r, "server")
+		if err != nil {
+			return nil, err
+		}
+		sc := &req.Entity
+		if err := required("name", sc.Name); err != nil {
+			return nil, err
+		}
+		v := &contextforge.Server{
+			Name:                sc.Name,
+			Description:         sc.Description,
+			Icon:                sc.Icon,
+			IsActive:            true,
+			Enabled:             true,
+			AssociatedTools:     sc.AssociatedTools,
+			AssociatedResources: sc.AssociatedResources,
+			AssociatedPrompts:   sc.AssociatedPrompts,
+			AssociatedA2aAgents: sc.AssociatedA2aAgents,
+			Tags:                contextforge.NewTags(sc.Tags),
+			TeamID:              firstOf(req.TeamID, sc.TeamID),
+			OwnerEmail:          contextforge.String(s.email),
+			Visibility:          visibility(req.Visibility, sc.Visibility),
+		}
+		if err := s.servers.insert(v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+
+	s.handle(mux, "GET /servers/{id}/tools", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		v, err := s.servers.get(r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		return associated(&s.tools, v.AssociatedTools, r), nil
+	})
+	s.handle(mux, "GET /servers/{id}/resources", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		v, err := s.servers.get(r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		return associated(&s.resources, v.AssociatedResources, r), nil
+	})
+	s.handle(mux, "GET /servers/{id}/prompts", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		v, err := s.servers.get(r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		return associated(&s.prompts, v.AssociatedPrompts, r), nil
+	})
+}
+
+// associated returns the entities of c with the given IDs, skipping
+// inactive ones unless the request includes them.
+func associated[T any](c *collection[T], ids []string, r *http.Request) []*T {
+	includeInactive := r.URL.Query().Get("include_inactive") == "true"
+	items := []*T{}
+	for _, id := range ids {
+		if item, ok := c.items[id]; ok && (includeInactive || c.active(item)) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// templateVar matches the Jinja-style variables of prompt templates.
+var templateVar = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+func (s *Server) handlePrompts(mux *http.ServeMux) {
+	handleEntity(s, mux, "prompts", &s.prompts, false, wrapped[contextforge.Prompt]("Prompt", "prompt"))
+
+	s.handle(mux, "POST /prompts", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		req, err := readCreate[contextforge.PromptCreate](r, "prompt")
+		if err != nil {
+			return nil, err
+		}
+		pc := &req.Entity
+		if err := required("name", pc.Name); err != nil {
+			return nil, err
+		}
+		if err := required("template", pc.Template); err != nil {
+			return nil, err
+		}
+		p := &contextforge.Prompt{
+			Name:        pc.Name,
+			CustomName:  pc.CustomName,
+			DisplayName: pc.DisplayName,
+			Description: pc.Description,
+			Template:    pc.Template,
+			Arguments:   pc.Arguments,
+			IsActive:    true,
+			Enabled:     true,
+			Tags:        contextforge.NewTags(pc.Tags),
+			TeamID:      firstOf(req.TeamID, pc.TeamID),
+			OwnerEmail:  contextforge.String(s.email),
+			Visibility:  visibility(req.Visibility, pc.Visibility),
+		}
+		if p.Arguments == nil {
+			// Like the API, derive the arguments from the template.
+			p.Arguments = []contextforge.PromptArgument{}
+			for _, m := range templateVar.FindAllStringSubmatch(p.Template, -1) {
+				if !slices.ContainsFunc(p.Arguments, func(a contextforge.PromptArgument) bool { return a.Name == m[1] }) {
+					p.Arguments = append(p.Arguments, contextforge.PromptArgument{Name: m[1], Required: true})
+				}
+			}
+		}
+		if err := s.prompts.insert(p); err != nil {
+			return nil, err
+		}
+		return p, nil
+	})
+
+	render := func(r *http.Request, args map[string]string) (any, *apiError) {
+		p, err := s.prompts.get(r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range p.Arguments {
+			if _, ok := args[a.Name]; a.Required && !ok {
+				return nil, invalid(a.Name, "missing required argument: %s", a.Name)
+			}
+		}
+		text := templateVar.ReplaceAllStringFunc(p.Template, func(v string) string {
+			return args[templateVar.FindStringSubmatch(v)[1]]
+		})
+		return &contextforge.PromptResult{
+			Description: p.Description,
+			Messages: []*contextforge.PromptMessage{{
+				Role:    "user",
+				Content: &contextforge.PromptMessageContent{Type: "text", Text: &text},
+			}},
+		}, nil
+	}
+	s.handle(mux, "POST /prompts/{id}", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		args := map[string]string{}
+		if err := readBody(r, &args); err != nil {
+			return nil, err
+		}
+		return render(r, args)
+	})
+	s.handle(mux, "GET /prompts/{id}", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		return render(r, nil)
+	})
+}
+
+func (s *Server) handleAgents(mux *http.ServeMux) {
+	handleEntity(s, mux, "a2a", &s.agents, true, func(a *contextforge.Agent) any { return a })
+
+	s.handle(mux, "POST /a2a", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		req, err := readCreate[contextforge.AgentCreate](r, "agent")
+		if err != nil {
+			return nil, err
+		}
+		ac := &req.Entity
+		if err := required("name", ac.Name); err != nil {
+			return nil, err
+		}
+		if err := required("endpoint_url", ac.EndpointURL); err != nil {
+			return nil, err
+		}
+		a := &contextforge.Agent{
+			Name:              ac.Name,
+			Slug:              slugify(ac.Name),
+			Description:       ac.Description,
+			EndpointURL:       ac.EndpointURL,
+			AgentType:         ac.AgentType,
+			ProtocolVersion:   ac.ProtocolVersion,
+			Capabilities:      ac.Capabilities,
+			Config:            ac.Config,
+			AuthType:          ac.AuthType,
+			OAuthConfig:       ac.OAuthConfig,
+			AuthQueryParamKey: ac.AuthQueryParamKey,
+			Enabled:           true,
+			Reachable:         true,
+			Tags:              contextforge.NewTags(ac.Tags),
+			TeamID:            firstOf(req.TeamID, ac.TeamID),
+			OwnerEmail:        contextforge.String(s.email),
+			Visibility:        visibility(req.Visibility, ac.Visibility),
+		}
+		if ac.Slug != nil {
+			a.Slug = *ac.Slug
+		}
+		if a.AgentType == "" {
+			a.AgentType = "generic"
+		}
+		if a.ProtocolVersion == "" {
+			a.ProtocolVersion = "1.0"
+		}
+		if err := s.agents.insert(a); err != nil {
+			return nil, err
+		}
+		return a, nil
+	})
+}
+
+// slugify lowercases s and replaces runs of other characters than letters
+// and digits with a hyphen.
+func slugify(s string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			hyphen = false
+		} else if !hyphen && b.Len() > 0 {
+			b.WriteByte('-')
+			hyphen = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// snakeCase returns the JSON object of v with its keys in snake_case, the
+// format of the resource state endpoints.
+func snakeCase(v any) map[string]any {
+	data, _ := json.Marshal(v)
+	var fields map[string]any
+	_ = json.Unmarshal(data, &fields)
+
+	out := make(map[string]any, len(fields))
+	for k, v := range fields {
+		var b strings.Builder
+		for _, r := range k {
+			if unicode.IsUpper(r) {
+				b.WriteByte('_')
+				r = unicode.ToLower(r)
+			}
+			b.WriteRune(r)
+		}
+		out[b.String()] = v
+	}
+	return out
+}
@@ -0,0 +1,69 @@
+package contextforgetest
+
+import (
+	"fmt"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+// The Add methods store entities directly, bypassing the API and its
+// defaults, so tests can set up state the API would not let them create,
+// such as inactive entities or fixed IDs. Each stores a copy of its
+// argument, assigns an ID if it has none, and returns a copy of what was
+// stored. They panic if the name or ID of the entity is already taken.
+
+// AddTool stores a tool.
+func (s *Server) AddTool(tool *contextforge.Tool) *contextforge.Tool {
+	return add(s, &s.tools, tool)
+}
+
+// AddResource stores a resource, served with the given content.
+func (s *Server) AddResource(resource *contextforge.Resource, content string) *contextforge.Resource {
+	stored := add(s, &s.resources, resource)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.contents[s.resources.id(stored)] = content
+	return stored
+}
+
+// AddGateway stores a gateway.
+func (s *Server) AddGateway(gateway *contextforge.Gateway) *contextforge.Gateway {
+	return add(s, &s.gateways, gateway)
+}
+
+// AddServer stores a server.
+func (s *Server) AddServer(server *contextforge.Server) *contextforge.Server {
+	return add(s, &s.servers, server)
+}
+
+// AddPrompt stores a prompt.
+func (s *Server) AddPrompt(prompt *contextforge.Prompt) *contextforge.Prompt {
+	return add(s, &s.prompts, prompt)
+}
+
+// AddAgent stores an A2A agent.
+func (s *Server) AddAgent(agent *contextforge.Agent) *contextforge.Agent {
+	return add(s, &s.agents, agent)
+}
+
+// AddTeam stores a team, with the server's user as its owner.
+func (s *Server) AddTeam(team *contextforge.Team) *contextforge.Team {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := clone(team)
+	if err := s.teams.insert(stored); err != nil {
+		panic(fmt.Sprintf("contextforgetest: add team: %s", err.message))
+	}
+	s.addMember(stored, s.email, "owner", nil)
+	return clone(stored)
+}
+
+func add[T any](s *Server, c *collection[T], item *T) *T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := clone(item)
+	if err := c.insert(stored); err != nil {
+		panic(fmt.Sprintf("contextforgetest: add %T: %s", item, err.message))
+	}
+	return clone(stored)
+}
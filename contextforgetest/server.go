@@ -0,0 +1,254 @@
+package contextforgetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+// DefaultPageSize is the number of items list endpoints return when the
+// request sets no limit.
+const DefaultPageSize = 50
+
+// DefaultToken is the bearer token a Server accepts, and issues from
+// /auth/login, unless changed with SetToken.
+const DefaultToken = "contextforgetest-token"
+
+// Server is a stateful, in-memory fake of the ContextForge REST API, served
+// over HTTP on a local address. The zero value is not usable; create one
+// with NewServer. A Server is safe for concurrent use.
+type Server struct {
+	// URL is the base address of the server, e.g. "http://127.0.0.1:1234".
+	URL string
+
+	httpServer *httptest.Server
+
+	mu        sync.Mutex
+	token     string
+	email     string
+	pageSize  int
+	tools     collection[contextforge.Tool]
+	resources collection[contextforge.Resource]
+	contents  map[string]any // resource content by resource ID
+	gateways  collection[contextforge.Gateway]
+	servers   collection[contextforge.Server]
+	prompts   collection[contextforge.Prompt]
+	agents    collection[contextforge.Agent]
+	teams     collection[contextforge.Team]
+	members   map[string][]*contextforge.TeamMember     // by team ID
+	invites   map[string][]*contextforge.TeamInvitation // by team ID
+	inviteSeq int
+	memberSeq int
+}
+
+// NewServer starts and returns a new, empty Server. The caller should call
+// Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		token:    DefaultToken,
+		email:    "admin@example.com",
+		pageSize: DefaultPageSize,
+		contents: make(map[string]any),
+		members:  make(map[string][]*contextforge.TeamMember),
+		invites:  make(map[string][]*contextforge.TeamInvitation),
+	}
+	s.initCollections()
+	s.httpServer = httptest.NewServer(s.routes())
+	s.URL = s.httpServer.URL
+	return s
+}
+
+// Close shuts down the server and blocks until all outstanding requests on
+// it have completed.
+func (s *Server) Close() {
+	s.httpServer.Close()
+}
+
+// Client returns a client for the server, authenticated with its token.
+func (s *Server) Client() *contextforge.Client {
+	s.mu.Lock()
+	token := s.token
+	s.mu.Unlock()
+
+	client, err := contextforge.NewClientWithOptions(s.URL,
+		contextforge.WithHTTPClient(s.httpServer.Client()),
+		contextforge.WithBearerToken(token))
+	if err != nil {
+		panic(fmt.Sprintf("contextforgetest: create client: %v", err))
+	}
+	return client
+}
+
+// SetToken changes the bearer token the server requires. An empty token
+// disables authentication.
+func (s *Server) SetToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+}
+
+// SetPageSize changes the number of items list endpoints return when the
+// request sets no limit. Zero returns every item in one page.
+func (s *Server) SetPageSize(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pageSize = n
+}
+
+// routes returns the handler serving the fake API.
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /auth/login", s.login)
+
+	s.handleTools(mux)
+	s.handleResources(mux)
+	s.handleGateways(mux)
+	s.handleServers(mux)
+	s.handlePrompts(mux)
+	s.handleAgents(mux)
+	s.handleTeams(mux)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/auth/login" && !s.authorized(r) {
+			writeError(w, unauthorized())
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token == "" || r.Header.Get("Authorization") == "Bearer "+s.token
+}
+
+// login accepts any username and password and issues the server's token.
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Username string `json:"username"`
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, invalid("body", "invalid JSON: %v", err))
+		return
+	}
+	if (body.Username == "" && body.Email == "") || body.Password == "" {
+		writeError(w, &apiError{status: http.StatusUnauthorized, message: "Invalid email or password"})
+		return
+	}
+
+	s.mu.Lock()
+	token := s.token
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": token,
+		"token_type":   "bearer",
+		"expires_in":   3600,
+	})
+}
+
+// handle registers a handler that runs with the server locked and writes
+// the value or error it returns.
+func (s *Server) handle(mux *http.ServeMux, pattern string, status int, h func(r *http.Request) (any, *apiError)) {
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		v, err := h(r)
+		// Encode while locked: v may point into the server's state.
+		var data []byte
+		if err == nil {
+			data, _ = json.Marshal(v)
+		}
+		s.mu.Unlock()
+
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write(append(data, '\n'))
+	})
+}
+
+// readBody decodes the JSON request body into v.
+func readBody(r *http.Request, v any) *apiError {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return invalid("body", "read body: %v", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return invalid("body", "invalid JSON: %v", err)
+	}
+	return nil
+}
+
+// apiError is an error response. It is written with both the message and
+// errors fields decoded into contextforge.ErrorResponse and the detail field
+// the ContextForge API uses.
+type apiError struct {
+	status  int
+	message string
+	field   string
+}
+
+func notFound(format string, args ...any) *apiError {
+	return &apiError{status: http.StatusNotFound, message: fmt.Sprintf(format, args...)}
+}
+
+func conflict(format string, args ...any) *apiError {
+	return &apiError{status: http.StatusConflict, message: fmt.Sprintf(format, args...)}
+}
+
+func invalid(field, format string, args ...any) *apiError {
+	return &apiError{status: http.StatusUnprocessableEntity, message: fmt.Sprintf(format, args...), field: field}
+}
+
+func unauthorized() *apiError {
+	return &apiError{status: http.StatusUnauthorized, message: "Not authenticated"}
+}
+
+func writeError(w http.ResponseWriter, err *apiError) {
+	body := map[string]any{"message": err.message}
+	if err.status == http.StatusUnprocessableEntity {
+		body["errors"] = []contextforge.Error{{Field: err.field, Code: "invalid", Message: err.message}}
+		body["detail"] = []map[string]any{{"loc": []string{"body", err.field}, "msg": err.message, "type": "value_error"}}
+	} else {
+		body["detail"] = err.message
+	}
+	writeJSON(w, err.status, body)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// now returns the current time, truncated to the precision the API reports.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+func required(field, value string) *apiError {
+	if strings.TrimSpace(value) == "" {
+		return invalid(field, "%s is required", field)
+	}
+	return nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,370 @@
+package contextforgetest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+func setup(t *testing.T) (*Server, *contextforge.Client) {
+	t.Helper()
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+	return srv, srv.Client()
+}
+
+// wantStatus fails the test unless err is an API error with the given status.
+func wantStatus(t *testing.T, err error, status int) {
+	t.Helper()
+	var errResp *contextforge.ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("error = %v, want an API error with status %d", err, status)
+	}
+	if got := errResp.Response.StatusCode; got != status {
+		t.Errorf("status = %d, want %d (%v)", got, status, err)
+	}
+}
+
+func TestTools_CRUD(t *testing.T) {
+	ctx := context.Background()
+	_, client := setup(t)
+
+	created, _, err := client.Tools.Create(ctx, &contextforge.Tool{
+		Name:        "search",
+		Description: contextforge.String("Search docs"),
+		Tags:        contextforge.NewTags([]string{"docs"}),
+	}, &contextforge.ToolCreateOptions{TeamID: contextforge.String("team-1")})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if created.ID == "" || !created.Enabled || created.Visibility != "public" || deref(created.TeamID) != "team-1" {
+		t.Errorf("Create returned %+v, want an enabled public tool of team-1 with an ID", created)
+	}
+
+	got, _, err := client.Tools.Get(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if got.Name != "search" || deref(got.Description) != "Search docs" {
+		t.Errorf("Get returned %+v", got)
+	}
+
+	got.Description = contextforge.String("Search the docs")
+	updated, _, err := client.Tools.Update(ctx, created.ID, got)
+	if err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	if deref(updated.Description) != "Search the docs" || *updated.Version != 2 {
+		t.Errorf("Update returned description %q, version %d", deref(updated.Description), *updated.Version)
+	}
+
+	if _, err := client.Tools.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	_, _, err = client.Tools.Get(ctx, created.ID)
+	wantStatus(t, err, http.StatusNotFound)
+}
+
+func TestTools_State(t *testing.T) {
+	ctx := context.Background()
+	srv, client := setup(t)
+	srv.AddTool(&contextforge.Tool{ID: "t1", Name: "search", Enabled: true})
+
+	tool, _, err := client.Tools.SetState(ctx, "t1", false)
+	if err != nil {
+		t.Fatalf("SetState returned error: %v", err)
+	}
+	if tool.Enabled {
+		t.Error("SetState(false) returned an enabled tool")
+	}
+
+	tools, _, err := client.Tools.List(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tools) != 0 {
+		t.Errorf("List returned %d tools, want inactive tools omitted", len(tools))
+	}
+
+	tool, _, err = client.Tools.Toggle(ctx, "t1", true)
+	if err != nil {
+		t.Fatalf("Toggle returned error: %v", err)
+	}
+	if !tool.Enabled {
+		t.Error("Toggle(true) returned a disabled tool")
+	}
+}
+
+func TestTools_Filters(t *testing.T) {
+	ctx := context.Background()
+	srv, client := setup(t)
+	srv.AddTool(&contextforge.Tool{Name: "a", Enabled: true, Tags: contextforge.NewTags([]string{"docs"}), Visibility: "public"})
+	srv.AddTool(&contextforge.Tool{Name: "b", Enabled: true, TeamID: contextforge.String("team-1"), Visibility: "team"})
+	srv.AddTool(&contextforge.Tool{Name: "c", Enabled: false, Tags: contextforge.NewTags([]string{"docs", "web"})})
+
+	tests := []struct {
+		name string
+		opts *contextforge.ToolListOptions
+		want []string
+	}{
+		{"default", nil, []string{"a", "b"}},
+		{"include inactive", &contextforge.ToolListOptions{IncludeInactive: true}, []string{"a", "b", "c"}},
+		{"tags", &contextforge.ToolListOptions{Tags: "web,docs", IncludeInactive: true}, []string{"a", "c"}},
+		{"team", &contextforge.ToolListOptions{TeamID: "team-1"}, []string{"b"}},
+		{"visibility", &contextforge.ToolListOptions{Visibility: "public"}, []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tools, _, err := client.Tools.List(ctx, tt.opts)
+			if err != nil {
+				t.Fatalf("List returned error: %v", err)
+			}
+			var names []string
+			for _, tool := range tools {
+				names = append(names, tool.Name)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("List returned %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestPagination(t *testing.T) {
+	ctx := context.Background()
+	srv, client := setup(t)
+	srv.SetPageSize(2)
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		srv.AddPrompt(&contextforge.Prompt{Name: name, Template: "Hi", IsActive: true})
+		srv.AddTeam(&contextforge.Team{Name: name, IsActive: true})
+	}
+
+	prompts, resp, err := client.Prompts.List(ctx, nil)
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(prompts) != 2 || resp.NextCursor == "" {
+		t.Errorf("List returned %d prompts, next cursor %q; want a page of 2 and a cursor", len(prompts), resp.NextCursor)
+	}
+
+	var names []string
+	for p, err := range client.Prompts.All(ctx, nil) {
+		if err != nil {
+			t.Fatalf("All returned error: %v", err)
+		}
+		names = append(names, p.Name)
+	}
+	if want := []string{"a", "b", "c", "d", "e"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("All prompts = %v, want %v", names, want)
+	}
+
+	teams, resp, err := client.Teams.List(ctx, &contextforge.TeamListOptions{Skip: 4})
+	if err != nil {
+		t.Fatalf("Teams.List returned error: %v", err)
+	}
+	if len(teams) != 1 || teams[0].Name != "e" || resp.Total != 5 {
+		t.Errorf("Teams.List returned %d teams of %d total", len(teams), resp.Total)
+	}
+	count := 0
+	for _, err := range client.Teams.All(ctx, nil) {
+		if err != nil {
+			t.Fatalf("Teams.All returned error: %v", err)
+		}
+		count++
+	}
+	if count != 5 {
+		t.Errorf("Teams.All yielded %d teams, want 5", count)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	ctx := context.Background()
+	srv, client := setup(t)
+	srv.AddGateway(&contextforge.Gateway{Name: "weather", URL: "http://weather.internal"})
+
+	tests := []struct {
+		name   string
+		call   func() error
+		status int
+	}{
+		{"not found", func() error {
+			_, _, err := client.Servers.Get(ctx, "missing")
+			return err
+		}, http.StatusNotFound},
+		{"duplicate name", func() error {
+			_, _, err := client.Gateways.Create(ctx, &contextforge.Gateway{Name: "weather", URL: "http://other.internal"}, nil)
+			return err
+		}, http.StatusConflict},
+		{"missing field", func() error {
+			_, _, err := client.Agents.Create(ctx, &contextforge.AgentCreate{Name: "helper"}, nil)
+			return err
+		}, http.StatusUnprocessableEntity},
+		{"missing prompt argument", func() error {
+			srv.AddPrompt(&contextforge.Prompt{ID: "p1", Name: "greet", Template: "Hi {{ name }}",
+				Arguments: []contextforge.PromptArgument{{Name: "name", Required: true}}, IsActive: true})
+			_, _, err := client.Prompts.Get(ctx, "p1", nil)
+			return err
+		}, http.StatusUnprocessableEntity},
+		{"wrong token", func() error {
+			c, err := contextforge.NewClient(nil, srv.URL, "wrong")
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _, err = c.Tools.List(ctx, nil)
+			return err
+		}, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wantStatus(t, tt.call(), tt.status)
+		})
+	}
+}
+
+func TestResources(t *testing.T) {
+	ctx := context.Background()
+	_, client := setup(t)
+
+	created, _, err := client.Resources.Create(ctx, &contextforge.ResourceCreate{
+		URI: "file:///readme", Name: "readme", Content: "# Readme", MimeType: contextforge.String("text/markdown"),
+	}, nil)
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	id := created.ID.String()
+
+	content, _, err := client.Resources.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if deref(content.Text) != "# Readme" || content.URI != "file:///readme" {
+		t.Errorf("Get returned %+v", content)
+	}
+
+	info, _, err := client.Resources.GetInfo(ctx, id, nil)
+	if err != nil {
+		t.Fatalf("GetInfo returned error: %v", err)
+	}
+	if info.Name != "readme" {
+		t.Errorf("GetInfo returned name %q", info.Name)
+	}
+
+	res, _, err := client.Resources.SetState(ctx, id, false)
+	if err != nil {
+		t.Fatalf("SetState returned error: %v", err)
+	}
+	if res.IsActive {
+		t.Error("SetState(false) returned an active resource")
+	}
+}
+
+func TestServers_Associations(t *testing.T) {
+	ctx := context.Background()
+	srv, client := setup(t)
+	search := srv.AddTool(&contextforge.Tool{Name: "search", Enabled: true})
+	fetch := srv.AddTool(&contextforge.Tool{Name: "fetch", Enabled: false})
+
+	server, _, err := client.Servers.Create(ctx, &contextforge.ServerCreate{
+		Name: "docs", AssociatedTools: []string{search.ID, fetch.ID},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	tools, _, err := client.Servers.ListTools(ctx, server.ID, nil)
+	if err != nil {
+		t.Fatalf("ListTools returned error: %v", err)
+	}
+	if len(tools) != 1 || tools[0].Name != "search" {
+		t.Errorf("ListTools returned %d tools, want only the active one", len(tools))
+	}
+}
+
+func TestPrompts_Render(t *testing.T) {
+	ctx := context.Background()
+	_, client := setup(t)
+
+	prompt, _, err := client.Prompts.Create(ctx, &contextforge.PromptCreate{
+		Name: "greet", Template: "Hello, {{ name }}!",
+	}, nil)
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if len(prompt.Arguments) != 1 || prompt.Arguments[0].Name != "name" {
+		t.Errorf("Create returned arguments %+v, want them derived from the template", prompt.Arguments)
+	}
+
+	result, _, err := client.Prompts.Get(ctx, prompt.ID, map[string]string{"name": "Ada"})
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if text := deref(result.Messages[0].Content.Text); text != "Hello, Ada!" {
+		t.Errorf("Get rendered %q, want %q", text, "Hello, Ada!")
+	}
+}
+
+func TestTeams_Members(t *testing.T) {
+	ctx := context.Background()
+	_, client := setup(t)
+
+	team, _, err := client.Teams.Create(ctx, &contextforge.TeamCreate{Name: "Platform Team"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if team.Slug != "platform-team" || team.MemberCount != 1 {
+		t.Errorf("Create returned slug %q, %d members", team.Slug, team.MemberCount)
+	}
+
+	invite, _, err := client.Teams.InviteMember(ctx, team.ID, &contextforge.TeamInvite{Email: "dev@example.com"})
+	if err != nil {
+		t.Fatalf("InviteMember returned error: %v", err)
+	}
+	if _, _, err := client.Teams.AcceptInvitation(ctx, invite.Token); err != nil {
+		t.Fatalf("AcceptInvitation returned error: %v", err)
+	}
+	member, _, err := client.Teams.UpdateMember(ctx, team.ID, "dev@example.com", &contextforge.TeamMemberUpdate{Role: "owner"})
+	if err != nil {
+		t.Fatalf("UpdateMember returned error: %v", err)
+	}
+	if member.Role != "owner" {
+		t.Errorf("UpdateMember returned role %q", member.Role)
+	}
+
+	members, _, err := client.Teams.ListMembers(ctx, team.ID)
+	if err != nil {
+		t.Fatalf("ListMembers returned error: %v", err)
+	}
+	if len(members) != 2 {
+		t.Errorf("ListMembers returned %d members, want 2", len(members))
+	}
+	invites, _, err := client.Teams.ListInvitations(ctx, team.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invites) != 0 {
+		t.Errorf("ListInvitations returned %d invitations after acceptance, want 0", len(invites))
+	}
+}
+
+func TestLogin(t *testing.T) {
+	srv, _ := setup(t)
+	srv.SetToken("issued-token")
+
+	ts, err := contextforge.NewPasswordTokenSource(nil, srv.URL, "admin@example.com", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := contextforge.NewClientWithOptions(srv.URL, contextforge.WithTokenSource(ts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Agents.List(context.Background(), nil); err != nil {
+		t.Errorf("List with a password token source returned error: %v", err)
+	}
+}
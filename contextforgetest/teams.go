@@ -0,0 +1,240 @@
+package contextforgetest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+// invitationTTL is how long team invitations stay valid.
+const invitationTTL = 7 * 24 * time.Hour
+
+func (s *Server) handleTeams(mux *http.ServeMux) {
+	// Teams use skip/limit pagination and report the total instead of a
+	// cursor. Every team is listed, whatever its state.
+	s.handle(mux, "GET /teams", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		q := r.URL.Query()
+		q.Set("include_inactive", "true")
+		teams := s.teams.filter(q)
+		q.Del("cursor")
+		items, _, err := page(teams, q, s.pageSize)
+		if err != nil {
+			return nil, err
+		}
+		if items == nil {
+			items = []*contextforge.Team{}
+		}
+		return &contextforge.TeamListResponse{Teams: items, Total: len(teams)}, nil
+	})
+
+	s.handle(mux, "POST /teams", http.StatusCreated, func(r *http.Request) (any, *apiError) {
+		var tc contextforge.TeamCreate
+		if err := readBody(r, &tc); err != nil {
+			return nil, err
+		}
+		if err := required("name", tc.Name); err != nil {
+			return nil, err
+		}
+		team := &contextforge.Team{
+			Name:        tc.Name,
+			Slug:        slugify(tc.Name),
+			Description: tc.Description,
+			Visibility:  tc.Visibility,
+			MaxMembers:  tc.MaxMembers,
+			IsActive:    true,
+			CreatedBy:   s.email,
+		}
+		if tc.Slug != nil {
+			team.Slug = *tc.Slug
+		}
+		if team.Visibility == nil {
+			team.Visibility = contextforge.String("private")
+		}
+		if err := s.teams.insert(team); err != nil {
+			return nil, err
+		}
+		s.addMember(team, s.email, "owner", nil)
+		return team, nil
+	})
+
+	s.handle(mux, "GET /teams/{id}/{$}", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		return s.teams.get(r.PathValue("id"))
+	})
+
+	s.handle(mux, "PUT /teams/{id}/{$}", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		body, err := readRaw(r)
+		if err != nil {
+			return nil, err
+		}
+		return s.teams.update(r.PathValue("id"), body)
+	})
+
+	s.handle(mux, "DELETE /teams/{id}/{$}", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		id := r.PathValue("id")
+		if err := s.teams.delete(id); err != nil {
+			return nil, err
+		}
+		delete(s.members, id)
+		delete(s.invites, id)
+		return map[string]any{"message": fmt.Sprintf("Team %s deleted successfully", id), "success": true}, nil
+	})
+
+	s.handle(mux, "GET /teams/{id}/members/{$}", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		id := r.PathValue("id")
+		if _, err := s.teams.get(id); err != nil {
+			return nil, err
+		}
+		members := s.members[id]
+		if members == nil {
+			members = []*contextforge.TeamMember{}
+		}
+		return members, nil
+	})
+
+	s.handle(mux, "PUT /teams/{id}/members/{email}/{$}", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		var update contextforge.TeamMemberUpdate
+		if err := readBody(r, &update); err != nil {
+			return nil, err
+		}
+		if err := required("role", update.Role); err != nil {
+			return nil, err
+		}
+		member, err := s.member(r.PathValue("id"), r.PathValue("email"))
+		if err != nil {
+			return nil, err
+		}
+		member.Role = update.Role
+		return member, nil
+	})
+
+	s.handle(mux, "DELETE /teams/{id}/members/{email}/{$}", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		id, email := r.PathValue("id"), r.PathValue("email")
+		if _, err := s.member(id, email); err != nil {
+			return nil, err
+		}
+		s.members[id] = slices.DeleteFunc(s.members[id], func(m *contextforge.TeamMember) bool { return m.UserEmail == email })
+		s.teams.items[id].MemberCount = len(s.members[id])
+		return map[string]any{"message": "Team member removed successfully", "success": true}, nil
+	})
+
+	s.handle(mux, "POST /teams/{id}/invitations/{$}", http.StatusCreated, func(r *http.Request) (any, *apiError) {
+		var invite contextforge.TeamInvite
+		if err := readBody(r, &invite); err != nil {
+			return nil, err
+		}
+		if err := required("email", invite.Email); err != nil {
+			return nil, err
+		}
+		team, err := s.teams.get(r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.member(team.ID, invite.Email); err == nil {
+			return nil, conflict("User %s is already a member of team %s", invite.Email, team.ID)
+		}
+		role := "member"
+		if invite.Role != nil {
+			role = *invite.Role
+		}
+		s.inviteSeq++
+		at := now()
+		inv := &contextforge.TeamInvitation{
+			ID:        fmt.Sprintf("%032x", s.inviteSeq),
+			TeamID:    team.ID,
+			TeamName:  team.Name,
+			Email:     invite.Email,
+			Role:      role,
+			InvitedBy: s.email,
+			InvitedAt: &contextforge.Timestamp{Time: at},
+			ExpiresAt: &contextforge.Timestamp{Time: at.Add(invitationTTL)},
+			Token:     fmt.Sprintf("invite-%d", s.inviteSeq),
+			IsActive:  true,
+		}
+		s.invites[team.ID] = append(s.invites[team.ID], inv)
+		return inv, nil
+	})
+
+	s.handle(mux, "GET /teams/{id}/invitations/{$}", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		id := r.PathValue("id")
+		if _, err := s.teams.get(id); err != nil {
+			return nil, err
+		}
+		invites := []*contextforge.TeamInvitation{}
+		for _, inv := range s.invites[id] {
+			if inv.IsActive {
+				invites = append(invites, inv)
+			}
+		}
+		return invites, nil
+	})
+
+	s.handle(mux, "POST /teams/invitations/{token}/accept/{$}", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		token := r.PathValue("token")
+		inv := s.invitation(func(inv *contextforge.TeamInvitation) bool { return inv.Token == token })
+		if inv == nil || !inv.IsActive {
+			return nil, notFound("Invitation not found: %s", token)
+		}
+		inv.IsActive = false
+		return s.addMember(s.teams.items[inv.TeamID], inv.Email, inv.Role, &inv.InvitedBy), nil
+	})
+
+	s.handle(mux, "DELETE /teams/invitations/{id}/{$}", http.StatusOK, func(r *http.Request) (any, *apiError) {
+		id := r.PathValue("id")
+		inv := s.invitation(func(inv *contextforge.TeamInvitation) bool { return inv.ID == id })
+		if inv == nil {
+			return nil, notFound("Invitation not found: %s", id)
+		}
+		s.invites[inv.TeamID] = slices.DeleteFunc(s.invites[inv.TeamID], func(v *contextforge.TeamInvitation) bool { return v == inv })
+		return map[string]any{"message": "Invitation cancelled successfully", "success": true}, nil
+	})
+}
+
+// addMember adds a member with the given email and role to team.
+func (s *Server) addMember(team *contextforge.Team, email, role string, invitedBy *string) *contextforge.TeamMember {
+	s.memberSeq++
+	member := &contextforge.TeamMember{
+		ID:        fmt.Sprintf("%032x", s.memberSeq),
+		TeamID:    team.ID,
+		UserEmail: email,
+		Role:      role,
+		JoinedAt:  &contextforge.Timestamp{Time: now()},
+		InvitedBy: invitedBy,
+		IsActive:  true,
+	}
+	s.members[team.ID] = append(s.members[team.ID], member)
+	team.MemberCount = len(s.members[team.ID])
+	return member
+}
+
+// member returns the member of the team with the given email. The email
+// may still be path-escaped.
+func (s *Server) member(teamID, email string) (*contextforge.TeamMember, *apiError) {
+	if _, err := s.teams.get(teamID); err != nil {
+		return nil, err
+	}
+	if unescaped, err := url.PathUnescape(email); err == nil {
+		email = unescaped
+	}
+	for _, m := range s.members[teamID] {
+		if m.UserEmail == email {
+			return m, nil
+		}
+	}
+	return nil, notFound("Team member not found: %s", email)
+}
+
+// invitation returns the first invitation of any team matching match.
+func (s *Server) invitation(match func(*contextforge.TeamInvitation) bool) *contextforge.TeamInvitation {
+	for _, invites := range s.invites {
+		for _, inv := range invites {
+			if match(inv) {
+				return inv
+			}
+		}
+	}
+	return nil
+}
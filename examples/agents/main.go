@@ -223,21 +223,22 @@ func main() {
 		InteractionType: "query",
 	}
 
-	result, _, err := client.Agents.Invoke(ctx, createdAgent1.Name, invokeReq)
+	// The mock agent replies with a custom (non-A2A) shape, so decode it
+	// with InvokeInto rather than reading the typed A2A fields of Invoke.
+	type mockInvokeResult struct {
+		Status        string         `json:"status"`
+		Result        map[string]any `json:"result"`
+		ExecutionTime int            `json:"execution_time"`
+	}
+	result, _, err := contextforge.InvokeInto[mockInvokeResult](ctx, client.Agents, createdAgent1.Name, invokeReq)
 	if err != nil {
 		// In this mock example, invoke might succeed or fail depending on mock implementation
 		fmt.Printf("   ⚠ Invoke returned error (expected in mock): %v\n", err)
 	} else {
 		fmt.Printf("   ✓ Invoke succeeded with result:\n")
-		if status, ok := result["status"]; ok {
-			fmt.Printf("      Status: %v\n", status)
-		}
-		if data, ok := result["result"]; ok {
-			fmt.Printf("      Result: %v\n", data)
-		}
-		if execTime, ok := result["execution_time"]; ok {
-			fmt.Printf("      Execution time: %v ms\n", execTime)
-		}
+		fmt.Printf("      Status: %v\n", result.Status)
+		fmt.Printf("      Result: %v\n", result.Result)
+		fmt.Printf("      Execution time: %v ms\n", result.ExecutionTime)
 	}
 	fmt.Println()
 
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/leefowlercu/go-contextforge/contextforge"
+	"github.com/leefowlercu/go-contextforge/jsonschema"
 )
 
 func main() {
@@ -48,18 +49,11 @@ func main() {
 	newTool := &contextforge.Tool{
 		Name:        "example-calculator",
 		Description: contextforge.String("A simple calculator tool for demonstrations"),
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"operation": map[string]any{
-					"type": "string",
-					"enum": []string{"add", "subtract", "multiply", "divide"},
-				},
-				"a": map[string]any{"type": "number"},
-				"b": map[string]any{"type": "number"},
-			},
-			"required": []string{"operation", "a", "b"},
-		},
+		InputSchema: jsonschema.Object().
+			RequiredProperty("operation", jsonschema.String().Enum("add", "subtract", "multiply", "divide")).
+			RequiredProperty("a", jsonschema.Number()).
+			RequiredProperty("b", jsonschema.Number()).
+			Map(),
 		Tags:       contextforge.NewTags([]string{"math", "calculator", "example"}),
 		Visibility: "public",
 	}
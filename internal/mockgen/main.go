@@ -0,0 +1,395 @@
+// Command mockgen generates the mocks of the contextforgemock package from
+// the service interfaces declared in a source file of the contextforge
+// package. For each interface it writes a struct of the same name that
+// embeds contextforgemock.Mock, with a <Method>Func field and a recording
+// implementation for every method. Methods returning an iterator return an
+// empty one rather than nil when neither the Func field nor Return is set.
+//
+// Usage:
+//
+//	go run ./internal/mockgen -o contextforgemock/mocks.go contextforge/interfaces.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// sourcePath is the import path of the package declaring the interfaces.
+const sourcePath = "github.com/leefowlercu/go-contextforge/contextforge"
+
+func main() {
+	out := flag.String("o", "", "write the mocks to `file` instead of standard output")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: mockgen [-o file] source.go\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	src, err := generate(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "mockgen: %v\n", err)
+		os.Exit(1)
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(src)
+	} else {
+		err = os.WriteFile(*out, src, 0o644)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "mockgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// generate returns the formatted source of the mocks of the interfaces
+// declared in the named file.
+func generate(filename string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("parse source; %w", err)
+	}
+
+	g := &generator{
+		pkg:     file.Name.Name,
+		imports: map[string]string{},
+		used:    map[string]bool{file.Name.Name: true},
+	}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		g.imports[name] = path
+	}
+
+	var names []string
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			iface, ok := ts.Type.(*ast.InterfaceType)
+			if !ok || !ts.Name.IsExported() {
+				continue
+			}
+			if err := g.mock(ts.Name.Name, iface); err != nil {
+				return nil, fmt.Errorf("interface %s; %w", ts.Name.Name, err)
+			}
+			names = append(names, ts.Name.Name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no exported interfaces in %s", filename)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by mockgen from %s; DO NOT EDIT.\n\n", fileBase(filename))
+	fmt.Fprintf(&buf, "package %smock\n\n", g.pkg)
+	buf.WriteString("import (\n")
+	var paths []string
+	for name := range g.used {
+		if name == g.pkg {
+			paths = append(paths, sourcePath)
+		} else {
+			paths = append(paths, g.imports[name])
+		}
+	}
+	// Standard library imports come first, in a group of their own.
+	slices.SortFunc(paths, func(a, b string) int {
+		if sa, sb := isStd(a), isStd(b); sa != sb {
+			if sa {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	for i, path := range paths {
+		if i > 0 && isStd(paths[i-1]) && !isStd(path) {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "\t%q\n", path)
+	}
+	buf.WriteString(")\n\n")
+	buf.WriteString("var (\n")
+	for _, name := range names {
+		fmt.Fprintf(&buf, "\t_ %s.%s = (*%s)(nil)\n", g.pkg, name, name)
+	}
+	buf.WriteString(")\n")
+	buf.Write(g.body.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format output; %w", err)
+	}
+	return src, nil
+}
+
+func isStd(path string) bool {
+	return !strings.Contains(strings.SplitN(path, "/", 2)[0], ".")
+}
+
+func fileBase(filename string) string {
+	return filename[strings.LastIndexAny(filename, `/\`)+1:]
+}
+
+type generator struct {
+	pkg     string            // name of the source package
+	imports map[string]string // import path by package name, from the source file
+	used    map[string]bool   // packages referenced by the output
+	body    bytes.Buffer
+}
+
+// param is a parameter or result of a method.
+type param struct {
+	name  string
+	typ   string
+	empty string // value returned instead of nil, for iterators
+}
+
+// mock writes the mock of one interface.
+func (g *generator) mock(name string, iface *ast.InterfaceType) error {
+	type method struct {
+		name            string
+		params, results []param
+		ctx             map[string]bool // names of the context.Context params
+	}
+
+	var methods []method
+	for _, field := range iface.Methods.List {
+		fn, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) != 1 {
+			return fmt.Errorf("embedded interfaces are not supported")
+		}
+		m := method{name: field.Names[0].Name, ctx: map[string]bool{}}
+		var err error
+		if m.params, err = g.fields(fn.Params, "p"); err != nil {
+			return fmt.Errorf("method %s; %w", m.name, err)
+		}
+		if m.results, err = g.fields(fn.Results, "r"); err != nil {
+			return fmt.Errorf("method %s; %w", m.name, err)
+		}
+		for i := range m.results {
+			// Results are named so that they hold zero values unless a
+			// canned response replaces them.
+			m.results[i].name = "r" + strconv.Itoa(i)
+		}
+		for _, p := range m.params {
+			if p.name == "m" {
+				return fmt.Errorf("method %s; parameter name m collides with the receiver", m.name)
+			}
+			if p.typ == "context.Context" {
+				m.ctx[p.name] = true
+			}
+		}
+		methods = append(methods, m)
+	}
+
+	b := &g.body
+	fmt.Fprintf(b, "\n// %s is a mock of %s.%s.\n", name, g.pkg, name)
+	fmt.Fprintf(b, "type %s struct {\n\tMock\n", name)
+	for _, m := range methods {
+		fmt.Fprintf(b, "\n\t// %sFunc, if set, implements %s.\n", m.name, m.name)
+		fmt.Fprintf(b, "\t%sFunc func(%s) %s\n", m.name, joinParams(m.params, true), resultList(m.results, false))
+	}
+	b.WriteString("}\n")
+
+	for _, m := range methods {
+		var args, recorded []string
+		for _, p := range m.params {
+			args = append(args, p.name)
+			if !m.ctx[p.name] {
+				recorded = append(recorded, p.name)
+			}
+		}
+		var ptrs []string
+		for _, r := range m.results {
+			ptrs = append(ptrs, "&"+r.name)
+		}
+
+		fmt.Fprintf(b, "\n// %s records the call and returns the results of %sFunc, if set, or\n", m.name, m.name)
+		fmt.Fprintf(b, "// the results set with Return(%q).\n", m.name)
+		fmt.Fprintf(b, "func (m *%s) %s(%s) %s {\n", name, m.name, joinParams(m.params, true), resultList(m.results, true))
+		fmt.Fprintf(b, "\tm.record(%s)\n", strings.Join(append([]string{strconv.Quote(m.name)}, recorded...), ", "))
+		fmt.Fprintf(b, "\tif m.%sFunc != nil {\n\t\treturn m.%sFunc(%s)\n\t}\n", m.name, m.name, strings.Join(args, ", "))
+		fmt.Fprintf(b, "\tm.results(%s)\n", strings.Join(append([]string{strconv.Quote(m.name)}, ptrs...), ", "))
+		for _, r := range m.results {
+			if r.empty != "" {
+				fmt.Fprintf(b, "\tif %s == nil {\n\t\t%s = %s\n\t}\n", r.name, r.name, r.empty)
+			}
+		}
+		b.WriteString("\treturn\n}\n")
+	}
+	return nil
+}
+
+// fields returns the parameters declared by list, naming unnamed ones with
+// prefix and their index.
+func (g *generator) fields(list *ast.FieldList, prefix string) ([]param, error) {
+	if list == nil {
+		return nil, nil
+	}
+	var params []param
+	for _, field := range list.List {
+		typ, err := g.typeString(field.Type)
+		if err != nil {
+			return nil, err
+		}
+		empty, err := g.emptyValue(field.Type)
+		if err != nil {
+			return nil, err
+		}
+		if len(field.Names) == 0 {
+			params = append(params, param{name: prefix + strconv.Itoa(len(params)), typ: typ, empty: empty})
+		}
+		for _, n := range field.Names {
+			params = append(params, param{name: n.Name, typ: typ, empty: empty})
+		}
+	}
+	return params, nil
+}
+
+// emptyValue returns an empty iter.Seq or iter.Seq2 if expr is one of these
+// types, whose nil value panics when ranged over, and "" otherwise.
+func (g *generator) emptyValue(expr ast.Expr) (string, error) {
+	var x ast.Expr
+	var indices []ast.Expr
+	switch e := expr.(type) {
+	case *ast.IndexExpr:
+		x, indices = e.X, []ast.Expr{e.Index}
+	case *ast.IndexListExpr:
+		x, indices = e.X, e.Indices
+	default:
+		return "", nil
+	}
+	sel, ok := x.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Seq" && sel.Sel.Name != "Seq2" {
+		return "", nil
+	}
+	if pkg, ok := sel.X.(*ast.Ident); !ok || g.imports[pkg.Name] != "iter" {
+		return "", nil
+	}
+
+	var args []string
+	for _, index := range indices {
+		arg, err := g.typeString(index)
+		if err != nil {
+			return "", err
+		}
+		args = append(args, arg)
+	}
+	return "func(func(" + strings.Join(args, ", ") + ") bool) {}", nil
+}
+
+// predeclared holds the predeclared type identifiers, which are not
+// qualified with the source package.
+var predeclared = map[string]bool{
+	"any": true, "bool": true, "byte": true, "error": true, "float32": true, "float64": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true, "rune": true, "string": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+}
+
+// typeString renders a type expression of the source package as it must
+// be written in the mock package, recording the packages it references.
+func (g *generator) typeString(expr ast.Expr) (string, error) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if predeclared[e.Name] {
+			return e.Name, nil
+		}
+		g.used[g.pkg] = true
+		return g.pkg + "." + e.Name, nil
+	case *ast.SelectorExpr:
+		x, ok := e.X.(*ast.Ident)
+		if !ok || g.imports[x.Name] == "" {
+			return "", fmt.Errorf("unknown package in %s", exprString(e))
+		}
+		g.used[x.Name] = true
+		return x.Name + "." + e.Sel.Name, nil
+	case *ast.StarExpr:
+		s, err := g.typeString(e.X)
+		return "*" + s, err
+	case *ast.ArrayType:
+		if e.Len != nil {
+			return "", fmt.Errorf("arrays are not supported")
+		}
+		s, err := g.typeString(e.Elt)
+		return "[]" + s, err
+	case *ast.MapType:
+		k, err := g.typeString(e.Key)
+		if err != nil {
+			return "", err
+		}
+		v, err := g.typeString(e.Value)
+		return "map[" + k + "]" + v, err
+	case *ast.Ellipsis:
+		s, err := g.typeString(e.Elt)
+		return "..." + s, err
+	case *ast.IndexExpr:
+		return g.generic(e.X, []ast.Expr{e.Index})
+	case *ast.IndexListExpr:
+		return g.generic(e.X, e.Indices)
+	}
+	return "", fmt.Errorf("unsupported type %s", exprString(expr))
+}
+
+func (g *generator) generic(x ast.Expr, indices []ast.Expr) (string, error) {
+	s, err := g.typeString(x)
+	if err != nil {
+		return "", err
+	}
+	var args []string
+	for _, index := range indices {
+		arg, err := g.typeString(index)
+		if err != nil {
+			return "", err
+		}
+		args = append(args, arg)
+	}
+	return s + "[" + strings.Join(args, ", ") + "]", nil
+}
+
+// exprString renders expr for error messages.
+func exprString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	_ = format.Node(&buf, token.NewFileSet(), expr)
+	return buf.String()
+}
+
+func joinParams(params []param, named bool) string {
+	var parts []string
+	for _, p := range params {
+		if named {
+			parts = append(parts, p.name+" "+p.typ)
+		} else {
+			parts = append(parts, p.typ)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func resultList(results []param, named bool) string {
+	s := joinParams(results, named)
+	if len(results) > 1 || named && len(results) == 1 {
+		return "(" + s + ")"
+	}
+	return s
+}
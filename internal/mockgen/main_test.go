@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate_UpToDate(t *testing.T) {
+	got, err := generate(filepath.Join("..", "..", "contextforge", "interfaces.go"))
+	if err != nil {
+		t.Fatalf("generate returned error: %v", err)
+	}
+	want, err := os.ReadFile(filepath.Join("..", "..", "contextforgemock", "mocks.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("contextforgemock/mocks.go is out of date; run go generate ./contextforgemock")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	src := `package contextforge
+
+import (
+	"context"
+	"iter"
+)
+
+type ThingsAPI interface {
+	List(ctx context.Context, opts *ListOptions) ([]*Thing, *Response, error)
+	All(context.Context) iter.Seq2[*Thing, error]
+	Rename(ctx context.Context, from, to string) error
+}
+
+type notExported interface {
+	Skip()
+}
+`
+	name := filepath.Join(t.TempDir(), "things.go")
+	if err := os.WriteFile(name, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := generate(name)
+	if err != nil {
+		t.Fatalf("generate returned error: %v", err)
+	}
+	for _, want := range []string{
+		"package contextforgemock",
+		"_ contextforge.ThingsAPI = (*ThingsAPI)(nil)",
+		"ListFunc func(ctx context.Context, opts *contextforge.ListOptions) ([]*contextforge.Thing, *contextforge.Response, error)",
+		"func (m *ThingsAPI) All(p0 context.Context) (r0 iter.Seq2[*contextforge.Thing, error]) {",
+		`m.record("Rename", from, to)`,
+		`m.results("Rename", &r0)`,
+		"if r0 == nil {\n\t\tr0 = func(func(*contextforge.Thing, error) bool) {}\n\t}",
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(string(out), "notExported") {
+		t.Error("output contains a mock of an unexported interface")
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	tests := []struct {
+		name, src, want string
+	}{
+		{"no interfaces", "package contextforge\n\ntype Tool struct{}\n", "no exported interfaces"},
+		{"embedded", "package contextforge\n\nimport \"io\"\n\ntype A interface{ io.Reader }\n", "embedded interfaces are not supported"},
+		{"receiver collision", "package contextforge\n\ntype A interface{ Do(m string) }\n", "collides with the receiver"},
+		{"unknown package", "package contextforge\n\ntype A interface{ Do(x http.Header) }\n", "unknown package"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := filepath.Join(t.TempDir(), "src.go")
+			if err := os.WriteFile(name, []byte(tt.src), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := generate(name)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("generate error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package yaml
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  any
+	}{
+		{
+			name: "block mapping and sequence",
+			input: `
+# catalog
+---
+name: weather   # trailing comment
+count: 3
+ratio: 0.5
+enabled: true
+missing: ~
+tags:
+  - a
+  - "b c"
+`,
+			want: map[string]any{
+				"name":    "weather",
+				"count":   json.Number("3"),
+				"ratio":   json.Number("0.5"),
+				"enabled": true,
+				"missing": nil,
+				"tags":    []any{"a", "b c"},
+			},
+		},
+		{
+			name: "sequence of mappings",
+			input: `tools:
+- name: search
+  tags: [web, 'read only']
+- name: fetch
+  inputSchema: {type: object, required: []}
+`,
+			want: map[string]any{
+				"tools": []any{
+					map[string]any{"name": "search", "tags": []any{"web", "read only"}},
+					map[string]any{"name": "fetch", "inputSchema": map[string]any{"type": "object", "required": []any{}}},
+				},
+			},
+		},
+		{
+			name: "nested mappings",
+			input: `a:
+  b:
+    c: "x: y"
+  d: 'it''s'
+`,
+			want: map[string]any{"a": map[string]any{"b": map[string]any{"c": "x: y"}, "d": "it's"}},
+		},
+		{
+			name: "literal block scalar",
+			input: `template: |
+  Hello {{ name }}.
+
+  Bye.
+next: "tab\there"
+`,
+			want: map[string]any{"template": "Hello {{ name }}.\n\nBye.\n", "next": "tab\there"},
+		},
+		{
+			name: "folded block scalar with strip chomping",
+			input: `description: >-
+  one
+  two
+
+  three
+`,
+			want: map[string]any{"description": "one two\nthree"},
+		},
+		{
+			name: "anchors, aliases, and merge keys",
+			input: `defaults: &defaults
+  visibility: team
+  tags: [shared]
+tools:
+  - <<: *defaults
+    name: search
+    tags: [web]
+  - *defaults
+`,
+			want: map[string]any{
+				"defaults": map[string]any{"visibility": "team", "tags": []any{"shared"}},
+				"tools": []any{
+					map[string]any{"visibility": "team", "name": "search", "tags": []any{"web"}},
+					map[string]any{"visibility": "team", "tags": []any{"shared"}},
+				},
+			},
+		},
+		{
+			name:  "non-JSON scalars",
+			input: "created: 2024-01-02\nhex: 0x1F\nbig: 1_000\nexp: 1e3\n",
+			want:  map[string]any{"created": "2024-01-02", "hex": json.Number("31"), "big": json.Number("1000"), "exp": json.Number("1e3")},
+		},
+		{
+			name:  "empty document",
+			input: "# nothing here\n",
+			want:  nil,
+		},
+		{
+			name:  "quoted values keep their type",
+			input: "version: \"1.0\"\nflag: 'true'\n",
+			want:  map[string]any{"version": "1.0", "flag": "true"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Decode returned error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Decode() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// billionLaughs nests aliases so that the document expands to 10^9 scalars.
+const billionLaughs = `a: &a ["lol","lol","lol","lol","lol","lol","lol","lol","lol","lol"]
+b: &b [*a,*a,*a,*a,*a,*a,*a,*a,*a,*a]
+c: &c [*b,*b,*b,*b,*b,*b,*b,*b,*b,*b]
+d: &d [*c,*c,*c,*c,*c,*c,*c,*c,*c,*c]
+e: &e [*d,*d,*d,*d,*d,*d,*d,*d,*d,*d]
+f: &f [*e,*e,*e,*e,*e,*e,*e,*e,*e,*e]
+g: &g [*f,*f,*f,*f,*f,*f,*f,*f,*f,*f]
+h: &h [*g,*g,*g,*g,*g,*g,*g,*g,*g,*g]
+i: &i [*h,*h,*h,*h,*h,*h,*h,*h,*h,*h]
+`
+
+func TestDecode_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"recursive alias", "a: &x\n  b: *x\n", "line 1"},
+		{"multiple documents", "a: 1\n---\nb: 2\n", "multiple documents"},
+		{"duplicate key", "a: 1\na: 2\n", "line 2"},
+		{"bad indentation", "a:\n  b: 1\n c: 2\n", "line 2"},
+		{"infinite number", "a: .inf\n", "line 1"},
+		{"non-scalar key", "? [a]\n: 1\n", "line 1"},
+		{"unterminated flow", "a: [1, 2\n", "line 1"},
+		{"billion laughs", billionLaughs, "aliases expand to more than"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode([]byte(tt.input))
+			if err == nil {
+				t.Fatal("Decode expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Decode error = %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package yaml
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	type tool struct {
+		Name        string         `json:"name"`
+		Description *string        `json:"description,omitempty"`
+		Enabled     bool           `json:"enabled"`
+		Tags        []string       `json:"tags"`
+		InputSchema map[string]any `json:"inputSchema"`
+		Template    string         `json:"template"`
+	}
+	desc := "Search: the docs"
+
+	got, err := Encode([]*tool{{
+		Name:        "search",
+		Description: &desc,
+		Enabled:     true,
+		Tags:        []string{"docs", "true", ""},
+		InputSchema: map[string]any{"type": "object", "required": []string{}, "properties": map[string]any{}},
+		Template:    "Hello {{ name }}\n\nBye\n",
+	}})
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	want := `- name: search
+  description: 'Search: the docs'
+  enabled: true
+  tags:
+    - docs
+    - "true"
+    - ""
+  inputSchema:
+    properties: {}
+    required: []
+    type: object
+  template: |
+    Hello {{ name }}
+
+    Bye
+`
+	if string(got) != want {
+		t.Errorf("Encode() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	values := []any{
+		map[string]any{
+			"plain":     "hello world",
+			"number":    json.Number("-1.5e3"),
+			"numeric":   "42",
+			"null":      nil,
+			"nullish":   "~",
+			"bool":      false,
+			"colon":     "a: b",
+			"comment":   "a #b",
+			"indicator": "- item",
+			"quote":     `say "hi"`,
+			"control":   "tab\there\a",
+			"unicode":   "héllo ✓",
+			"trailing":  "no newline\nat end",
+			"indented":  " leading space\nline",
+			"blank":     "\n\nstarts blank",
+			"key: odd":  "value",
+			"nested": []any{
+				[]any{"a", json.Number("1")},
+				map[string]any{"x": []any{}, "y": map[string]any{"z": "deep"}},
+				[]any{},
+			},
+		},
+		[]any{},
+		"scalar",
+		"multi\nline\n",
+	}
+
+	for _, v := range values {
+		data, err := Encode(v)
+		if err != nil {
+			t.Fatalf("Encode(%v) returned error: %v", v, err)
+		}
+		got, err := Decode(data)
+		if err != nil {
+			t.Fatalf("Decode returned error: %v\n%s", err, data)
+		}
+		if !reflect.DeepEqual(got, v) {
+			t.Errorf("Decode(Encode(v)) = %#v, want %#v\n%s", got, v, data)
+		}
+	}
+}
+
+func TestEncode_Error(t *testing.T) {
+	if _, err := Encode(map[string]any{"ch": make(chan int)}); err == nil {
+		t.Error("Encode expected error, got nil")
+	}
+}
@@ -0,0 +1,267 @@
+// Package yaml converts between YAML documents and the JSON data model, so
+// that manifests, configuration files, and command output in YAML go
+// through the same json struct tags and marshalers as JSON. Parsing and
+// formatting are done by gopkg.in/yaml.v3.
+package yaml
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxAliasNodes is the number of nodes that expanding the aliases of a
+// document may produce. It bounds documents that nest aliases to expand
+// exponentially, such as "billion laughs".
+const maxAliasNodes = 100000
+
+// Decode parses a single YAML document into the values encoding/json would
+// produce for the equivalent JSON: map[string]any, []any, string, bool, nil,
+// and json.Number. Anchors, aliases, and merge keys (<<) are expanded, up to
+// maxAliasNodes expanded nodes.
+// Scalars that JSON cannot represent, such as timestamps and binary data,
+// decode to their text, and non-finite numbers are rejected.
+func Decode(data []byte) (any, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	var doc yaml.Node
+	if err := dec.Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var extra yaml.Node
+	if err := dec.Decode(&extra); !errors.Is(err, io.EOF) {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("yaml: line %d: multiple documents are not supported", extra.Line)
+	}
+
+	c := &converter{active: make(map[*yaml.Node]bool)}
+	return c.value(&doc)
+}
+
+// converter converts yaml.Node trees into JSON values.
+type converter struct {
+	// active holds the nodes being converted, to detect aliases that refer
+	// to one of their own ancestors.
+	active map[*yaml.Node]bool
+
+	// aliases is the number of aliases being expanded, and expanded the
+	// number of nodes their expansion has produced.
+	aliases  int
+	expanded int
+}
+
+func (c *converter) value(n *yaml.Node) (any, error) {
+	if c.active[n] {
+		return nil, fmt.Errorf("yaml: line %d: alias refers to an enclosing node", n.Line)
+	}
+	c.active[n] = true
+	defer delete(c.active, n)
+
+	if c.aliases > 0 {
+		if c.expanded++; c.expanded > maxAliasNodes {
+			return nil, fmt.Errorf("yaml: line %d: aliases expand to more than %d nodes", n.Line, maxAliasNodes)
+		}
+	}
+
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil, nil
+		}
+		return c.value(n.Content[0])
+	case yaml.AliasNode:
+		c.aliases++
+		defer func() { c.aliases-- }()
+		return c.value(n.Alias)
+	case yaml.SequenceNode:
+		list := make([]any, 0, len(n.Content))
+		for _, item := range n.Content {
+			v, err := c.value(item)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	case yaml.MappingNode:
+		return c.mapping(n)
+	case yaml.ScalarNode:
+		return scalar(n)
+	}
+	return nil, fmt.Errorf("yaml: line %d: unexpected node kind %d", n.Line, n.Kind)
+}
+
+// mapping converts a mapping node. Keys set explicitly take precedence over
+// those merged in with <<, as in YAML 1.1.
+func (c *converter) mapping(n *yaml.Node) (map[string]any, error) {
+	m := make(map[string]any, len(n.Content)/2)
+	var merges []*yaml.Node
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if key.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("yaml: line %d: mapping keys must be scalars", key.Line)
+		}
+		if key.Tag == "!!merge" {
+			merges = append(merges, value)
+			continue
+		}
+		if _, ok := m[key.Value]; ok {
+			return nil, fmt.Errorf("yaml: line %d: duplicate key %q", key.Line, key.Value)
+		}
+		v, err := c.value(value)
+		if err != nil {
+			return nil, err
+		}
+		m[key.Value] = v
+	}
+
+	for _, merge := range merges {
+		v, err := c.value(merge)
+		if err != nil {
+			return nil, err
+		}
+		sources, ok := v.([]any)
+		if !ok {
+			sources = []any{v}
+		}
+		for _, source := range sources {
+			sm, ok := source.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("yaml: line %d: merge value must be a mapping or a sequence of mappings", merge.Line)
+			}
+			for k, v := range sm {
+				if _, ok := m[k]; !ok {
+					m[k] = v
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+// scalar converts a scalar node according to its resolved tag.
+func scalar(n *yaml.Node) (any, error) {
+	switch n.ShortTag() {
+	case "!!null":
+		return nil, nil
+	case "!!bool":
+		var b bool
+		if err := n.Decode(&b); err != nil {
+			return nil, err
+		}
+		return b, nil
+	case "!!int", "!!float":
+		// Keep numbers written in JSON syntax exactly; convert the others,
+		// such as 0x1F or 1_000.
+		var num json.Number
+		if json.Unmarshal([]byte(n.Value), &num) == nil {
+			return num, nil
+		}
+		var f float64
+		if err := n.Decode(&f); err != nil {
+			return nil, err
+		}
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, fmt.Errorf("yaml: line %d: %s cannot be represented in JSON", n.Line, n.Value)
+		}
+		if n.ShortTag() == "!!int" {
+			var i any
+			if err := n.Decode(&i); err == nil {
+				return json.Number(fmt.Sprint(i)), nil
+			}
+		}
+		return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), nil
+	}
+	return n.Value, nil
+}
+
+// Encode writes v as a block-style YAML document. The value is first
+// marshaled with encoding/json, so json struct tags and custom marshalers
+// apply, and object keys keep the order encoding/json gives them.
+func Encode(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	n, err := readNode(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(n); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// readNode reads the next JSON value from dec as a yaml.Node, keeping
+// object keys in document order.
+func readNode(dec *json.Decoder) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok := tok.(type) {
+	case json.Delim:
+		n := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if tok == '{' {
+			n.Kind, n.Tag = yaml.MappingNode, "!!map"
+		}
+		for dec.More() {
+			if n.Kind == yaml.MappingNode {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key.(string)})
+			}
+			value, err := readNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			n.Content = append(n.Content, value)
+		}
+		if len(n.Content) == 0 {
+			n.Style = yaml.FlowStyle
+		}
+		_, err := dec.Token() // } or ]
+		return n, err
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(tok)}, nil
+	case json.Number:
+		tag := "!!int"
+		if _, err := tok.Int64(); err != nil {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: tok.String()}, nil
+	case string:
+		n := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: tok}
+		if strings.HasPrefix(tok, "\n") {
+			// yaml.v3 drops leading blank lines from block scalars.
+			n.Style = yaml.DoubleQuotedStyle
+		}
+		return n, nil
+	}
+	return nil, fmt.Errorf("unexpected JSON token %v", tok)
+}
@@ -0,0 +1,316 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+)
+
+// Schema is a JSON Schema under construction. Start from one of the
+// constructors, such as Object or String, refine it with the chainable
+// methods, and call Map to obtain a value for Tool.InputSchema:
+//
+//	schema := jsonschema.Object().
+//		RequiredProperty("city", jsonschema.String().Description("City name").MinLength(1)).
+//		Property("units", jsonschema.String().Enum("metric", "imperial")).
+//		Property("days", jsonschema.Integer().Minimum(1).Maximum(14))
+//
+//	tool.InputSchema = schema.Map()
+//
+// Methods modify the schema in place and return it, so a Schema should not
+// be shared between unrelated schemas while it is still being built.
+type Schema struct {
+	keywords   map[string]any
+	properties map[string]*Schema
+	order      []string // property names in the order they were added
+	required   []string
+	items      *Schema
+	additional any // bool or *Schema
+	allOf      []*Schema
+	anyOf      []*Schema
+	oneOf      []*Schema
+	not        *Schema
+	defs       map[string]*Schema
+}
+
+func newSchema(typ string) *Schema {
+	s := &Schema{keywords: make(map[string]any)}
+	if typ != "" {
+		s.keywords["type"] = typ
+	}
+	return s
+}
+
+// Object returns a schema for JSON objects. Add properties with Property and
+// RequiredProperty.
+func Object() *Schema { return newSchema("object") }
+
+// String returns a schema for JSON strings.
+func String() *Schema { return newSchema("string") }
+
+// Integer returns a schema for integral JSON numbers.
+func Integer() *Schema { return newSchema("integer") }
+
+// Number returns a schema for JSON numbers.
+func Number() *Schema { return newSchema("number") }
+
+// Boolean returns a schema for JSON booleans.
+func Boolean() *Schema { return newSchema("boolean") }
+
+// Null returns a schema for JSON null.
+func Null() *Schema { return newSchema("null") }
+
+// Array returns a schema for JSON arrays whose elements match items. Pass
+// nil to allow elements of any type.
+func Array(items *Schema) *Schema {
+	s := newSchema("array")
+	s.items = items
+	return s
+}
+
+// Any returns a schema that accepts any value.
+func Any() *Schema { return newSchema("") }
+
+// Ref returns a schema that refers to another schema, such as
+// "#/$defs/node". Declare the referenced schema with Def on the root.
+func Ref(ref string) *Schema {
+	s := newSchema("")
+	s.keywords["$ref"] = ref
+	return s
+}
+
+// AllOf returns a schema matching values that match every one of schemas.
+func AllOf(schemas ...*Schema) *Schema {
+	s := newSchema("")
+	s.allOf = schemas
+	return s
+}
+
+// AnyOf returns a schema matching values that match at least one of schemas.
+func AnyOf(schemas ...*Schema) *Schema {
+	s := newSchema("")
+	s.anyOf = schemas
+	return s
+}
+
+// OneOf returns a schema matching values that match exactly one of schemas.
+func OneOf(schemas ...*Schema) *Schema {
+	s := newSchema("")
+	s.oneOf = schemas
+	return s
+}
+
+// Not returns a schema matching values that do not match schema.
+func Not(schema *Schema) *Schema {
+	s := newSchema("")
+	s.not = schema
+	return s
+}
+
+// set sets a keyword and returns s.
+func (s *Schema) set(keyword string, value any) *Schema {
+	if s.keywords == nil {
+		s.keywords = make(map[string]any)
+	}
+	s.keywords[keyword] = value
+	return s
+}
+
+// Title sets the schema's title.
+func (s *Schema) Title(title string) *Schema { return s.set("title", title) }
+
+// Description sets the schema's description, which MCP clients show to
+// models alongside the tool.
+func (s *Schema) Description(description string) *Schema {
+	return s.set("description", description)
+}
+
+// Default sets the value assumed when the property is omitted.
+func (s *Schema) Default(value any) *Schema { return s.set("default", value) }
+
+// Examples sets example values.
+func (s *Schema) Examples(values ...any) *Schema { return s.set("examples", values) }
+
+// Enum restricts values to those given.
+func (s *Schema) Enum(values ...any) *Schema { return s.set("enum", values) }
+
+// Const restricts values to value.
+func (s *Schema) Const(value any) *Schema { return s.set("const", value) }
+
+// Nullable additionally allows null, turning a type of "string" into
+// ["string", "null"].
+func (s *Schema) Nullable() *Schema {
+	switch t := s.keywords["type"].(type) {
+	case string:
+		s.keywords["type"] = []string{t, "null"}
+	case []string:
+		if !slices.Contains(t, "null") {
+			s.keywords["type"] = append(t, "null")
+		}
+	}
+	return s
+}
+
+// Format sets the format annotation, such as "email" or "date-time". Formats
+// are not checked by Validator.
+func (s *Schema) Format(format string) *Schema { return s.set("format", format) }
+
+// Pattern restricts strings to those matching the regular expression expr.
+func (s *Schema) Pattern(expr string) *Schema { return s.set("pattern", expr) }
+
+// MinLength sets the minimum length of strings, in characters.
+func (s *Schema) MinLength(n int) *Schema { return s.set("minLength", n) }
+
+// MaxLength sets the maximum length of strings, in characters.
+func (s *Schema) MaxLength(n int) *Schema { return s.set("maxLength", n) }
+
+// Minimum sets the inclusive lower bound of numbers.
+func (s *Schema) Minimum(n float64) *Schema { return s.set("minimum", n) }
+
+// Maximum sets the inclusive upper bound of numbers.
+func (s *Schema) Maximum(n float64) *Schema { return s.set("maximum", n) }
+
+// ExclusiveMinimum sets the exclusive lower bound of numbers.
+func (s *Schema) ExclusiveMinimum(n float64) *Schema { return s.set("exclusiveMinimum", n) }
+
+// ExclusiveMaximum sets the exclusive upper bound of numbers.
+func (s *Schema) ExclusiveMaximum(n float64) *Schema { return s.set("exclusiveMaximum", n) }
+
+// MultipleOf restricts numbers to multiples of n.
+func (s *Schema) MultipleOf(n float64) *Schema { return s.set("multipleOf", n) }
+
+// Items sets the schema of array elements.
+func (s *Schema) Items(items *Schema) *Schema {
+	s.items = items
+	return s
+}
+
+// MinItems sets the minimum number of array elements.
+func (s *Schema) MinItems(n int) *Schema { return s.set("minItems", n) }
+
+// MaxItems sets the maximum number of array elements.
+func (s *Schema) MaxItems(n int) *Schema { return s.set("maxItems", n) }
+
+// UniqueItems requires array elements to be distinct.
+func (s *Schema) UniqueItems() *Schema { return s.set("uniqueItems", true) }
+
+// Property adds an optional property to an object schema.
+func (s *Schema) Property(name string, schema *Schema) *Schema {
+	if s.properties == nil {
+		s.properties = make(map[string]*Schema)
+	}
+	if _, ok := s.properties[name]; !ok {
+		s.order = append(s.order, name)
+	}
+	s.properties[name] = schema
+	return s
+}
+
+// RequiredProperty adds a required property to an object schema.
+func (s *Schema) RequiredProperty(name string, schema *Schema) *Schema {
+	return s.Property(name, schema).Required(name)
+}
+
+// Required marks the named properties as required.
+func (s *Schema) Required(names ...string) *Schema {
+	for _, name := range names {
+		if !slices.Contains(s.required, name) {
+			s.required = append(s.required, name)
+		}
+	}
+	return s
+}
+
+// AdditionalProperties sets whether an object schema allows properties
+// other than those added with Property.
+func (s *Schema) AdditionalProperties(allowed bool) *Schema {
+	s.additional = allowed
+	return s
+}
+
+// AdditionalPropertiesSchema sets the schema of properties other than those
+// added with Property, as for a map.
+func (s *Schema) AdditionalPropertiesSchema(schema *Schema) *Schema {
+	s.additional = schema
+	return s
+}
+
+// Def declares a schema under $defs, where Ref("#/$defs/"+name) refers to
+// it. Declare definitions on the root schema.
+func (s *Schema) Def(name string, schema *Schema) *Schema {
+	if s.defs == nil {
+		s.defs = make(map[string]*Schema)
+	}
+	s.defs[name] = schema
+	return s
+}
+
+// Map returns the schema as the nested map[string]any used by
+// Tool.InputSchema. A nil Schema yields nil.
+func (s *Schema) Map() map[string]any {
+	if s == nil {
+		return nil
+	}
+
+	m := maps.Clone(s.keywords)
+	if m == nil {
+		m = make(map[string]any)
+	}
+	if len(s.properties) > 0 {
+		props := make(map[string]any, len(s.properties))
+		for _, name := range s.order {
+			props[name] = s.properties[name].Map()
+		}
+		m["properties"] = props
+	}
+	if len(s.required) > 0 {
+		m["required"] = slices.Clone(s.required)
+	}
+	if s.items != nil {
+		m["items"] = s.items.Map()
+	}
+	switch a := s.additional.(type) {
+	case bool:
+		m["additionalProperties"] = a
+	case *Schema:
+		m["additionalProperties"] = a.Map()
+	}
+	if s.allOf != nil {
+		m["allOf"] = mapAll(s.allOf)
+	}
+	if s.anyOf != nil {
+		m["anyOf"] = mapAll(s.anyOf)
+	}
+	if s.oneOf != nil {
+		m["oneOf"] = mapAll(s.oneOf)
+	}
+	if s.not != nil {
+		m["not"] = s.not.Map()
+	}
+	if len(s.defs) > 0 {
+		defs := make(map[string]any, len(s.defs))
+		for name, def := range s.defs {
+			defs[name] = def.Map()
+		}
+		m["$defs"] = defs
+	}
+	return m
+}
+
+func mapAll(schemas []*Schema) []any {
+	out := make([]any, len(schemas))
+	for i, s := range schemas {
+		out[i] = s.Map()
+	}
+	return out
+}
+
+// MarshalJSON encodes the schema as JSON.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Map())
+}
+
+// Compile compiles the schema into a Validator.
+func (s *Schema) Compile() (*Validator, error) {
+	return Compile(s.Map())
+}
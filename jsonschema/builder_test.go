@@ -0,0 +1,77 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSchema_Map(t *testing.T) {
+	schema := Object().
+		RequiredProperty("input", String().Description("Test input parameter")).
+		Property("count", Integer().Description("Test count parameter").Minimum(0)).
+		Property("units", String().Enum("metric", "imperial").Default("metric")).
+		Property("tags", Array(String()).UniqueItems().MaxItems(5)).
+		Property("timeout", Number().Nullable()).
+		AdditionalProperties(false)
+
+	want := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"input": map[string]any{"type": "string", "description": "Test input parameter"},
+			"count": map[string]any{"type": "integer", "description": "Test count parameter", "minimum": 0.0},
+			"units": map[string]any{"type": "string", "enum": []any{"metric", "imperial"}, "default": "metric"},
+			"tags": map[string]any{
+				"type":        "array",
+				"items":       map[string]any{"type": "string"},
+				"uniqueItems": true,
+				"maxItems":    5,
+			},
+			"timeout": map[string]any{"type": []string{"number", "null"}},
+		},
+		"required":             []string{"input"},
+		"additionalProperties": false,
+	}
+
+	if got := schema.Map(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Map() = %#v, want %#v", got, want)
+	}
+}
+
+func TestSchema_Combinators(t *testing.T) {
+	schema := Object().
+		Property("target", OneOf(String(), Integer().Minimum(0))).
+		Property("mode", Not(Object().Property("unsafe", Boolean().Const(true)))).
+		Property("node", Ref("#/$defs/node")).
+		Def("node", Object().Property("children", Array(Ref("#/$defs/node"))))
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+	want := `{"$defs":{"node":{"properties":{"children":{"items":{"$ref":"#/$defs/node"},"type":"array"}},"type":"object"}},` +
+		`"properties":{"mode":{"not":{"properties":{"unsafe":{"const":true,"type":"boolean"}},"type":"object"}},` +
+		`"node":{"$ref":"#/$defs/node"},` +
+		`"target":{"oneOf":[{"type":"string"},{"minimum":0,"type":"integer"}]}},"type":"object"}`
+	if string(data) != want {
+		t.Errorf("json.Marshal = %s, want %s", data, want)
+	}
+
+	if _, err := schema.Compile(); err != nil {
+		t.Errorf("Compile returned error: %v", err)
+	}
+}
+
+func TestSchema_Compile(t *testing.T) {
+	v, err := Object().
+		RequiredProperty("zip", String().Pattern(`^\d{5}$`)).
+		Compile()
+	if err != nil {
+		t.Fatalf("Compile returned error: %v", err)
+	}
+
+	err = v.Validate(map[string]any{"zip": "abc"})
+	if err == nil || err.Error() != `zip: must match pattern "^\\d{5}$"` {
+		t.Errorf("Validate error = %v, want pattern mismatch", err)
+	}
+}
@@ -0,0 +1,399 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Validator is a compiled schema. It is safe for concurrent use.
+type Validator struct {
+	root *node
+}
+
+// node is a compiled schema or subschema.
+type node struct {
+	// always, if non-nil, is the result of a boolean schema (true or false).
+	always *bool
+
+	types    []string
+	enum     []any
+	constVal any
+	hasConst bool
+
+	properties           map[string]*node
+	required             []string
+	additionalProperties *node
+
+	items       *node
+	minItems    *int
+	maxItems    *int
+	uniqueItems bool
+
+	minLength *int
+	maxLength *int
+	pattern   *regexp.Regexp
+
+	minimum          *big.Rat
+	maximum          *big.Rat
+	exclusiveMinimum *big.Rat
+	exclusiveMaximum *big.Rat
+	multipleOf       *big.Rat
+
+	allOf []*node
+	anyOf []*node
+	oneOf []*node
+	not   *node
+
+	ref *node
+}
+
+// jsonTypes are the type names defined by JSON Schema.
+var jsonTypes = []string{"array", "boolean", "integer", "null", "number", "object", "string"}
+
+// Compile compiles schema, typically a Tool.InputSchema, into a Validator.
+// If the schema is not well formed, Compile returns ValidationErrors whose
+// paths locate the offending keywords. A nil schema accepts any value.
+func Compile(schema map[string]any) (*Validator, error) {
+	if schema == nil {
+		return &Validator{root: &node{}}, nil
+	}
+
+	raw, err := normalize(schema)
+	if err != nil {
+		return nil, fmt.Errorf("normalize schema; %w", err)
+	}
+
+	c := &compiler{root: raw.(map[string]any), refs: make(map[string]*node), paths: make(map[*node]string)}
+	root := c.compile(raw, "")
+	c.checkCycles()
+	if len(c.errs) > 0 {
+		return nil, c.errs
+	}
+	return &Validator{root: root}, nil
+}
+
+// Validate compiles schema and validates v against it. Use Compile to
+// validate many values against the same schema.
+func Validate(schema map[string]any, v any) error {
+	validator, err := Compile(schema)
+	if err != nil {
+		return err
+	}
+	return validator.Validate(v)
+}
+
+// normalize round-trips v through JSON so that schemas and values built in
+// Go (with []string, int, and struct values) look the same as decoded JSON,
+// with numbers as json.Number.
+func normalize(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var out any
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// compiler holds the state of a single Compile call.
+type compiler struct {
+	root  map[string]any
+	refs  map[string]*node
+	nodes []*node          // compiled nodes, in compilation order
+	paths map[*node]string // path of each compiled node
+	errs  ValidationErrors
+}
+
+func (c *compiler) errorf(path, format string, args ...any) {
+	c.errs = append(c.errs, &ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// compile compiles the schema raw found at path.
+func (c *compiler) compile(raw any, path string) *node {
+	return c.compileInto(&node{}, raw, path)
+}
+
+func (c *compiler) compileInto(n *node, raw any, path string) *node {
+	c.nodes = append(c.nodes, n)
+	c.paths[n] = path
+	switch s := raw.(type) {
+	case bool:
+		n.always = &s
+		return n
+	case map[string]any:
+		c.compileObject(n, s, path)
+		return n
+	default:
+		c.errorf(path, "must be an object or a boolean")
+		return n
+	}
+}
+
+func (c *compiler) compileObject(n *node, s map[string]any, path string) {
+	if v, ok := s["type"]; ok {
+		n.types = c.typeList(v, joinPath(path, "type"))
+	}
+	if v, ok := s["enum"]; ok {
+		if enum, ok := v.([]any); ok {
+			n.enum = enum
+		} else {
+			c.errorf(joinPath(path, "enum"), "must be an array")
+		}
+	}
+	if v, ok := s["const"]; ok {
+		n.constVal, n.hasConst = v, true
+	}
+
+	if v, ok := s["properties"]; ok {
+		props, ok := v.(map[string]any)
+		if !ok {
+			c.errorf(joinPath(path, "properties"), "must be an object")
+		} else {
+			n.properties = make(map[string]*node, len(props))
+			for _, name := range sortedKeys(props) {
+				n.properties[name] = c.compile(props[name], joinPath(joinPath(path, "properties"), name))
+			}
+		}
+	}
+	if v, ok := s["required"]; ok {
+		n.required = c.stringList(v, joinPath(path, "required"))
+	}
+	if v, ok := s["additionalProperties"]; ok {
+		n.additionalProperties = c.compile(v, joinPath(path, "additionalProperties"))
+	}
+
+	if v, ok := s["items"]; ok {
+		if _, isArray := v.([]any); isArray {
+			c.errorf(joinPath(path, "items"), "must be a schema; use prefixItems for tuples")
+		} else {
+			n.items = c.compile(v, joinPath(path, "items"))
+		}
+	}
+	n.minItems = c.count(s, "minItems", path)
+	n.maxItems = c.count(s, "maxItems", path)
+	if v, ok := s["uniqueItems"]; ok {
+		b, ok := v.(bool)
+		if !ok {
+			c.errorf(joinPath(path, "uniqueItems"), "must be a boolean")
+		}
+		n.uniqueItems = b
+	}
+
+	n.minLength = c.count(s, "minLength", path)
+	n.maxLength = c.count(s, "maxLength", path)
+	if v, ok := s["pattern"]; ok {
+		expr, ok := v.(string)
+		if !ok {
+			c.errorf(joinPath(path, "pattern"), "must be a string")
+		} else if re, err := regexp.Compile(expr); err != nil {
+			c.errorf(joinPath(path, "pattern"), "invalid regular expression: %v", err)
+		} else {
+			n.pattern = re
+		}
+	}
+
+	n.minimum = c.number(s, "minimum", path)
+	n.maximum = c.number(s, "maximum", path)
+	n.exclusiveMinimum = c.number(s, "exclusiveMinimum", path)
+	n.exclusiveMaximum = c.number(s, "exclusiveMaximum", path)
+	n.multipleOf = c.number(s, "multipleOf", path)
+	if n.multipleOf != nil && n.multipleOf.Sign() <= 0 {
+		c.errorf(joinPath(path, "multipleOf"), "must be greater than 0")
+		n.multipleOf = nil
+	}
+
+	n.allOf = c.schemaList(s, "allOf", path)
+	n.anyOf = c.schemaList(s, "anyOf", path)
+	n.oneOf = c.schemaList(s, "oneOf", path)
+	if v, ok := s["not"]; ok {
+		n.not = c.compile(v, joinPath(path, "not"))
+	}
+
+	if v, ok := s["$ref"]; ok {
+		ref, ok := v.(string)
+		if !ok {
+			c.errorf(joinPath(path, "$ref"), "must be a string")
+		} else {
+			n.ref = c.resolve(ref, joinPath(path, "$ref"))
+		}
+	}
+}
+
+// resolve returns the compiled schema that ref points to. Only references
+// to the root schema and to its $defs and definitions are supported.
+func (c *compiler) resolve(ref, path string) *node {
+	if n, ok := c.refs[ref]; ok {
+		return n
+	}
+
+	var target any
+	switch {
+	case ref == "#":
+		target = c.root
+	case strings.HasPrefix(ref, "#/$defs/"), strings.HasPrefix(ref, "#/definitions/"):
+		section, name, _ := strings.Cut(strings.TrimPrefix(ref, "#/"), "/")
+		defs, _ := c.root[section].(map[string]any)
+		name = strings.NewReplacer("~1", "/", "~0", "~").Replace(name)
+		if def, ok := defs[name]; ok {
+			target = def
+		}
+	}
+	if target == nil {
+		c.errorf(path, "cannot resolve reference %q", ref)
+		return nil
+	}
+
+	// Register the node before compiling it so recursive references
+	// resolve to it instead of recursing forever.
+	n := &node{}
+	c.refs[ref] = n
+	return c.compileInto(n, target, strings.TrimPrefix(strings.ReplaceAll(ref, "/", "."), "#."))
+}
+
+// checkCycles reports each $ref that leads back to a schema already being
+// applied to the same instance, such as {"$ref": "#"}. Validating against
+// such a schema would recurse forever. References reached through
+// properties, additionalProperties, or items are fine, since those apply to
+// a smaller part of the instance.
+func (c *compiler) checkCycles() {
+	const (
+		unvisited = iota
+		active
+		done
+	)
+	state := make(map[*node]int, len(c.nodes))
+
+	var visit func(n *node)
+	visit = func(n *node) {
+		state[n] = active
+		next := slices.Concat(n.allOf, n.anyOf, n.oneOf, []*node{n.not})
+		for _, m := range next {
+			if m != nil && state[m] == unvisited {
+				visit(m)
+			}
+		}
+		if n.ref != nil {
+			switch state[n.ref] {
+			case unvisited:
+				visit(n.ref)
+			case active:
+				c.errorf(joinPath(c.paths[n], "$ref"), "reference cycle does not consume any input")
+			}
+		}
+		state[n] = done
+	}
+	for _, n := range c.nodes {
+		if state[n] == unvisited {
+			visit(n)
+		}
+	}
+}
+
+func (c *compiler) typeList(v any, path string) []string {
+	var types []string
+	switch t := v.(type) {
+	case string:
+		types = []string{t}
+	case []any:
+		types = c.stringList(t, path)
+	default:
+		c.errorf(path, "must be a string or an array of strings")
+		return nil
+	}
+	for _, t := range types {
+		if !slices.Contains(jsonTypes, t) {
+			c.errorf(path, "unknown type %q", t)
+		}
+	}
+	return types
+}
+
+func (c *compiler) stringList(v any, path string) []string {
+	list, ok := v.([]any)
+	if !ok {
+		c.errorf(path, "must be an array of strings")
+		return nil
+	}
+	strs := make([]string, 0, len(list))
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			c.errorf(path, "must be an array of strings")
+			return nil
+		}
+		strs = append(strs, s)
+	}
+	return strs
+}
+
+func (c *compiler) schemaList(s map[string]any, keyword, path string) []*node {
+	v, ok := s[keyword]
+	if !ok {
+		return nil
+	}
+	path = joinPath(path, keyword)
+	list, ok := v.([]any)
+	if !ok || len(list) == 0 {
+		c.errorf(path, "must be a non-empty array")
+		return nil
+	}
+	nodes := make([]*node, len(list))
+	for i, item := range list {
+		nodes[i] = c.compile(item, indexPath(path, i))
+	}
+	return nodes
+}
+
+func (c *compiler) number(s map[string]any, keyword, path string) *big.Rat {
+	v, ok := s[keyword]
+	if !ok {
+		return nil
+	}
+	r, ok := toRat(v)
+	if !ok {
+		c.errorf(joinPath(path, keyword), "must be a number")
+		return nil
+	}
+	return r
+}
+
+func (c *compiler) count(s map[string]any, keyword, path string) *int {
+	v, ok := s[keyword]
+	if !ok {
+		return nil
+	}
+	r, ok := toRat(v)
+	if !ok || !r.IsInt() || r.Sign() < 0 || !r.Num().IsInt64() {
+		c.errorf(joinPath(path, keyword), "must be a non-negative integer")
+		return nil
+	}
+	n := int(r.Num().Int64())
+	return &n
+}
+
+// toRat converts a normalized JSON number to an exact rational.
+func toRat(v any) (*big.Rat, bool) {
+	num, ok := v.(json.Number)
+	if !ok {
+		return nil, false
+	}
+	return new(big.Rat).SetString(num.String())
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
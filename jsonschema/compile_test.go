@@ -0,0 +1,154 @@
+package jsonschema
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"query": map[string]any{"type": "string", "minLength": 1},
+			"limit": map[string]any{"type": "integer", "minimum": 1, "maximum": 100},
+			"tags":  map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"node":  map[string]any{"$ref": "#/$defs/node"},
+		},
+		"required":             []string{"query"},
+		"additionalProperties": false,
+		"$defs": map[string]any{
+			"node": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"children": map[string]any{"type": "array", "items": map[string]any{"$ref": "#/$defs/node"}},
+				},
+			},
+		},
+	}
+
+	if _, err := Compile(schema); err != nil {
+		t.Fatalf("Compile returned error: %v", err)
+	}
+}
+
+func TestCompile_Nil(t *testing.T) {
+	v, err := Compile(nil)
+	if err != nil {
+		t.Fatalf("Compile(nil) returned error: %v", err)
+	}
+	if err := v.Validate(map[string]any{"anything": true}); err != nil {
+		t.Errorf("Validate returned error: %v", err)
+	}
+}
+
+func TestCompile_InvalidSchema(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"id":    map[string]any{"type": "uuid"},
+			"code":  map[string]any{"type": "string", "pattern": "[a-z"},
+			"count": map[string]any{"type": "integer", "minimum": "zero", "maxItems": -1},
+			"ref":   map[string]any{"$ref": "#/$defs/missing"},
+			"step":  map[string]any{"multipleOf": 0},
+		},
+		"required": "id",
+		"anyOf":    []any{},
+	}
+
+	_, err := Compile(schema)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Compile error = %v, want ValidationErrors", err)
+	}
+
+	got := make([]string, len(errs))
+	for i, e := range errs {
+		got[i] = e.Path
+	}
+	want := []string{
+		"properties.code.pattern",
+		"properties.count.maxItems",
+		"properties.count.minimum",
+		"properties.id.type",
+		"properties.ref.$ref",
+		"properties.step.multipleOf",
+		"required",
+		"anyOf",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("error paths = %q, want %q", got, want)
+	}
+}
+
+func TestCompile_InvalidSubschema(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema map[string]any
+		want   string
+	}{
+		{"non-schema property", map[string]any{"properties": map[string]any{"a": "string"}}, "properties.a: must be an object or a boolean"},
+		{"tuple items", map[string]any{"items": []any{map[string]any{}}}, "items: must be a schema; use prefixItems for tuples"},
+		{"non-array enum", map[string]any{"enum": "a"}, "enum: must be an array"},
+		{"non-string type", map[string]any{"type": 1}, "type: must be a string or an array of strings"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.schema)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Compile error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompile_RefCycle(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema map[string]any
+		want   string
+	}{
+		{"self", map[string]any{"$ref": "#"}, "#.$ref: reference cycle does not consume any input"},
+		{"defs", map[string]any{
+			"$ref": "#/$defs/a",
+			"$defs": map[string]any{
+				"a": map[string]any{"$ref": "#/$defs/b"},
+				"b": map[string]any{"allOf": []any{map[string]any{"$ref": "#/$defs/a"}}},
+			},
+		}, "$defs.b.allOf[0].$ref: reference cycle does not consume any input"},
+		{"in an unused definition", map[string]any{
+			"$defs":      map[string]any{"loop": map[string]any{"anyOf": []any{map[string]any{"$ref": "#/$defs/loop"}}}},
+			"properties": map[string]any{"x": map[string]any{"$ref": "#/$defs/loop"}},
+		}, "$defs.loop.anyOf[0].$ref: reference cycle does not consume any input"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.schema)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Compile error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompile_RecursionThroughInstance(t *testing.T) {
+	schema := map[string]any{
+		"type":  "object",
+		"allOf": []any{map[string]any{"required": []string{"name"}}},
+		"properties": map[string]any{
+			"name":     map[string]any{"type": "string"},
+			"children": map[string]any{"type": "array", "items": map[string]any{"$ref": "#"}},
+		},
+	}
+
+	v, err := Compile(schema)
+	if err != nil {
+		t.Fatalf("Compile returned error: %v", err)
+	}
+	tree := map[string]any{"name": "a", "children": []any{map[string]any{"name": "b", "children": []any{map[string]any{}}}}}
+	if err := v.Validate(tree); err == nil {
+		t.Error("Validate accepted a child without a name")
+	}
+}
@@ -0,0 +1,65 @@
+// Package jsonschema builds and validates the JSON Schemas that ContextForge
+// tools declare as their input schema (Tool.InputSchema).
+//
+// The gateway only rejects malformed arguments after a round-trip to the
+// upstream MCP server. Validating on the client reports every problem at
+// once, with the path of the offending field, before anything is sent.
+//
+// # Building Schemas
+//
+// Rather than writing nested map[string]any literals, build schemas with the
+// fluent Schema type:
+//
+//	tool.InputSchema = jsonschema.Object().
+//		RequiredProperty("city", jsonschema.String().Description("City name")).
+//		Property("days", jsonschema.Integer().Minimum(1).Maximum(14)).
+//		Map()
+//
+// or derive them from the Go type a tool handler decodes its arguments into,
+// using json tags for names and jsonschema tags for constraints:
+//
+//	type ForecastArgs struct {
+//		City string `json:"city" jsonschema:"description=City name"`
+//		Days int    `json:"days,omitempty" jsonschema:"minimum=1,maximum=14"`
+//	}
+//
+//	schema, err := jsonschema.Reflect(ForecastArgs{})
+//	tool.InputSchema = schema.Map()
+//
+// # Validating Values
+//
+// Compile a schema once, then validate any number of values against it:
+//
+//	v, err := jsonschema.Compile(tool.InputSchema)
+//	if err != nil {
+//		// The schema itself is invalid.
+//	}
+//	if err := v.Validate(args); err != nil {
+//		var verrs jsonschema.ValidationErrors
+//		errors.As(err, &verrs)
+//		for _, e := range verrs {
+//			fmt.Println(e.Path, e.Message) // e.g. "address.zip", "must match pattern ..."
+//		}
+//	}
+//
+// Compile also checks that a schema is well formed, which makes it useful
+// before registering a tool with ToolsService.Create.
+//
+// # Supported Keywords
+//
+// The package implements the subset of JSON Schema draft 2020-12 used by
+// tool input schemas:
+//
+//   - type (a single type or a list of types), enum, const
+//   - properties, required, additionalProperties
+//   - items, minItems, maxItems, uniqueItems
+//   - minLength, maxLength, pattern
+//   - minimum, maximum, exclusiveMinimum, exclusiveMaximum, multipleOf
+//   - allOf, anyOf, oneOf, not
+//   - $ref to local definitions under #/$defs or #/definitions
+//
+// Other keywords, such as format, title, description, and default, are
+// accepted and ignored. Recursive references must apply to a part of the
+// instance, as in items: {"$ref": "#"}; Compile rejects reference cycles,
+// such as {"$ref": "#"}, that would validate the same value forever.
+package jsonschema
@@ -0,0 +1,49 @@
+package jsonschema
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ValidationError describes a single problem with a value or a schema.
+type ValidationError struct {
+	// Path locates the problem, such as "address.zip" or "tags[2]". For
+	// value errors it is the path of the offending field; for schema
+	// errors it is the path of the offending keyword. The root is "".
+	Path string
+
+	// Message describes the problem, such as "is required".
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors is the list of problems found by Compile or
+// Validator.Validate, in the order they were found.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// joinPath returns the path of the named property of the value at path.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// indexPath returns the path of element i of the array at path.
+func indexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
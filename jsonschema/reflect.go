@@ -0,0 +1,380 @@
+package jsonschema
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	rawMessageType    = reflect.TypeFor[json.RawMessage]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// Reflect derives a schema from the Go type of v, typically a tool
+// handler's argument struct, so the tool can be registered with an
+// InputSchema that matches what the handler decodes:
+//
+//	type SearchArgs struct {
+//		Query string   `json:"query" jsonschema:"description=Search terms,minLength=1"`
+//		Limit int      `json:"limit,omitempty" jsonschema:"minimum=1,maximum=100,default=10"`
+//		Sort  string   `json:"sort,omitempty" jsonschema:"enum=relevance,enum=date"`
+//		Tags  []string `json:"tags,omitempty"`
+//	}
+//
+//	schema, err := jsonschema.Reflect(SearchArgs{})
+//	tool.InputSchema = schema.Map()
+//
+// Struct fields are named and omitted following their json tags, and
+// embedded structs are flattened as encoding/json does. A field is required
+// unless its json tag has omitempty or omitzero or it is a pointer; the
+// jsonschema tag options required and optional override this. Pointer
+// fields also accept null.
+//
+// The jsonschema tag is a comma-separated list of options; write \, for a
+// literal comma in a value. The options are:
+//
+//	description=, title=, format=, pattern=
+//	minimum=, maximum=, exclusiveMinimum=, exclusiveMaximum=, multipleOf=
+//	minLength=, maxLength=, minItems=, maxItems=, uniqueItems
+//	enum= (repeat for each value), default=, example= (repeatable)
+//	required, optional, nullable
+//
+// Values of enum, default, and example are parsed as the field's type.
+// A jsonschema tag of "-" omits the field from the schema. Recursive types
+// are described under $defs and referenced with $ref.
+func Reflect(v any) (*Schema, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return nil, fmt.Errorf("cannot reflect schema from nil")
+	}
+
+	r := &reflector{
+		active: make(map[reflect.Type]bool),
+		names:  make(map[reflect.Type]string),
+		defs:   make(map[string]*Schema),
+	}
+	s, err := r.reflect(t)
+	if err != nil {
+		return nil, err
+	}
+	for name, def := range r.defs {
+		s.Def(name, def)
+	}
+	return s, nil
+}
+
+// reflector holds the state of a single Reflect call.
+type reflector struct {
+	active map[reflect.Type]bool   // struct types being reflected
+	names  map[reflect.Type]string // $defs names of recursive struct types
+	defs   map[string]*Schema
+}
+
+func (r *reflector) reflect(t reflect.Type) (*Schema, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return String().Format("date-time"), nil
+	case t == rawMessageType:
+		return Any(), nil
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return Any(), nil
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return String(), nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return String(), nil
+	case reflect.Bool:
+		return Boolean(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Integer(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Integer().Minimum(0), nil
+	case reflect.Float32, reflect.Float64:
+		return Number(), nil
+	case reflect.Interface:
+		return Any(), nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return String().set("contentEncoding", "base64"), nil
+		}
+		items, err := r.reflect(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		s := Array(items)
+		if t.Kind() == reflect.Array {
+			s.MinItems(t.Len()).MaxItems(t.Len())
+		}
+		return s, nil
+	case reflect.Map:
+		switch t.Key().Kind() {
+		case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			return nil, fmt.Errorf("unsupported map key type %s", t.Key())
+		}
+		values, err := r.reflect(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return Object().AdditionalPropertiesSchema(values), nil
+	case reflect.Struct:
+		return r.reflectStruct(t)
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+func (r *reflector) reflectStruct(t reflect.Type) (*Schema, error) {
+	if r.active[t] {
+		return Ref("#/$defs/" + r.defName(t)), nil
+	}
+	r.active[t] = true
+	defer delete(r.active, t)
+
+	s := Object()
+	for _, f := range structFields(t) {
+		prop, required, err := r.reflectField(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t, f.field.Name, err)
+		}
+		if prop == nil {
+			continue
+		}
+		s.Property(f.name, prop)
+		if required {
+			s.Required(f.name)
+		}
+	}
+
+	// A type that refers to itself is described once under $defs.
+	if name, ok := r.names[t]; ok {
+		r.defs[name] = s
+		return Ref("#/$defs/" + name), nil
+	}
+	return s, nil
+}
+
+// defName returns the $defs name of a recursive struct type.
+func (r *reflector) defName(t reflect.Type) string {
+	if name, ok := r.names[t]; ok {
+		return name
+	}
+	base := t.Name()
+	if base == "" {
+		base = "anonymous"
+	}
+	name := base
+	for i := 2; ; i++ {
+		taken := false
+		for _, n := range r.names {
+			taken = taken || n == name
+		}
+		if !taken {
+			break
+		}
+		name = base + strconv.Itoa(i)
+	}
+	r.names[t] = name
+	return name
+}
+
+// reflectField returns the schema of a struct field and whether it is
+// required. It returns a nil schema for fields excluded by a "-" tag.
+func (r *reflector) reflectField(f jsonField) (*Schema, bool, error) {
+	tag, hasTag := f.field.Tag.Lookup("jsonschema")
+	if hasTag && tag == "-" {
+		return nil, false, nil
+	}
+
+	s, err := r.reflect(f.field.Type)
+	if err != nil {
+		return nil, false, err
+	}
+	if f.asString {
+		s = String()
+	}
+	// encoding/json encodes nil pointers as null.
+	if f.field.Type.Kind() == reflect.Pointer {
+		if _, typed := s.keywords["type"]; typed {
+			s.Nullable()
+		} else if _, isRef := s.keywords["$ref"]; isRef {
+			s = AnyOf(s, Null())
+		}
+	}
+
+	required := !f.omitEmpty && f.field.Type.Kind() != reflect.Pointer
+	if !hasTag {
+		return s, required, nil
+	}
+
+	for _, opt := range splitTag(tag) {
+		key, value, _ := strings.Cut(opt, "=")
+		switch key {
+		case "required":
+			required = true
+		case "optional":
+			required = false
+		case "nullable":
+			s.Nullable()
+		case "uniqueItems":
+			s.UniqueItems()
+		case "description", "title", "format", "pattern":
+			s.set(key, value)
+		case "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, false, fmt.Errorf("jsonschema tag %s: invalid number %q", key, value)
+			}
+			s.set(key, n)
+		case "minLength", "maxLength", "minItems", "maxItems":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return nil, false, fmt.Errorf("jsonschema tag %s: invalid count %q", key, value)
+			}
+			s.set(key, n)
+		case "enum", "example":
+			v, err := parseTagValue(f.field.Type, value)
+			if err != nil {
+				return nil, false, fmt.Errorf("jsonschema tag %s: %w", key, err)
+			}
+			keyword := key
+			if key == "example" {
+				keyword = "examples"
+			}
+			values, _ := s.keywords[keyword].([]any)
+			s.set(keyword, append(values, v))
+		case "default":
+			v, err := parseTagValue(f.field.Type, value)
+			if err != nil {
+				return nil, false, fmt.Errorf("jsonschema tag %s: %w", key, err)
+			}
+			s.Default(v)
+		default:
+			return nil, false, fmt.Errorf("unknown jsonschema tag option %q", key)
+		}
+	}
+	// The null of a nil pointer must also satisfy the enum.
+	if enum, ok := s.keywords["enum"].([]any); ok && f.field.Type.Kind() == reflect.Pointer {
+		s.Enum(append(enum, nil)...)
+	}
+	return s, required, nil
+}
+
+// splitTag splits a jsonschema tag on commas not escaped with a backslash.
+func splitTag(tag string) []string {
+	var opts []string
+	var b strings.Builder
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			b.WriteByte(',')
+			i++
+		case tag[i] == ',':
+			opts = append(opts, b.String())
+			b.Reset()
+		default:
+			b.WriteByte(tag[i])
+		}
+	}
+	return append(opts, b.String())
+}
+
+// parseTagValue parses a tag value as a value of type t, or of t's element
+// type for slices.
+func parseTagValue(t reflect.Type, value string) (any, error) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return strconv.ParseBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(value, 10, 64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.ParseUint(value, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(value, 64)
+	}
+	return value, nil
+}
+
+// jsonField is a struct field as encoding/json sees it.
+type jsonField struct {
+	name      string
+	field     reflect.StructField
+	omitEmpty bool
+	asString  bool
+}
+
+// structFields returns the fields encoding/json encodes for struct type t,
+// flattening embedded structs. Fields of embedded structs are shadowed by
+// fields with the same name at a shallower depth.
+func structFields(t reflect.Type) []jsonField {
+	var direct, promoted []jsonField
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				promoted = append(promoted, structFields(ft)...)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = sf.Name
+		}
+		f := jsonField{name: name, field: sf}
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "omitempty", "omitzero":
+				f.omitEmpty = true
+			case "string":
+				switch sf.Type.Kind() {
+				case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+					reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+					reflect.Float32, reflect.Float64:
+					f.asString = true
+				}
+			}
+		}
+		direct = append(direct, f)
+	}
+
+	fields := direct
+	for _, p := range promoted {
+		shadowed := false
+		for _, f := range fields {
+			shadowed = shadowed || f.name == p.name
+		}
+		if !shadowed {
+			fields = append(fields, p)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,134 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type searchArgs struct {
+	Query    string            `json:"query" jsonschema:"description=Search terms\\, in any language,minLength=1"`
+	Limit    int               `json:"limit,omitempty" jsonschema:"minimum=1,maximum=100,default=10"`
+	Sort     string            `json:"sort,omitempty" jsonschema:"enum=relevance,enum=date"`
+	Tags     []string          `json:"tags,omitempty" jsonschema:"uniqueItems"`
+	Since    *time.Time        `json:"since"`
+	Filters  map[string]string `json:"filters,omitempty"`
+	Exact    bool              `json:"exact" jsonschema:"optional"`
+	Internal string            `json:"-"`
+	Debug    bool              `json:"debug" jsonschema:"-"`
+	paging
+}
+
+type paging struct {
+	Page  uint `json:"page,omitempty"`
+	Limit int  `json:"limit"` // shadowed by searchArgs.Limit
+}
+
+func TestReflect(t *testing.T) {
+	schema, err := Reflect(&searchArgs{})
+	if err != nil {
+		t.Fatalf("Reflect returned error: %v", err)
+	}
+
+	want := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"query": map[string]any{"type": "string", "description": "Search terms, in any language", "minLength": 1},
+			"limit": map[string]any{"type": "integer", "minimum": 1.0, "maximum": 100.0, "default": int64(10)},
+			"sort":  map[string]any{"type": "string", "enum": []any{"relevance", "date"}},
+			"tags": map[string]any{
+				"type":        "array",
+				"items":       map[string]any{"type": "string"},
+				"uniqueItems": true,
+			},
+			"since":   map[string]any{"type": []string{"string", "null"}, "format": "date-time"},
+			"filters": map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+			"exact":   map[string]any{"type": "boolean"},
+			"page":    map[string]any{"type": "integer", "minimum": 0.0},
+		},
+		"required": []string{"query"},
+	}
+
+	if got := schema.Map(); !reflect.DeepEqual(got, want) {
+		gotJSON, _ := json.MarshalIndent(got, "", "  ")
+		wantJSON, _ := json.MarshalIndent(want, "", "  ")
+		t.Errorf("Reflect() = %s, want %s", gotJSON, wantJSON)
+	}
+}
+
+func TestReflect_Validates(t *testing.T) {
+	schema, err := Reflect(searchArgs{})
+	if err != nil {
+		t.Fatalf("Reflect returned error: %v", err)
+	}
+	v, err := schema.Compile()
+	if err != nil {
+		t.Fatalf("Compile returned error: %v", err)
+	}
+
+	if err := v.Validate(searchArgs{Query: "go", Limit: 10, Sort: "date"}); err != nil {
+		t.Errorf("Validate returned error for a valid value: %v", err)
+	}
+	err = v.Validate(map[string]any{"query": "go", "sort": "popularity"})
+	if err == nil || err.Error() != `sort: must be one of "relevance", "date"` {
+		t.Errorf("Validate error = %v, want enum mismatch", err)
+	}
+}
+
+type treeNode struct {
+	Name     string      `json:"name"`
+	Children []*treeNode `json:"children,omitempty"`
+}
+
+func TestReflect_Recursive(t *testing.T) {
+	schema, err := Reflect(treeNode{})
+	if err != nil {
+		t.Fatalf("Reflect returned error: %v", err)
+	}
+
+	data, _ := json.Marshal(schema)
+	want := `{"$defs":{"treeNode":{"properties":{"children":{"items":{"$ref":"#/$defs/treeNode"},"type":"array"},` +
+		`"name":{"type":"string"}},"required":["name"],"type":"object"}},"$ref":"#/$defs/treeNode"}`
+	if string(data) != want {
+		t.Errorf("Reflect() = %s, want %s", data, want)
+	}
+
+	v, err := schema.Compile()
+	if err != nil {
+		t.Fatalf("Compile returned error: %v", err)
+	}
+	err = v.Validate(map[string]any{"name": "root", "children": []any{map[string]any{}}})
+	if err == nil || err.Error() != "children[0].name: is required" {
+		t.Errorf("Validate error = %v, want %q", err, "children[0].name: is required")
+	}
+}
+
+func TestReflect_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{"nil", nil},
+		{"unsupported type", struct {
+			C chan int `json:"c"`
+		}{}},
+		{"unknown tag option", struct {
+			A string `json:"a" jsonschema:"colour=red"`
+		}{}},
+		{"bad enum value", struct {
+			N int `json:"n" jsonschema:"enum=one"`
+		}{}},
+		{"bad minimum", struct {
+			N int `json:"n" jsonschema:"minimum=low"`
+		}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Reflect(tt.v); err == nil {
+				t.Error("Reflect expected error, got nil")
+			}
+		})
+	}
+}
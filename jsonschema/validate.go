@@ -0,0 +1,251 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validate validates v against the compiled schema. v may be decoded JSON
+// or any Go value that encodes to JSON, such as a map[string]any of tool
+// arguments or a struct. If v does not satisfy the schema, Validate returns
+// ValidationErrors listing every problem found.
+func (v *Validator) Validate(value any) error {
+	instance, err := normalize(value)
+	if err != nil {
+		return fmt.Errorf("encode value; %w", err)
+	}
+
+	var errs ValidationErrors
+	v.root.validate(instance, "", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validate appends the problems with instance, found at path, to errs.
+func (n *node) validate(instance any, path string, errs *ValidationErrors) {
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, &ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if n.always != nil {
+		if !*n.always {
+			fail("is not allowed")
+		}
+		return
+	}
+
+	if n.ref != nil {
+		n.ref.validate(instance, path, errs)
+	}
+
+	if len(n.types) > 0 && !n.matchesType(instance) {
+		fail("must be of type %s", strings.Join(n.types, " or "))
+		return
+	}
+	if n.enum != nil && !containsValue(n.enum, instance) {
+		fail("must be one of %s", formatValues(n.enum))
+	}
+	if n.hasConst && !equalValues(n.constVal, instance) {
+		fail("must equal %s", formatValues([]any{n.constVal}))
+	}
+
+	switch x := instance.(type) {
+	case map[string]any:
+		n.validateObject(x, path, errs)
+	case []any:
+		n.validateArray(x, path, errs)
+	case string:
+		length := utf8.RuneCountInString(x)
+		if n.minLength != nil && length < *n.minLength {
+			fail("must be at least %d characters long", *n.minLength)
+		}
+		if n.maxLength != nil && length > *n.maxLength {
+			fail("must be at most %d characters long", *n.maxLength)
+		}
+		if n.pattern != nil && !n.pattern.MatchString(x) {
+			fail("must match pattern %q", n.pattern.String())
+		}
+	case json.Number:
+		r, _ := toRat(x)
+		if n.minimum != nil && r.Cmp(n.minimum) < 0 {
+			fail("must be >= %s", n.minimum.RatString())
+		}
+		if n.maximum != nil && r.Cmp(n.maximum) > 0 {
+			fail("must be <= %s", n.maximum.RatString())
+		}
+		if n.exclusiveMinimum != nil && r.Cmp(n.exclusiveMinimum) <= 0 {
+			fail("must be > %s", n.exclusiveMinimum.RatString())
+		}
+		if n.exclusiveMaximum != nil && r.Cmp(n.exclusiveMaximum) >= 0 {
+			fail("must be < %s", n.exclusiveMaximum.RatString())
+		}
+		if n.multipleOf != nil && !new(big.Rat).Quo(r, n.multipleOf).IsInt() {
+			fail("must be a multiple of %s", n.multipleOf.RatString())
+		}
+	}
+
+	for _, sub := range n.allOf {
+		sub.validate(instance, path, errs)
+	}
+	if n.anyOf != nil && n.countMatches(n.anyOf, instance, path) == 0 {
+		fail("must match at least one schema in anyOf")
+	}
+	if n.oneOf != nil {
+		if matches := n.countMatches(n.oneOf, instance, path); matches != 1 {
+			fail("must match exactly one schema in oneOf, matched %d", matches)
+		}
+	}
+	if n.not != nil && n.not.matches(instance, path) {
+		fail("must not match the schema in not")
+	}
+}
+
+func (n *node) validateObject(obj map[string]any, path string, errs *ValidationErrors) {
+	for _, name := range n.required {
+		if _, ok := obj[name]; !ok {
+			*errs = append(*errs, &ValidationError{Path: joinPath(path, name), Message: "is required"})
+		}
+	}
+
+	for _, name := range sortedKeys(obj) {
+		if prop, ok := n.properties[name]; ok {
+			prop.validate(obj[name], joinPath(path, name), errs)
+		} else if n.additionalProperties != nil {
+			n.additionalProperties.validate(obj[name], joinPath(path, name), errs)
+		}
+	}
+}
+
+func (n *node) validateArray(arr []any, path string, errs *ValidationErrors) {
+	if n.minItems != nil && len(arr) < *n.minItems {
+		*errs = append(*errs, &ValidationError{Path: path, Message: fmt.Sprintf("must have at least %d items", *n.minItems)})
+	}
+	if n.maxItems != nil && len(arr) > *n.maxItems {
+		*errs = append(*errs, &ValidationError{Path: path, Message: fmt.Sprintf("must have at most %d items", *n.maxItems)})
+	}
+	if n.uniqueItems {
+		for i := 1; i < len(arr); i++ {
+			if containsValue(arr[:i], arr[i]) {
+				*errs = append(*errs, &ValidationError{Path: indexPath(path, i), Message: "must not duplicate an earlier item"})
+			}
+		}
+	}
+	if n.items != nil {
+		for i, item := range arr {
+			n.items.validate(item, indexPath(path, i), errs)
+		}
+	}
+}
+
+// matches reports whether instance satisfies n.
+func (n *node) matches(instance any, path string) bool {
+	var errs ValidationErrors
+	n.validate(instance, path, &errs)
+	return len(errs) == 0
+}
+
+func (n *node) countMatches(nodes []*node, instance any, path string) int {
+	matches := 0
+	for _, sub := range nodes {
+		if sub.matches(instance, path) {
+			matches++
+		}
+	}
+	return matches
+}
+
+func (n *node) matchesType(instance any) bool {
+	for _, t := range n.types {
+		if typeOf(instance) == t || (t == "number" && typeOf(instance) == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// typeOf returns the JSON Schema type of a normalized value. Numbers with
+// no fractional part, such as 1.0, are integers.
+func typeOf(instance any) string {
+	switch x := instance.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	case json.Number:
+		if r, ok := toRat(x); ok && r.IsInt() {
+			return "integer"
+		}
+		return "number"
+	}
+	return ""
+}
+
+// equalValues reports whether two normalized values are equal as JSON
+// values. Numbers are compared by value, so 1 and 1.0 are equal.
+func equalValues(a, b any) bool {
+	switch x := a.(type) {
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		rx, okx := toRat(x)
+		ry, oky := toRat(y)
+		return okx && oky && rx.Cmp(ry) == 0
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equalValues(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, vx := range x {
+			vy, ok := y[k]
+			if !ok || !equalValues(vx, vy) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+func containsValue(values []any, v any) bool {
+	for _, candidate := range values {
+		if equalValues(candidate, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatValues formats values as a comma-separated list of JSON literals.
+func formatValues(values []any) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		data, _ := json.Marshal(v)
+		parts[i] = string(data)
+	}
+	return strings.Join(parts, ", ")
+}
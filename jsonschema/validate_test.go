@@ -0,0 +1,245 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func mustCompile(t *testing.T, schema map[string]any) *Validator {
+	t.Helper()
+	v, err := Compile(schema)
+	if err != nil {
+		t.Fatalf("Compile returned error: %v", err)
+	}
+	return v
+}
+
+func TestValidator_Validate(t *testing.T) {
+	v := mustCompile(t, map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"query": map[string]any{"type": "string", "minLength": 1},
+			"limit": map[string]any{"type": "integer", "minimum": 1, "maximum": 100},
+			"units": map[string]any{"enum": []any{"metric", "imperial"}},
+			"address": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"zip": map[string]any{"type": "string", "pattern": `^\d{5}$`},
+				},
+				"required": []any{"zip"},
+			},
+			"tags": map[string]any{
+				"type":        "array",
+				"items":       map[string]any{"type": "string"},
+				"uniqueItems": true,
+				"maxItems":    3,
+			},
+		},
+		"required":             []any{"query"},
+		"additionalProperties": false,
+	})
+
+	tests := []struct {
+		name string
+		args map[string]any
+		want []string
+	}{
+		{
+			name: "valid",
+			args: map[string]any{"query": "go", "limit": 10, "units": "metric", "address": map[string]any{"zip": "12345"}, "tags": []string{"a", "b"}},
+		},
+		{
+			name: "integral float is an integer",
+			args: map[string]any{"query": "go", "limit": 10.0},
+		},
+		{
+			name: "missing required",
+			args: map[string]any{"limit": 10},
+			want: []string{"query: is required"},
+		},
+		{
+			name: "wrong types",
+			args: map[string]any{"query": 1, "limit": 2.5},
+			want: []string{"limit: must be of type integer", "query: must be of type string"},
+		},
+		{
+			name: "constraints",
+			args: map[string]any{"query": "", "limit": 101, "units": "kelvin"},
+			want: []string{
+				"limit: must be <= 100",
+				`query: must be at least 1 characters long`,
+				`units: must be one of "metric", "imperial"`,
+			},
+		},
+		{
+			name: "nested object",
+			args: map[string]any{"query": "go", "address": map[string]any{"zip": "abc"}},
+			want: []string{`address.zip: must match pattern "^\\d{5}$"`},
+		},
+		{
+			name: "nested required",
+			args: map[string]any{"query": "go", "address": map[string]any{}},
+			want: []string{"address.zip: is required"},
+		},
+		{
+			name: "array items",
+			args: map[string]any{"query": "go", "tags": []any{"a", 1, "a", "b"}},
+			want: []string{
+				"tags: must have at most 3 items",
+				"tags[2]: must not duplicate an earlier item",
+				"tags[1]: must be of type string",
+			},
+		},
+		{
+			name: "additional property",
+			args: map[string]any{"query": "go", "extra": true},
+			want: []string{"extra: is not allowed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.args)
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("Validate returned error: %v", err)
+				}
+				return
+			}
+
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Validate error = %v, want ValidationErrors", err)
+			}
+			got := make([]string, len(errs))
+			for i, e := range errs {
+				got[i] = e.Error()
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate errors = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidator_Validate_Combinators(t *testing.T) {
+	v := mustCompile(t, map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			// Optional fields in schemas generated by pydantic.
+			"timeout": map[string]any{"anyOf": []any{map[string]any{"type": "number"}, map[string]any{"type": "null"}}},
+			"target": map[string]any{"oneOf": []any{
+				map[string]any{"type": "string"},
+				map[string]any{"type": "integer", "minimum": 0},
+			}},
+			"name": map[string]any{"allOf": []any{
+				map[string]any{"type": "string"},
+				map[string]any{"maxLength": 3},
+			}},
+			"mode": map[string]any{"not": map[string]any{"const": "unsafe"}},
+		},
+	})
+
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"anyOf null", map[string]any{"timeout": nil}, ""},
+		{"anyOf number", map[string]any{"timeout": 1.5}, ""},
+		{"anyOf mismatch", map[string]any{"timeout": "1s"}, "timeout: must match at least one schema in anyOf"},
+		{"oneOf match", map[string]any{"target": 3}, ""},
+		{"oneOf none", map[string]any{"target": -1}, "target: must match exactly one schema in oneOf, matched 0"},
+		{"allOf", map[string]any{"name": "abcd"}, "name: must be at most 3 characters long"},
+		{"not", map[string]any{"mode": "unsafe"}, "mode: must not match the schema in not"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.args)
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.want {
+				t.Errorf("Validate error = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidator_Validate_Ref(t *testing.T) {
+	v := mustCompile(t, map[string]any{
+		"$ref": "#/$defs/node",
+		"$defs": map[string]any{
+			"node": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name":     map[string]any{"type": "string"},
+					"children": map[string]any{"type": "array", "items": map[string]any{"$ref": "#/$defs/node"}},
+				},
+				"required": []any{"name"},
+			},
+		},
+	})
+
+	tree := map[string]any{
+		"name": "root",
+		"children": []any{
+			map[string]any{"name": "a"},
+			map[string]any{"children": []any{}},
+		},
+	}
+	err := v.Validate(tree)
+	if err == nil || err.Error() != "children[1].name: is required" {
+		t.Errorf("Validate error = %v, want %q", err, "children[1].name: is required")
+	}
+}
+
+func TestValidator_Validate_Numbers(t *testing.T) {
+	v := mustCompile(t, map[string]any{
+		"type":             "number",
+		"exclusiveMinimum": 0,
+		"multipleOf":       0.1,
+	})
+
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{0.3, ""},
+		{json.Number("2.5"), ""},
+		{0, "must be > 0"},
+		{0.25, "must be a multiple of 1/10"},
+		{"1", "must be of type number"},
+	}
+
+	for _, tt := range tests {
+		err := v.Validate(tt.value)
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tt.want {
+			t.Errorf("Validate(%v) error = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestValidate_Struct(t *testing.T) {
+	type args struct {
+		City string `json:"city"`
+		Days int    `json:"days,omitempty"`
+	}
+	schema := map[string]any{
+		"type":     "object",
+		"required": []any{"city", "days"},
+	}
+
+	err := Validate(schema, args{City: "Paris"})
+	if err == nil || err.Error() != "days: is required" {
+		t.Errorf("Validate error = %v, want %q", err, "days: is required")
+	}
+}
@@ -0,0 +1,41 @@
+// Package otelcontextforge instruments a contextforge.Client with
+// OpenTelemetry tracing and metrics. It is a module of its own, so that the
+// contextforge module does not depend on OpenTelemetry.
+//
+// An Instrumentation is set as the client's contextforge.Instrumentation:
+//
+//	inst, err := otelcontextforge.New(
+//		otelcontextforge.WithTracerProvider(tp),
+//		otelcontextforge.WithMeterProvider(mp),
+//	)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	client, err := contextforge.NewClientWithOptions(address,
+//		contextforge.WithBearerToken(token),
+//		contextforge.WithInstrumentation(inst),
+//	)
+//
+// # Traces
+//
+// Every API request starts a client span, a child of the span in the
+// request's context, named after the SDK method it is made for, such as
+// "contextforge.Tools.List". The span records the HTTP method, URL, server
+// address, response status, number of attempts, and the ID of the entity
+// addressed, if any (OperationKey, EntityIDKey, AttemptsKey). Failed
+// requests, including those completing with a 4xx or 5xx status, set the
+// span status to Error.
+//
+// The trace context is injected into the request headers, as W3C
+// traceparent and tracestate by default, so spans recorded by ContextForge
+// join the caller's trace. Retries of a request share its span. The span of
+// a streamed request, such as AgentsService.InvokeStream, ends when
+// the response headers arrive.
+//
+// # Metrics
+//
+// Each request records its duration in the DurationMetric histogram, and
+// failed requests increment the ErrorsMetric counter, with the operation,
+// HTTP method, response status, and error type as attributes.
+package otelcontextforge
@@ -0,0 +1,202 @@
+package otelcontextforge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter used by
+// Instrumentation.
+const ScopeName = "github.com/leefowlercu/go-contextforge/otelcontextforge"
+
+// Attribute keys specific to ContextForge requests.
+const (
+	// OperationKey is the SDK method a request is made for, such as
+	// "Tools.List".
+	OperationKey = attribute.Key("contextforge.operation")
+
+	// EntityIDKey is the ID or name of the entity a request addresses.
+	EntityIDKey = attribute.Key("contextforge.entity_id")
+
+	// AttemptsKey is the number of attempts made to send a request,
+	// including retries.
+	AttemptsKey = attribute.Key("contextforge.attempts")
+)
+
+// Names of the instruments recorded by Instrumentation.
+const (
+	// DurationMetric is a histogram of the duration of requests in seconds,
+	// including retries.
+	DurationMetric = "contextforge.client.request.duration"
+
+	// ErrorsMetric counts the requests that failed or completed with a
+	// 4xx or 5xx status.
+	ErrorsMetric = "contextforge.client.request.errors"
+)
+
+// Option configures an Instrumentation created by New.
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagator     propagation.TextMapPropagator
+}
+
+// WithTracerProvider sets the provider of the tracer that records spans.
+// The default is the global provider, otel.GetTracerProvider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the provider of the meter that records metrics.
+// The default is the global provider, otel.GetMeterProvider.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// WithPropagator sets the propagator that injects the trace context into
+// request headers. The default is propagation.TraceContext, which sends
+// the W3C traceparent and tracestate headers.
+func WithPropagator(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagator = p
+	}
+}
+
+// Instrumentation traces and measures the requests of a contextforge.Client.
+// It implements contextforge.Instrumentation. Create one with New.
+type Instrumentation struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	duration   metric.Float64Histogram
+	errors     metric.Int64Counter
+}
+
+var _ contextforge.Instrumentation = (*Instrumentation)(nil)
+
+// New returns an Instrumentation that records spans and metrics with the
+// configured providers.
+func New(opts ...Option) (*Instrumentation, error) {
+	c := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagator:     propagation.TraceContext{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	meter := c.meterProvider.Meter(ScopeName)
+	duration, err := meter.Float64Histogram(DurationMetric,
+		metric.WithDescription("Duration of ContextForge API requests, including retries."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create %s histogram; %w", DurationMetric, err)
+	}
+	errs, err := meter.Int64Counter(ErrorsMetric,
+		metric.WithDescription("Number of ContextForge API requests that failed."),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create %s counter; %w", ErrorsMetric, err)
+	}
+
+	return &Instrumentation{
+		tracer:     c.tracerProvider.Tracer(ScopeName),
+		propagator: c.propagator,
+		duration:   duration,
+		errors:     errs,
+	}, nil
+}
+
+// Start starts a client span for req, named after the SDK method it is made
+// for, such as "contextforge.Tools.List", and injects the trace context
+// into its headers. The returned function ends the span and records the
+// request's metrics.
+func (i *Instrumentation) Start(ctx context.Context, req *http.Request) (context.Context, func(*contextforge.Response, error)) {
+	name := "contextforge." + req.Method
+	spanAttrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLFull(contextforge.SanitizeURL(req.URL).String()),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}
+	if port, err := strconv.Atoi(req.URL.Port()); err == nil {
+		spanAttrs = append(spanAttrs, semconv.ServerPort(port))
+	}
+	metricAttrs := []attribute.KeyValue{semconv.HTTPRequestMethodKey.String(req.Method)}
+	if op, ok := contextforge.OperationFromContext(ctx); ok {
+		name = "contextforge." + op.String()
+		spanAttrs = append(spanAttrs, OperationKey.String(op.String()))
+		if op.EntityID != "" {
+			spanAttrs = append(spanAttrs, EntityIDKey.String(op.EntityID))
+		}
+		// Entity IDs are left out of metrics to bound their cardinality.
+		metricAttrs = append(metricAttrs, OperationKey.String(op.String()))
+	}
+
+	ctx, span := i.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(spanAttrs...),
+	)
+	i.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	start := time.Now()
+
+	return ctx, func(resp *contextforge.Response, err error) {
+		defer span.End()
+
+		if resp != nil {
+			status := semconv.HTTPResponseStatusCode(resp.StatusCode)
+			span.SetAttributes(status, AttemptsKey.Int(resp.Attempts))
+			metricAttrs = append(metricAttrs, status)
+		}
+		if errType := errorType(resp, err); errType != "" {
+			metricAttrs = append(metricAttrs, semconv.ErrorTypeKey.String(errType))
+			span.SetAttributes(semconv.ErrorTypeKey.String(errType))
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			} else {
+				span.SetStatus(codes.Error, resp.Status)
+			}
+			i.errors.Add(ctx, 1, metric.WithAttributes(metricAttrs...))
+		}
+		i.duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(metricAttrs...))
+	}
+}
+
+// errorType returns the error.type of a failed request: the status code of
+// a 4xx or 5xx response, or the type of err. It returns "" if the request
+// succeeded.
+func errorType(resp *contextforge.Response, err error) string {
+	switch {
+	case resp != nil && resp.StatusCode >= 400:
+		return strconv.Itoa(resp.StatusCode)
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case err != nil:
+		return fmt.Sprintf("%T", err)
+	}
+	return ""
+}
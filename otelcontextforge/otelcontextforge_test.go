@@ -0,0 +1,281 @@
+package otelcontextforge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+type fixture struct {
+	client      *contextforge.Client
+	spans       *tracetest.InMemoryExporter
+	metrics     *sdkmetric.ManualReader
+	tracer      trace.Tracer
+	traceparent []string // received by the server, in order
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := &fixture{
+		spans:   tracetest.NewInMemoryExporter(),
+		metrics: sdkmetric.NewManualReader(),
+	}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(f.spans))
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(f.metrics))
+	f.tracer = tp.Tracer("test")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /tools", func(w http.ResponseWriter, r *http.Request) {
+		f.traceparent = append(f.traceparent, r.Header.Get("traceparent"))
+		fmt.Fprint(w, `[{"id":"t1","name":"search"}]`)
+	})
+	mux.HandleFunc("GET /tools/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.traceparent = append(f.traceparent, r.Header.Get("traceparent"))
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"detail":"Tool not found"}`)
+	})
+	mux.HandleFunc("POST /servers/{id}/mcp", func(w http.ResponseWriter, r *http.Request) {
+		var msg struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		json.NewDecoder(r.Body).Decode(&msg)
+		if msg.ID == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		result := `{"content":[]}`
+		if msg.Method == "initialize" {
+			result = `{"protocolVersion":"2025-06-18","capabilities":{}}`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%s}`, msg.ID, result)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	inst, err := New(WithTracerProvider(tp), WithMeterProvider(mp))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	f.client, err = contextforge.NewClientWithOptions(server.URL,
+		contextforge.WithBearerToken("test-token"),
+		contextforge.WithInstrumentation(inst),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func attrs(kvs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value)
+	for _, kv := range kvs {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func TestInstrumentation_Spans(t *testing.T) {
+	f := newFixture(t)
+	ctx, parent := f.tracer.Start(context.Background(), "parent")
+
+	if _, _, err := f.client.Tools.List(ctx, nil); err != nil {
+		t.Fatalf("Tools.List returned error: %v", err)
+	}
+	if _, _, err := f.client.Tools.Get(ctx, "t1"); err == nil {
+		t.Fatal("Tools.Get returned no error")
+	}
+	parent.End()
+
+	spans := f.spans.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("recorded %d spans, want 3", len(spans))
+	}
+	list, get := spans[0], spans[1]
+
+	tests := []struct {
+		span       tracetest.SpanStub
+		name       string
+		status     codes.Code
+		statusCode int64
+		entityID   string
+	}{
+		{list, "contextforge.Tools.List", codes.Unset, 200, ""},
+		{get, "contextforge.Tools.Get", codes.Error, 404, "t1"},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.span
+			if s.Name != tt.name {
+				t.Errorf("Name = %q, want %q", s.Name, tt.name)
+			}
+			if s.SpanKind != trace.SpanKindClient {
+				t.Errorf("SpanKind = %v, want client", s.SpanKind)
+			}
+			if s.Parent.SpanID() != parent.SpanContext().SpanID() {
+				t.Error("span is not a child of the span in the request context")
+			}
+			if s.Status.Code != tt.status {
+				t.Errorf("Status = %v, want %v", s.Status.Code, tt.status)
+			}
+
+			a := attrs(s.Attributes)
+			if got := a["http.response.status_code"].AsInt64(); got != tt.statusCode {
+				t.Errorf("http.response.status_code = %d, want %d", got, tt.statusCode)
+			}
+			if got := a["http.request.method"].AsString(); got != http.MethodGet {
+				t.Errorf("http.request.method = %q, want GET", got)
+			}
+			if got := a[OperationKey].AsString(); "contextforge."+got != tt.name {
+				t.Errorf("%s = %q, want the operation of %s", OperationKey, got, tt.name)
+			}
+			if got := a[EntityIDKey].AsString(); got != tt.entityID {
+				t.Errorf("%s = %q, want %q", EntityIDKey, got, tt.entityID)
+			}
+			if got := a[AttemptsKey].AsInt64(); got != 1 {
+				t.Errorf("%s = %d, want 1", AttemptsKey, got)
+			}
+
+			want := fmt.Sprintf("00-%s-%s-01", s.SpanContext.TraceID(), s.SpanContext.SpanID())
+			if f.traceparent[i] != want {
+				t.Errorf("server received traceparent %q, want %q", f.traceparent[i], want)
+			}
+		})
+	}
+}
+
+func TestInstrumentation_MCPSession(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	session, err := f.client.Servers.Connect(ctx, "srv-1", nil)
+	if err != nil {
+		t.Fatalf("Servers.Connect returned error: %v", err)
+	}
+	if _, err := session.CallTool(ctx, "search", nil); err != nil {
+		t.Fatalf("CallTool returned error: %v", err)
+	}
+
+	var names []string
+	for _, s := range f.spans.GetSpans() {
+		names = append(names, s.Name)
+		if got := attrs(s.Attributes)[EntityIDKey].AsString(); got != "srv-1" {
+			t.Errorf("span %s %s = %q, want srv-1", s.Name, EntityIDKey, got)
+		}
+	}
+	want := "[contextforge.MCP.initialize contextforge.MCP.notifications/initialized contextforge.MCP.tools/call]"
+	if fmt.Sprint(names) != want {
+		t.Errorf("spans = %v, want %s", names, want)
+	}
+}
+
+func TestInstrumentation_RedactsSecrets(t *testing.T) {
+	f := newFixture(t)
+	f.client.Teams.AcceptInvitation(context.Background(), "invitation-secret")
+
+	spans := f.spans.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+	for _, kv := range spans[0].Attributes {
+		if strings.Contains(kv.Value.Emit(), "invitation-secret") {
+			t.Errorf("span attribute %s = %q contains the invitation token", kv.Key, kv.Value.Emit())
+		}
+	}
+	if got := attrs(spans[0].Attributes)["url.full"].AsString(); !strings.HasSuffix(got, "/teams/invitations/REDACTED/accept/") {
+		t.Errorf("url.full = %q, want the token redacted", got)
+	}
+}
+
+func TestInstrumentation_Metrics(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	for range 2 {
+		if _, _, err := f.client.Tools.List(ctx, nil); err != nil {
+			t.Fatalf("Tools.List returned error: %v", err)
+		}
+	}
+	f.client.Tools.Get(ctx, "t1")
+
+	var rm metricdata.ResourceMetrics
+	if err := f.metrics.Collect(ctx, &rm); err != nil {
+		t.Fatal(err)
+	}
+	durations := make(map[string]uint64)
+	errors := make(map[string]int64)
+	for _, sm := range rm.ScopeMetrics {
+		if sm.Scope.Name != ScopeName {
+			t.Errorf("scope = %q, want %q", sm.Scope.Name, ScopeName)
+		}
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Histogram[float64]:
+				if m.Name != DurationMetric {
+					t.Errorf("histogram %q, want %q", m.Name, DurationMetric)
+				}
+				for _, dp := range data.DataPoints {
+					op, _ := dp.Attributes.Value(OperationKey)
+					durations[op.AsString()] += dp.Count
+				}
+			case metricdata.Sum[int64]:
+				if m.Name != ErrorsMetric {
+					t.Errorf("counter %q, want %q", m.Name, ErrorsMetric)
+				}
+				for _, dp := range data.DataPoints {
+					op, _ := dp.Attributes.Value(OperationKey)
+					errType, _ := dp.Attributes.Value("error.type")
+					errors[op.AsString()+" "+errType.AsString()] += dp.Value
+				}
+			}
+		}
+	}
+
+	if fmt.Sprint(durations) != "map[Tools.Get:1 Tools.List:2]" {
+		t.Errorf("duration counts = %v, want 2 Tools.List and 1 Tools.Get", durations)
+	}
+	if fmt.Sprint(errors) != "map[Tools.Get 404:1]" {
+		t.Errorf("error counts = %v, want 1 Tools.Get 404", errors)
+	}
+}
+
+func TestInstrumentation_TransportError(t *testing.T) {
+	f := newFixture(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, _, err := f.client.Tools.List(ctx, nil); err == nil {
+		t.Fatal("Tools.List returned no error")
+	}
+
+	spans := f.spans.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+	s := spans[0]
+	if s.Status.Code != codes.Error {
+		t.Errorf("Status = %v, want error", s.Status.Code)
+	}
+	if got := attrs(s.Attributes)["error.type"].AsString(); got != "canceled" {
+		t.Errorf("error.type = %q, want %q", got, "canceled")
+	}
+	if len(s.Events) == 0 || s.Events[0].Name != "exception" {
+		t.Errorf("Events = %v, want the recorded error", s.Events)
+	}
+}
@@ -0,0 +1,274 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Kind identifies the kind of entity a Change applies to.
+type Kind string
+
+// Entity kinds, in the order they appear in a Report.
+const (
+	KindGateway  Kind = "gateway"
+	KindTool     Kind = "tool"
+	KindResource Kind = "resource"
+	KindPrompt   Kind = "prompt"
+	KindServer   Kind = "server"
+	KindAgent    Kind = "agent"
+	KindTeam     Kind = "team"
+)
+
+// Action is what happened to an entity between two snapshots.
+type Action string
+
+// Actions of a Change.
+const (
+	Added    Action = "added"
+	Removed  Action = "removed"
+	Modified Action = "modified"
+)
+
+// Change is a difference in one entity between two snapshots.
+type Change struct {
+	Kind   Kind
+	Action Action
+
+	// Name identifies the entity: its name, or its URI for resources. When
+	// several entities of a kind share a name, their ID is appended.
+	Name string
+
+	// Fields lists the fields that differ, for modified entities.
+	Fields []FieldChange
+}
+
+// FieldChange is a difference in one field of a modified entity.
+type FieldChange struct {
+	// Path is the JSON name of the field. Fields of nested objects are
+	// separated by dots, as in "inputSchema.properties.query".
+	Path string
+
+	// Old and New are the JSON values of the field, or nil if it is unset.
+	Old, New any
+}
+
+// ignoredFields are the JSON names of fields that change without the catalog
+// changing: audit metadata, usage metrics, and health-check times.
+var ignoredFields = map[string]bool{
+	"createdAt": true, "created_at": true, "updatedAt": true, "updated_at": true,
+	"createdBy": true, "created_by": true, "createdFromIp": true, "createdVia": true, "createdUserAgent": true,
+	"modifiedBy": true, "modifiedFromIp": true, "modifiedVia": true, "modifiedUserAgent": true,
+	"importBatchId": true, "version": true, "metrics": true,
+	"lastSeen": true, "lastRefreshAt": true, "lastInteraction": true,
+}
+
+// Report lists the changes between two snapshots, grouped by kind and sorted
+// by name within each kind.
+type Report struct {
+	Changes []Change
+}
+
+// Diff compares two snapshots field by field and reports the entities added,
+// removed, and modified in to relative to from. Entities are matched by name,
+// or by URI for resources. Audit fields such as updatedAt and modifiedFromIp,
+// and usage metrics, are ignored.
+func Diff(from, to *Snapshot) (*Report, error) {
+	r := &Report{}
+	kinds := []struct {
+		kind     Kind
+		key      string
+		from, to any
+	}{
+		{KindGateway, "name", from.Gateways, to.Gateways},
+		{KindTool, "name", from.Tools, to.Tools},
+		{KindResource, "uri", from.Resources, to.Resources},
+		{KindPrompt, "name", from.Prompts, to.Prompts},
+		{KindServer, "name", from.Servers, to.Servers},
+		{KindAgent, "name", from.Agents, to.Agents},
+		{KindTeam, "name", from.Teams, to.Teams},
+	}
+	for _, k := range kinds {
+		before, err := objects(k.from)
+		if err != nil {
+			return nil, fmt.Errorf("compare %ss; %w", k.kind, err)
+		}
+		after, err := objects(k.to)
+		if err != nil {
+			return nil, fmt.Errorf("compare %ss; %w", k.kind, err)
+		}
+		dup := duplicates(k.key, before, after)
+		r.Changes = append(r.Changes, diffKind(k.kind, index(before, k.key, dup), index(after, k.key, dup))...)
+	}
+	return r, nil
+}
+
+// objects converts items, a slice of entity pointers, to JSON objects.
+func objects(items any) ([]map[string]any, error) {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	var objs []map[string]any
+	if err := json.Unmarshal(data, &objs); err != nil {
+		return nil, err
+	}
+	return objs, nil
+}
+
+// duplicates returns the values of the field named key shared by several
+// objects in either snapshot.
+func duplicates(key string, before, after []map[string]any) map[string]bool {
+	dup := make(map[string]bool)
+	for _, objs := range [][]map[string]any{before, after} {
+		seen := make(map[string]bool)
+		for _, obj := range objs {
+			name := fmt.Sprint(obj[key])
+			dup[name] = dup[name] || seen[name]
+			seen[name] = true
+		}
+	}
+	return dup
+}
+
+// index keys objs by the field named key, appending the ID to duplicated
+// values so that each entity keeps a distinct name.
+func index(objs []map[string]any, key string, dup map[string]bool) map[string]map[string]any {
+	idx := make(map[string]map[string]any, len(objs))
+	for _, obj := range objs {
+		name := fmt.Sprint(obj[key])
+		if dup[name] {
+			name = fmt.Sprintf("%s (%v)", name, obj["id"])
+		}
+		idx[name] = obj
+	}
+	return idx
+}
+
+func diffKind(kind Kind, before, after map[string]map[string]any) []Change {
+	names := make([]string, 0, len(before)+len(after))
+	for name := range before {
+		names = append(names, name)
+	}
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var changes []Change
+	for _, name := range names {
+		old, inOld := before[name]
+		cur, inNew := after[name]
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Kind: kind, Action: Added, Name: name})
+		case !inNew:
+			changes = append(changes, Change{Kind: kind, Action: Removed, Name: name})
+		default:
+			if fields := diffObjects("", old, cur); len(fields) > 0 {
+				changes = append(changes, Change{Kind: kind, Action: Modified, Name: name, Fields: fields})
+			}
+		}
+	}
+	return changes
+}
+
+// diffObjects compares two JSON objects, descending into fields that are
+// objects on both sides. Top-level ignored fields are skipped.
+func diffObjects(prefix string, before, after map[string]any) []FieldChange {
+	keys := make([]string, 0, len(before)+len(after))
+	for k := range before {
+		keys = append(keys, k)
+	}
+	for k := range after {
+		if _, ok := before[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	var fields []FieldChange
+	for _, k := range keys {
+		if prefix == "" && ignoredFields[k] {
+			continue
+		}
+		path := prefix + k
+		o, n := before[k], after[k]
+		if om, ok := o.(map[string]any); ok {
+			if nm, ok := n.(map[string]any); ok {
+				fields = append(fields, diffObjects(path+".", om, nm)...)
+				continue
+			}
+		}
+		if !reflect.DeepEqual(o, n) {
+			fields = append(fields, FieldChange{Path: path, Old: o, New: n})
+		}
+	}
+	return fields
+}
+
+// Empty reports whether the snapshots compared are equivalent.
+func (r *Report) Empty() bool {
+	return len(r.Changes) == 0
+}
+
+// String renders the report for review, one entity per line with the fields
+// of modified entities indented below it:
+//
+//	Diff: 1 added, 1 removed, 1 modified.
+//	+ tool "weather"
+//	- prompt "greet"
+//	~ tool "search"
+//	    description: "Search docs" -> "Search the docs"
+func (r *Report) String() string {
+	if r.Empty() {
+		return "No changes.\n"
+	}
+
+	counts := make(map[Action]int)
+	for _, c := range r.Changes {
+		counts[c.Action]++
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Diff: %d added, %d removed, %d modified.\n",
+		counts[Added], counts[Removed], counts[Modified])
+	for _, c := range r.Changes {
+		b.WriteString(c.String())
+		b.WriteByte('\n')
+		for _, f := range c.Fields {
+			fmt.Fprintf(&b, "    %s\n", f)
+		}
+	}
+	return b.String()
+}
+
+// String returns the change without its fields, such as `~ tool "search"`.
+func (c Change) String() string {
+	sign := map[Action]string{Added: "+", Removed: "-", Modified: "~"}[c.Action]
+	return fmt.Sprintf("%s %s %q", sign, c.Kind, c.Name)
+}
+
+// String returns the field change as `path: old -> new`, with the values in
+// compact JSON and unset values shown as (unset).
+func (f FieldChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", f.Path, formatValue(f.Old), formatValue(f.New))
+}
+
+func formatValue(v any) string {
+	if v == nil {
+		return "(unset)"
+	}
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
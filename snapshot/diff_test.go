@@ -0,0 +1,114 @@
+package snapshot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+func TestDiff(t *testing.T) {
+	t0 := &contextforge.Timestamp{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	t1 := &contextforge.Timestamp{Time: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name     string
+		from, to *Snapshot
+		want     []Change
+	}{
+		{
+			name: "identical",
+			from: &Snapshot{Tools: []*contextforge.Tool{{ID: "t1", Name: "search"}}},
+			to:   &Snapshot{Tools: []*contextforge.Tool{{ID: "t1", Name: "search"}}},
+		},
+		{
+			name: "added and removed",
+			from: &Snapshot{Prompts: []*contextforge.Prompt{{ID: "p1", Name: "greet"}}},
+			to:   &Snapshot{Tools: []*contextforge.Tool{{ID: "t1", Name: "search"}}},
+			want: []Change{
+				{Kind: KindTool, Action: Added, Name: "search"},
+				{Kind: KindPrompt, Action: Removed, Name: "greet"},
+			},
+		},
+		{
+			name: "modified fields",
+			from: &Snapshot{Tools: []*contextforge.Tool{{
+				ID: "t1", Name: "search", Description: contextforge.String("Search docs"), Enabled: true,
+				InputSchema: map[string]any{"type": "object", "properties": map[string]any{"q": map[string]any{"type": "string"}}},
+			}}},
+			to: &Snapshot{Tools: []*contextforge.Tool{{
+				ID: "t1", Name: "search", Enabled: false, Tags: []contextforge.Tag{{ID: "docs", Label: "docs"}},
+				InputSchema: map[string]any{"type": "object", "properties": map[string]any{"q": map[string]any{"type": "string"}, "n": map[string]any{"type": "integer"}}},
+			}}},
+			want: []Change{{Kind: KindTool, Action: Modified, Name: "search", Fields: []FieldChange{
+				{Path: "description", Old: "Search docs"},
+				{Path: "enabled", Old: true},
+				{Path: "inputSchema.properties.n", New: map[string]any{"type": "integer"}},
+				{Path: "tags", New: []any{"docs"}},
+			}}},
+		},
+		{
+			name: "audit fields ignored",
+			from: &Snapshot{Gateways: []*contextforge.Gateway{{
+				Name: "weather", URL: "http://weather", UpdatedAt: t0, ModifiedFromIP: contextforge.String("10.0.0.1"), LastSeen: t0,
+			}}},
+			to: &Snapshot{Gateways: []*contextforge.Gateway{{
+				Name: "weather", URL: "http://weather", UpdatedAt: t1, ModifiedFromIP: contextforge.String("10.0.0.2"), LastSeen: t1,
+				Version: contextforge.Int(2),
+			}}},
+		},
+		{
+			name: "resources matched by URI",
+			from: &Snapshot{Resources: []*contextforge.Resource{{URI: "file:///a", Name: "old"}}},
+			to:   &Snapshot{Resources: []*contextforge.Resource{{URI: "file:///a", Name: "new"}}},
+			want: []Change{{Kind: KindResource, Action: Modified, Name: "file:///a", Fields: []FieldChange{
+				{Path: "name", Old: "old", New: "new"},
+			}}},
+		},
+		{
+			name: "duplicate names disambiguated by ID",
+			from: &Snapshot{Teams: []*contextforge.Team{{ID: "tm1", Name: "Ops"}, {ID: "tm2", Name: "Ops"}}},
+			to:   &Snapshot{Teams: []*contextforge.Team{{ID: "tm1", Name: "Ops"}}},
+			want: []Change{{Kind: KindTeam, Action: Removed, Name: "Ops (tm2)"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := Diff(tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("Diff returned error: %v", err)
+			}
+			if !reflect.DeepEqual(report.Changes, tt.want) {
+				t.Errorf("Diff changes = %#v, want %#v", report.Changes, tt.want)
+			}
+		})
+	}
+}
+
+func TestReport_String(t *testing.T) {
+	report := &Report{Changes: []Change{
+		{Kind: KindTool, Action: Added, Name: "weather"},
+		{Kind: KindTool, Action: Modified, Name: "search", Fields: []FieldChange{
+			{Path: "description", Old: "Search docs", New: "Search the docs"},
+			{Path: "inputSchema.properties.n", New: map[string]any{"type": "integer"}},
+		}},
+		{Kind: KindPrompt, Action: Removed, Name: "greet"},
+	}}
+
+	want := `Diff: 1 added, 1 removed, 1 modified.
++ tool "weather"
+~ tool "search"
+    description: "Search docs" -> "Search the docs"
+    inputSchema.properties.n: (unset) -> {"type":"integer"}
+- prompt "greet"
+`
+	if got := report.String(); got != want {
+		t.Errorf("String() =\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := (&Report{}).String(); got != "No changes.\n" {
+		t.Errorf("String() of an empty report = %q, want %q", got, "No changes.\n")
+	}
+}
@@ -0,0 +1,44 @@
+// Package snapshot captures the catalog of a ContextForge instance to a file
+// and compares captures offline, for reviewing what changed between two
+// points in time.
+//
+// Take lists every gateway, tool, resource, prompt, server, agent, and team,
+// including inactive ones, through the existing List methods. WriteFile
+// saves the result as indented JSON with the entities of each kind sorted,
+// so that snapshots of the same state differ only in their TakenAt time and
+// can be kept under version control:
+//
+//	s, err := snapshot.Take(ctx, client)
+//	if err != nil {
+//		return err
+//	}
+//	if err := s.WriteFile("catalog-snapshot.json"); err != nil {
+//		return err
+//	}
+//
+// # Comparing snapshots
+//
+// Diff matches the entities of two snapshots by name, or by URI for
+// resources, and compares them field by field using their JSON field names.
+// Nested objects such as tool input schemas are compared key by key; arrays
+// are compared as a whole. Fields that change without the catalog changing,
+// such as updatedAt, modifiedFromIp, and usage metrics, are ignored.
+//
+//	before, err := snapshot.ReadFile("catalog-snapshot.json")
+//	if err != nil {
+//		return err
+//	}
+//	report, err := snapshot.Diff(before, after)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Print(report)
+//	// Diff: 1 added, 0 removed, 1 modified.
+//	// + tool "weather"
+//	// ~ tool "search"
+//	//     description: "Search docs" -> "Search the docs"
+//	//     inputSchema.properties.limit: (unset) -> {"type":"integer"}
+//
+// Snapshots contain whatever the List endpoints return, which can include
+// gateway authentication settings, so treat snapshot files as sensitive.
+package snapshot
@@ -0,0 +1,157 @@
+package snapshot
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+// Snapshot is the catalog state of a ContextForge instance at a point in
+// time. Entities of each kind are sorted by name, or by URI for resources.
+type Snapshot struct {
+	TakenAt time.Time `json:"takenAt"`
+	Address string    `json:"address,omitempty"`
+
+	Gateways  []*contextforge.Gateway  `json:"gateways"`
+	Tools     []*contextforge.Tool     `json:"tools"`
+	Resources []*contextforge.Resource `json:"resources"`
+	Prompts   []*contextforge.Prompt   `json:"prompts"`
+	Servers   []*contextforge.Server   `json:"servers"`
+	Agents    []*contextforge.Agent    `json:"agents"`
+	Teams     []*contextforge.Team     `json:"teams"`
+}
+
+// Take lists every gateway, tool, resource, prompt, server, agent, and team
+// visible to client, including inactive ones, and returns them as a
+// Snapshot.
+func Take(ctx context.Context, client *contextforge.Client) (*Snapshot, error) {
+	s := &Snapshot{TakenAt: time.Now().UTC(), Address: client.Address.String()}
+
+	var err error
+	if s.Gateways, err = collect(client.Gateways.All(ctx, &contextforge.GatewayListOptions{IncludeInactive: true})); err != nil {
+		return nil, fmt.Errorf("list gateways; %w", err)
+	}
+	if s.Tools, err = collect(client.Tools.All(ctx, &contextforge.ToolListOptions{IncludeInactive: true})); err != nil {
+		return nil, fmt.Errorf("list tools; %w", err)
+	}
+	if s.Resources, err = collect(client.Resources.All(ctx, &contextforge.ResourceListOptions{IncludeInactive: true})); err != nil {
+		return nil, fmt.Errorf("list resources; %w", err)
+	}
+	if s.Prompts, err = collect(client.Prompts.All(ctx, &contextforge.PromptListOptions{IncludeInactive: true})); err != nil {
+		return nil, fmt.Errorf("list prompts; %w", err)
+	}
+	if s.Servers, err = collect(client.Servers.All(ctx, &contextforge.ServerListOptions{IncludeInactive: true})); err != nil {
+		return nil, fmt.Errorf("list servers; %w", err)
+	}
+	if s.Agents, err = collect(client.Agents.All(ctx, &contextforge.AgentListOptions{IncludeInactive: true})); err != nil {
+		return nil, fmt.Errorf("list agents; %w", err)
+	}
+	if s.Teams, err = collect(client.Teams.All(ctx, nil)); err != nil {
+		return nil, fmt.Errorf("list teams; %w", err)
+	}
+
+	s.sort()
+	return s, nil
+}
+
+func collect[T any](seq iter.Seq2[*T, error]) ([]*T, error) {
+	items := []*T{}
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// sort orders the entities of each kind by key, breaking ties by ID, so that
+// snapshots of the same state are written identically.
+func (s *Snapshot) sort() {
+	sortBy(s.Gateways, func(g *contextforge.Gateway) (string, string) { return g.Name, deref(g.ID) })
+	sortBy(s.Tools, func(t *contextforge.Tool) (string, string) { return t.Name, t.ID })
+	sortBy(s.Resources, func(r *contextforge.Resource) (string, string) {
+		if r.ID == nil {
+			return r.URI, ""
+		}
+		return r.URI, r.ID.String()
+	})
+	sortBy(s.Prompts, func(p *contextforge.Prompt) (string, string) { return p.Name, p.ID })
+	sortBy(s.Servers, func(v *contextforge.Server) (string, string) { return v.Name, v.ID })
+	sortBy(s.Agents, func(a *contextforge.Agent) (string, string) { return a.Name, a.ID })
+	sortBy(s.Teams, func(t *contextforge.Team) (string, string) { return t.Name, t.ID })
+}
+
+func sortBy[T any](items []*T, key func(*T) (string, string)) {
+	slices.SortStableFunc(items, func(a, b *T) int {
+		ka, ida := key(a)
+		kb, idb := key(b)
+		return cmp.Or(cmp.Compare(ka, kb), cmp.Compare(ida, idb))
+	})
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// Write writes s to w as indented JSON. Entities are sorted first, and
+// object keys are written in a fixed order, so the output of two snapshots
+// of the same state differs only in TakenAt.
+func (s *Snapshot) Write(w io.Writer) error {
+	s.sort()
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode snapshot; %w", err)
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// WriteFile writes s to the named file, creating or truncating it.
+func (s *Snapshot) WriteFile(name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := s.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Read reads a snapshot written by Write.
+func Read(r io.Reader) (*Snapshot, error) {
+	var s Snapshot
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("decode snapshot; %w", err)
+	}
+	s.sort()
+	return &s, nil
+}
+
+// ReadFile reads a snapshot from the named file.
+func ReadFile(name string) (*Snapshot, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s, err := Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return s, nil
+}
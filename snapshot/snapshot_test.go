@@ -0,0 +1,150 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+// testState is the state served to Take, keyed by the pattern that serves it.
+var testState = map[string]string{
+	"GET /gateways": `[{"id":"g1","name":"weather","url":"http://weather.internal/mcp","enabled":true}]`,
+	"GET /tools": `[
+		{"id":"t2","name":"search","description":"Search docs","enabled":true,"tags":["docs"]},
+		{"id":"t1","name":"fetch","enabled":false}
+	]`,
+	"GET /resources": `[{"id":8,"uri":"file:///b","name":"b","isActive":true},{"id":7,"uri":"file:///a","name":"a","isActive":true}]`,
+	"GET /prompts":   `[{"id":"p1","name":"greet","template":"Hello","arguments":[],"isActive":true}]`,
+	"GET /servers":   `[{"id":"s1","name":"docs","associatedTools":["t2"],"isActive":true}]`,
+	"GET /a2a":       `[{"id":"a1","name":"helper","slug":"helper","endpointUrl":"http://agents.internal","agentType":"generic","protocolVersion":"1.0","enabled":true,"reachable":true}]`,
+	"GET /teams":     `{"teams":[{"id":"tm1","name":"Platform","slug":"platform","is_personal":false,"member_count":2,"is_active":true,"created_by":"admin"}],"total":1}`,
+}
+
+func setup(t *testing.T) *contextforge.Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	for pattern, body := range testState {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/teams" && r.URL.Query().Get("include_inactive") != "true" {
+				t.Errorf("%s requested without include_inactive", r.URL.Path)
+			}
+			fmt.Fprint(w, body)
+		})
+	}
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := contextforge.NewClient(nil, server.URL, "test-token")
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	return client
+}
+
+func TestTake(t *testing.T) {
+	client := setup(t)
+
+	s, err := Take(context.Background(), client)
+	if err != nil {
+		t.Fatalf("Take returned error: %v", err)
+	}
+
+	counts := []int{len(s.Gateways), len(s.Tools), len(s.Resources), len(s.Prompts), len(s.Servers), len(s.Agents), len(s.Teams)}
+	if want := []int{1, 2, 2, 1, 1, 1, 1}; !reflect.DeepEqual(counts, want) {
+		t.Errorf("Take counts = %v, want %v", counts, want)
+	}
+	if s.Tools[0].Name != "fetch" || s.Tools[1].Name != "search" {
+		t.Errorf("Take tools = %s, %s; want them sorted by name", s.Tools[0].Name, s.Tools[1].Name)
+	}
+	if s.Resources[0].URI != "file:///a" {
+		t.Errorf("Take resources[0] = %s, want them sorted by URI", s.Resources[0].URI)
+	}
+	if s.TakenAt.IsZero() || !strings.HasPrefix(s.Address, "http://127.0.0.1") {
+		t.Errorf("Take TakenAt, Address = %v, %q", s.TakenAt, s.Address)
+	}
+}
+
+func TestTake_Error(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+	client, err := contextforge.NewClient(nil, server.URL, "test-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Take(context.Background(), client)
+	if err == nil || !strings.HasPrefix(err.Error(), "list gateways; ") {
+		t.Errorf("Take error = %v, want a list gateways error", err)
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	client := setup(t)
+
+	first, err := Take(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := Take(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Snapshots of the same state are written identically once their
+	// capture times match, whatever order the entities are in.
+	second.TakenAt = first.TakenAt
+	second.Tools[0], second.Tools[1] = second.Tools[1], second.Tools[0]
+	var a, b bytes.Buffer
+	if err := first.Write(&a); err != nil {
+		t.Fatal(err)
+	}
+	if err := second.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	if a.String() != b.String() {
+		t.Errorf("Write output differs for the same state:\n%s\n%s", a.String(), b.String())
+	}
+
+	name := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := first.WriteFile(name); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+	read, err := ReadFile(name)
+	if err != nil {
+		t.Fatalf("ReadFile returned error: %v", err)
+	}
+	if !read.TakenAt.Equal(first.TakenAt.Truncate(time.Nanosecond)) {
+		t.Errorf("ReadFile TakenAt = %v, want %v", read.TakenAt, first.TakenAt)
+	}
+	report, err := Diff(first, read)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Empty() {
+		t.Errorf("Diff after a round trip = %s, want no changes", report)
+	}
+}
+
+func TestReadFile_Invalid(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := (&Snapshot{}).WriteFile(name); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadFile(name); err != nil {
+		t.Errorf("ReadFile of an empty snapshot returned error: %v", err)
+	}
+
+	if _, err := Read(strings.NewReader("{")); err == nil {
+		t.Error("Read of invalid JSON returned no error")
+	}
+}
@@ -0,0 +1,98 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+// TestAuthService_LoginAndMe verifies that email login returns a usable token
+// and that the current user can be retrieved.
+func TestAuthService_LoginAndMe(t *testing.T) {
+	skipIfNotIntegration(t)
+
+	client := setupClient(t)
+	ctx := context.Background()
+
+	login, _, err := client.Auth.Login(ctx, &contextforge.LoginRequest{
+		Email:    getAdminEmail(),
+		Password: getAdminPassword(),
+	})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	if login.AccessToken == "" {
+		t.Fatal("Login returned empty access token")
+	}
+
+	me, _, err := client.Auth.Me(ctx)
+	if err != nil {
+		t.Fatalf("Me failed: %v", err)
+	}
+	if me.Email != getAdminEmail() {
+		t.Errorf("Expected email %q, got %q", getAdminEmail(), me.Email)
+	}
+}
+
+// TestAuthService_TokenLifecycle creates, lists, uses, and revokes an API token.
+func TestAuthService_TokenLifecycle(t *testing.T) {
+	skipIfNotIntegration(t)
+
+	client := setupClient(t)
+	ctx := context.Background()
+
+	created, _, err := client.Auth.CreateToken(ctx, &contextforge.APITokenCreate{
+		Name:          "integration-token-" + uniqueSuffix(),
+		Description:   contextforge.String("Created by integration tests"),
+		ExpiresInDays: contextforge.Int(1),
+	})
+	if err != nil {
+		t.Fatalf("CreateToken failed: %v", err)
+	}
+	if created.AccessToken == "" || created.Token == nil {
+		t.Fatalf("CreateToken returned incomplete response: %+v", created)
+	}
+	tokenID := created.Token.ID
+	t.Cleanup(func() {
+		client.Auth.RevokeToken(context.Background(), tokenID, nil)
+	})
+
+	if created.Token.ExpiresAt == nil {
+		t.Error("Expected created token to have an expiry")
+	}
+
+	tokens, _, err := client.Auth.ListTokens(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListTokens failed: %v", err)
+	}
+	found := false
+	for _, tok := range tokens {
+		if tok.ID == tokenID {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Created token %s not found in ListTokens", tokenID)
+	}
+
+	apiClient, err := contextforge.NewClient(testHTTPClient(t), getAddress(), created.AccessToken)
+	if err != nil {
+		t.Fatalf("Failed to create client with API token: %v", err)
+	}
+	if _, _, err := apiClient.Tools.List(ctx, nil); err != nil {
+		t.Errorf("Tools.List with API token failed: %v", err)
+	}
+
+	if _, err := client.Auth.RevokeToken(ctx, tokenID, contextforge.String("integration test")); err != nil {
+		t.Fatalf("RevokeToken failed: %v", err)
+	}
+
+	got, _, err := client.Auth.GetToken(ctx, tokenID)
+	if err == nil && got.IsActive && !got.IsRevoked {
+		t.Errorf("Expected token %s to be revoked", tokenID)
+	}
+}
@@ -5,10 +5,8 @@ package integration
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"testing"
-	"time"
 
 	"github.com/leefowlercu/go-contextforge/contextforge"
 )
@@ -21,7 +19,7 @@ func TestCancellationService_Basic(t *testing.T) {
 	client := setupClient(t)
 	ctx := context.Background()
 
-	requestID := fmt.Sprintf("integration-cancel-%d", time.Now().UnixNano())
+	requestID := "integration-cancel-" + uniqueSuffix()
 	reason := "integration-test"
 
 	result, _, err := client.Cancel.Cancel(ctx, &contextforge.CancellationRequest{
@@ -21,7 +21,7 @@ func TestClient_Authentication(t *testing.T) {
 			t.Fatal("Expected non-empty JWT token")
 		}
 
-		client, err := contextforge.NewClient(nil, getAddress(), token)
+		client, err := contextforge.NewClient(testHTTPClient(t), getAddress(), token)
 		if err != nil {
 			t.Fatalf("Failed to create client: %v", err)
 		}
@@ -44,7 +44,7 @@ func TestClient_Authentication(t *testing.T) {
 		// Test environment has AUTH_REQUIRED=false for easier testing
 		t.Skip("Skipping - test environment has AUTH_REQUIRED=false which allows unauthenticated requests")
 
-		client, err := contextforge.NewClient(nil, getAddress(), "") // No token
+		client, err := contextforge.NewClient(testHTTPClient(t), getAddress(), "") // No token
 		if err != nil {
 			t.Fatalf("Failed to create client: %v", err)
 		}
@@ -66,7 +66,7 @@ func TestClient_Authentication(t *testing.T) {
 	})
 
 	t.Run("request with invalid token", func(t *testing.T) {
-		client, err := contextforge.NewClient(nil, getAddress(), "invalid-token-12345")
+		client, err := contextforge.NewClient(testHTTPClient(t), getAddress(), "invalid-token-12345")
 		if err != nil {
 			t.Fatalf("Failed to create client: %v", err)
 		}
@@ -0,0 +1,58 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+// TestExportService_ExportImport exports a tool and imports it back in
+// dry-run mode.
+func TestExportService_ExportImport(t *testing.T) {
+	skipIfNotIntegration(t)
+
+	client := setupClient(t)
+	ctx := context.Background()
+
+	tool := createTestTool(t, client, randomToolName())
+	t.Cleanup(func() { cleanupTool(t, client, tool.ID) })
+
+	doc, _, err := client.Export.Export(ctx, &contextforge.ExportOptions{
+		Types: []string{contextforge.ExportTypeTools},
+	})
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if doc.Version == "" {
+		t.Error("Export returned a document without a version")
+	}
+
+	found := false
+	for _, e := range doc.Entities.Tools {
+		if e.Name() == tool.Name {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Export did not include tool %q", tool.Name)
+	}
+	if len(doc.Entities.Gateways) > 0 {
+		t.Errorf("Export included %d gateways, want only tools", len(doc.Entities.Gateways))
+	}
+
+	result, _, err := client.Export.Import(ctx, doc, &contextforge.ImportOptions{
+		ConflictStrategy: contextforge.ConflictSkip,
+		DryRun:           true,
+	})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if result.Progress == nil || result.Progress.Total == 0 {
+		t.Errorf("Import progress = %+v, want the exported tools counted", result.Progress)
+	}
+	t.Logf("Dry-run import %s: %s, warnings: %v", result.ImportID, result.Status, result.Warnings)
+}
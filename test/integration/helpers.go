@@ -4,17 +4,21 @@
 package integration
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
+	"hash/fnv"
+	"io/fs"
 	"net/http"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/leefowlercu/go-contextforge/cassette"
 	"github.com/leefowlercu/go-contextforge/contextforge"
+	"github.com/leefowlercu/go-contextforge/jsonschema"
 )
 
 const (
@@ -29,13 +33,118 @@ const (
 	testTeamNamePrefix     = "test-team"
 )
 
-// skipIfNotIntegration skips the test if INTEGRATION_TESTS is not set to "true"
+// cassetteDir holds the cassettes recorded by the integration tests, one per test
+const cassetteDir = "testdata/cassettes"
+
+// skipIfNotIntegration skips the test if INTEGRATION_TESTS is not set to "true",
+// unless the tests replay cassettes, which needs no server
 func skipIfNotIntegration(t *testing.T) {
-	if os.Getenv("INTEGRATION_TESTS") != "true" {
+	if os.Getenv("INTEGRATION_TESTS") != "true" && !replayingCassettes() {
 		t.Skip("Skipping integration test. Set INTEGRATION_TESTS=true to run.")
 	}
 }
 
+// cassetteMode returns the mode set by CONTEXTFORGE_CASSETTES (replay, record, or
+// auto), and false if it is not set
+func cassetteMode() (cassette.Mode, bool) {
+	value := os.Getenv("CONTEXTFORGE_CASSETTES")
+	if value == "" {
+		return 0, false
+	}
+	mode, err := cassette.ParseMode(value)
+	if err != nil {
+		panic(fmt.Sprintf("CONTEXTFORGE_CASSETTES: %v", err))
+	}
+	return mode, true
+}
+
+// replayingCassettes reports whether the tests replay cassettes instead of
+// contacting a live server
+func replayingCassettes() bool {
+	mode, ok := cassetteMode()
+	return ok && mode == cassette.ModeReplay
+}
+
+var (
+	recordersMu sync.Mutex
+	recorders   = map[*testing.T]*cassette.Recorder{}
+
+	// names generates the unique suffixes of test entity names
+	names nameSequence
+)
+
+// testHTTPClient returns the HTTP client for the requests of t. When
+// CONTEXTFORGE_CASSETTES is set, the client records or replays the cassette of t,
+// which is saved when t finishes; otherwise it is nil, the default client. When
+// replaying, t fails if its cassette has not been recorded.
+func testHTTPClient(t *testing.T) *http.Client {
+	t.Helper()
+
+	mode, ok := cassetteMode()
+	if !ok {
+		return nil
+	}
+
+	recordersMu.Lock()
+	defer recordersMu.Unlock()
+	if rec, ok := recorders[t]; ok {
+		return rec.Client()
+	}
+
+	filename := filepath.Join(cassetteDir, filepath.FromSlash(t.Name())+".json")
+	if mode == cassette.ModeReplay {
+		if _, err := os.Stat(filename); errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("No cassette for %s. Run make integration-test-record to record it.", t.Name())
+		}
+	}
+	rec, err := cassette.New(filename, mode)
+	if err != nil {
+		t.Fatalf("Failed to open cassette: %v", err)
+	}
+	recorders[t] = rec
+	names.reset(t.Name())
+	t.Cleanup(func() {
+		recordersMu.Lock()
+		delete(recorders, t)
+		recordersMu.Unlock()
+		if err := rec.Stop(); err != nil {
+			t.Errorf("Failed to save cassette: %v", err)
+		}
+	})
+	return rec.Client()
+}
+
+// nameSequence generates suffixes that make the names of test entities unique.
+// With cassettes, the suffixes must be the same on every run so that replayed
+// requests match the recorded URLs, so they are derived from the test name
+// and a counter instead of the clock.
+type nameSequence struct {
+	mu     sync.Mutex
+	prefix string
+	n      int
+}
+
+func (s *nameSequence) reset(testName string) {
+	h := fnv.New32a()
+	h.Write([]byte(testName))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prefix = fmt.Sprintf("%08x", h.Sum32())
+	s.n = 0
+}
+
+// uniqueSuffix returns a suffix for the name of a test entity
+func uniqueSuffix() string {
+	if _, ok := cassetteMode(); !ok {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	names.mu.Lock()
+	defer names.mu.Unlock()
+	names.n++
+	return fmt.Sprintf("%s-%d", names.prefix, names.n)
+}
+
 // getAddress returns the address for the ContextForge API
 func getAddress() string {
 	if url := os.Getenv("CONTEXTFORGE_ADDR"); url != "" {
@@ -60,51 +169,28 @@ func getAdminPassword() string {
 	return defaultAdminPass
 }
 
-// loginResponse represents the response from the login endpoint
-type loginResponse struct {
-	AccessToken string `json:"access_token"`
-	TokenType   string `json:"token_type"`
+// newTestTokenSource returns a token source that logs in with the admin credentials
+func newTestTokenSource(t *testing.T) *contextforge.PasswordTokenSource {
+	t.Helper()
+
+	ts, err := contextforge.NewPasswordTokenSource(testHTTPClient(t), getAddress(), getAdminEmail(), getAdminPassword())
+	if err != nil {
+		t.Fatalf("Failed to create token source: %v", err)
+	}
+	return ts
 }
 
 // getTestToken authenticates with the ContextForge API and returns a JWT token
 func getTestToken(t *testing.T) string {
 	t.Helper()
 
-	address := getAddress()
-	loginURL := address + "auth/login"
-
-	loginReq := map[string]string{
-		"username": getAdminEmail(),
-		"password": getAdminPassword(),
-	}
-
-	body, err := json.Marshal(loginReq)
-	if err != nil {
-		t.Fatalf("Failed to marshal login request: %v", err)
-	}
-
-	resp, err := http.Post(loginURL, "application/json", bytes.NewBuffer(body))
+	token, err := newTestTokenSource(t).Token(context.Background())
 	if err != nil {
 		t.Fatalf("Failed to login: %v", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		t.Fatalf("Login failed with status %d: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	var loginResp loginResponse
-	if err := json.NewDecoder(resp.Body).Decode(&loginResp); err != nil {
-		t.Fatalf("Failed to decode login response: %v", err)
-	}
-
-	if loginResp.AccessToken == "" {
-		t.Fatal("Login response did not contain access token")
-	}
 
 	t.Logf("Successfully obtained JWT token")
-	return loginResp.AccessToken
+	return token
 }
 
 // setupClient creates an authenticated ContextForge client for testing
@@ -112,8 +198,10 @@ func setupClient(t *testing.T) *contextforge.Client {
 	t.Helper()
 	skipIfNotIntegration(t)
 
-	token := getTestToken(t)
-	client, err := contextforge.NewClient(nil, getAddress(), token)
+	client, err := contextforge.NewClientWithOptions(getAddress(),
+		contextforge.WithHTTPClient(testHTTPClient(t)),
+		contextforge.WithTokenSource(newTestTokenSource(t)),
+	)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
@@ -124,7 +212,7 @@ func setupClient(t *testing.T) *contextforge.Client {
 
 // randomToolName generates a unique tool name for testing
 func randomToolName() string {
-	return fmt.Sprintf("%s-%d", testToolNamePrefix, time.Now().UnixNano())
+	return fmt.Sprintf("%s-%s", testToolNamePrefix, uniqueSuffix())
 }
 
 // minimalToolInput returns a minimal valid tool input for testing
@@ -149,20 +237,10 @@ func completeToolInput() *contextforge.Tool {
 	return &contextforge.Tool{
 		Name:        randomToolName(),
 		Description: contextforge.String("A complete test tool with all fields"),
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"input": map[string]any{
-					"type":        "string",
-					"description": "Test input parameter",
-				},
-				"count": map[string]any{
-					"type":        "integer",
-					"description": "Test count parameter",
-				},
-			},
-			"required": []string{"input"},
-		},
+		InputSchema: jsonschema.Object().
+			RequiredProperty("input", jsonschema.String().Description("Test input parameter")).
+			Property("count", jsonschema.Integer().Description("Test count parameter")).
+			Map(),
 		Visibility: "public",
 		Tags:       contextforge.NewTags([]string{"test", "integration"}),
 		TeamID:     contextforge.String("test-team"),
@@ -224,7 +302,7 @@ func cleanupTools(t *testing.T, client *contextforge.Client, toolIDs []string) {
 
 // randomGatewayName generates a unique gateway name for testing
 func randomGatewayName() string {
-	return fmt.Sprintf("%s-%d", testGatewayNamePrefix, time.Now().UnixNano())
+	return fmt.Sprintf("%s-%s", testGatewayNamePrefix, uniqueSuffix())
 }
 
 // minimalGatewayInput returns a minimal valid gateway input for testing
@@ -303,13 +381,13 @@ func cleanupGateways(t *testing.T, client *contextforge.Client, gatewayIDs []str
 
 // randomResourceName generates a unique resource name for testing
 func randomResourceName() string {
-	return fmt.Sprintf("%s-%d", testResourceNamePrefix, time.Now().UnixNano())
+	return fmt.Sprintf("%s-%s", testResourceNamePrefix, uniqueSuffix())
 }
 
 // minimalResourceInput returns a minimal valid resource input for testing
 func minimalResourceInput() *contextforge.ResourceCreate {
 	return &contextforge.ResourceCreate{
-		URI:         fmt.Sprintf("file:///test-%d.txt", time.Now().UnixNano()),
+		URI:         fmt.Sprintf("file:///test-%s.txt", uniqueSuffix()),
 		Name:        randomResourceName(),
 		Content:     "test content",
 		Description: contextforge.String("A test resource for integration testing"),
@@ -319,7 +397,7 @@ func minimalResourceInput() *contextforge.ResourceCreate {
 // completeResourceInput returns a resource input with all optional fields for testing
 func completeResourceInput() *contextforge.ResourceCreate {
 	return &contextforge.ResourceCreate{
-		URI:         fmt.Sprintf("file:///complete-%d.txt", time.Now().UnixNano()),
+		URI:         fmt.Sprintf("file:///complete-%s.txt", uniqueSuffix()),
 		Name:        randomResourceName(),
 		Content:     "complete test content",
 		Description: contextforge.String("A complete test resource with all fields"),
@@ -383,7 +461,7 @@ func cleanupResources(t *testing.T, client *contextforge.Client, resourceIDs []s
 
 // randomServerName generates a unique server name for testing
 func randomServerName() string {
-	return fmt.Sprintf("%s-%d", testServerNamePrefix, time.Now().UnixNano())
+	return fmt.Sprintf("%s-%s", testServerNamePrefix, uniqueSuffix())
 }
 
 // minimalServerInput returns a minimal valid server input for testing
@@ -455,7 +533,7 @@ const testPromptNamePrefix = "test-prompt"
 
 // randomPromptName generates a unique prompt name for testing
 func randomPromptName() string {
-	return fmt.Sprintf("%s-%d", testPromptNamePrefix, time.Now().UnixNano())
+	return fmt.Sprintf("%s-%s", testPromptNamePrefix, uniqueSuffix())
 }
 
 // minimalPromptInput returns a minimal valid prompt input for testing
@@ -537,7 +615,7 @@ func cleanupPrompts(t *testing.T, client *contextforge.Client, promptIDs []strin
 
 // randomAgentName generates a unique agent name for testing
 func randomAgentName() string {
-	return fmt.Sprintf("%s-%d", testAgentNamePrefix, time.Now().UnixNano())
+	return fmt.Sprintf("%s-%s", testAgentNamePrefix, uniqueSuffix())
 }
 
 // minimalAgentInput returns a minimal valid agent input for testing
@@ -625,7 +703,7 @@ func cleanupAgents(t *testing.T, client *contextforge.Client, agentIDs []string)
 
 // randomTeamName generates a unique team name for testing
 func randomTeamName() string {
-	return fmt.Sprintf("%s-%d", testTeamNamePrefix, time.Now().UnixNano())
+	return fmt.Sprintf("%s-%s", testTeamNamePrefix, uniqueSuffix())
 }
 
 // minimalTeamInput returns a minimal valid team input for testing
@@ -10,8 +10,8 @@ import (
 
 // TestMain sets up and tears down the mock MCP server for all integration tests
 func TestMain(m *testing.M) {
-	// Skip if not running integration tests
-	if os.Getenv("INTEGRATION_TESTS") != "true" {
+	// Skip if not running integration tests or replaying cassettes
+	if os.Getenv("INTEGRATION_TESTS") != "true" && !replayingCassettes() {
 		os.Exit(m.Run())
 	}
 
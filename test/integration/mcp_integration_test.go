@@ -0,0 +1,78 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/leefowlercu/go-contextforge/contextforge"
+)
+
+// TestServersService_Connect opens an MCP session with a virtual server and
+// exercises the initialize handshake and tools/list.
+func TestServersService_Connect(t *testing.T) {
+	skipIfNotIntegration(t)
+
+	client := setupClient(t)
+	ctx := context.Background()
+
+	server := createTestServer(t, client, randomServerName())
+
+	session, err := client.Servers.Connect(ctx, server.ID, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect MCP session: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := session.Close(context.Background()); err != nil {
+			t.Logf("Warning: failed to close MCP session: %v", err)
+		}
+	})
+
+	init := session.InitializeResult()
+	if init.ProtocolVersion == "" {
+		t.Error("Expected negotiated protocol version")
+	}
+	t.Logf("Connected to %+v (session %q, protocol %s)", init.ServerInfo, session.SessionID(), init.ProtocolVersion)
+
+	tools, err := session.ListTools(ctx, "")
+	if err != nil {
+		t.Fatalf("Failed to list tools over MCP: %v", err)
+	}
+	// The test server has no associated tools.
+	t.Logf("Server exposes %d tools", len(tools.Tools))
+}
+
+// TestServersService_Connect_SSE opens an MCP session with a virtual server
+// over the legacy SSE transport.
+func TestServersService_Connect_SSE(t *testing.T) {
+	skipIfNotIntegration(t)
+
+	client := setupClient(t)
+	ctx := context.Background()
+
+	server := createTestServer(t, client, randomServerName())
+
+	session, err := client.Servers.Connect(ctx, server.ID, &contextforge.MCPSessionOptions{
+		Transport: contextforge.MCPTransportSSE,
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect MCP session over SSE: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := session.Close(context.Background()); err != nil {
+			t.Logf("Warning: failed to close MCP session: %v", err)
+		}
+	})
+
+	if session.SessionID() == "" {
+		t.Error("Expected session ID from the SSE endpoint event")
+	}
+
+	tools, err := session.ListTools(ctx, "")
+	if err != nil {
+		t.Fatalf("Failed to list tools over SSE: %v", err)
+	}
+	t.Logf("Server exposes %d tools", len(tools.Tools))
+}